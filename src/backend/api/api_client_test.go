@@ -0,0 +1,110 @@
+// src/backend/api/api_client_test.go
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"tubes2stima/backend/client"
+	"tubes2stima/backend/dataset"
+)
+
+// TestAPIClient menjalankan package client terhadap server sungguhan.
+func TestAPIClient(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+	fakeImageFetcher(t, func(url string) (CachedImage, error) { return pngImage, nil })
+	t.Setenv(adminTokenEnv, "rahasia")
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	c := client.New(server.URL + "/")
+	c.AdminToken = "rahasia"
+	ctx := context.Background()
+
+	res, err := c.Search(ctx, client.SearchParams{Target: "stone", Mode: "multiple", Max: 2})
+	if err != nil || !res.PathFound || res.SearchTarget != "Stone" || len(res.AllPaths()) != 2 {
+		t.Fatalf("Search = %+v, %v", res, err)
+	}
+	if res, err := c.Search(ctx, client.SearchParams{Target: "Brick", Avoid: []string{"Mud"}, Require: []string{"Stone"}}); err != nil || len(res.Avoid) != 1 || len(res.Require) != 1 {
+		t.Errorf("Search avoid/require = %+v, %v", res, err)
+	}
+	var apiErr *client.Error
+	if _, err := c.Search(ctx, client.SearchParams{Target: "Bukanelemen"}); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Search elemen tidak dikenal: err = %v, ingin *client.Error 400", err)
+	}
+	if dot, err := c.SearchRendered(ctx, client.SearchParams{Target: "Mud"}, "dot"); err != nil || !bytes.Contains(dot, []byte("digraph")) {
+		t.Errorf("SearchRendered dot = %q, %v", dot, err)
+	}
+
+	if rel, err := c.Ancestors(ctx, "Brick", client.RelationParams{Contains: []string{"Fire"}}); err != nil || !rel.Contains["Fire"] {
+		t.Errorf("Ancestors = %+v, %v", rel, err)
+	}
+	if rel, err := c.Descendants(ctx, "Brick", client.RelationParams{Depth: 1}); err != nil || rel.MaxDepth != 1 || rel.Count == 0 {
+		t.Errorf("Descendants = %+v, %v", rel, err)
+	}
+	if req, err := c.Required(ctx, "Mud", ""); err != nil || req.Element != "Mud" {
+		t.Errorf("Required = %+v, %v", req, err)
+	}
+	if games, err := c.Games(ctx); err != nil || len(games) != len(dataset.Editions) || !games[0].Default {
+		t.Errorf("Games = %+v, %v", games, err)
+	}
+	if data, contentType, err := c.Image(ctx, "Mud", ""); err != nil || contentType != "image/png" || !bytes.Equal(data, pngImage.Data) {
+		t.Errorf("Image = %q, %q, %v", data, contentType, err)
+	}
+	diff, err := c.Diff(ctx, client.DiffRequest{New: []client.Recipe{{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}}}, "")
+	if err != nil || diff.NewRecipes != 1 || len(diff.RemovedRecipes) == 0 {
+		t.Errorf("Diff = %+v, %v", diff, err)
+	}
+	c.AdminToken = "salah"
+	if _, err := c.Diff(ctx, client.DiffRequest{New: []client.Recipe{{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}}}, ""); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Diff token salah: err = %v, ingin *client.Error 401", err)
+	}
+	if spec, err := c.OpenAPI(ctx); err != nil || !bytes.Equal(spec, openAPISpec) {
+		t.Errorf("OpenAPI: %v", err)
+	}
+
+	// Tipe client harus mencakup setiap field respons server
+	for path, out := range map[string]any{
+		"/api/search?target=Brick&mode=cheapest": &client.SearchResponse{},
+		"/api/search?target=Brick&algo=iddfs":    &client.SearchResponse{},
+		"/api/element/Brick/ancestors":           &client.ElementRelation{},
+		"/api/stats":                             &client.DatasetStats{},
+		"/api/meta":                              &client.Meta{},
+		"/api/datasets":                          &[]client.DatasetProfile{},
+		"/api/games":                             &[]client.GameInfo{},
+		"/api/export":                            &[]client.Recipe{},
+	} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(out); err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+	if _, err := c.Stats(ctx, "zz"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Stats game tidak dikenal: err = %v", err)
+	}
+	if _, err := c.Meta(ctx, ""); err != nil {
+		t.Errorf("Meta: %v", err)
+	}
+	if _, err := c.Datasets(ctx, ""); err != nil {
+		t.Errorf("Datasets: %v", err)
+	}
+	if csv, err := c.Export(ctx, "csv", ""); err != nil || !bytes.HasPrefix(csv, []byte("result,")) {
+		t.Errorf("Export csv = %.40q, %v", csv, err)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !debug
+
+// src/backend/api/debug_off.go
+package api
+
+// debugValidatePaths nonaktif pada build biasa. Lihat debug_on.go.
+const debugValidatePaths = false
@@ -0,0 +1,9 @@
+//go:build debug
+
+// src/backend/api/debug_on.go
+package api
+
+// debugValidatePaths aktif pada build dengan tag "debug" (go build -tags debug):
+// searchHandler memverifikasi setiap jalur hasil pencarian dengan ValidatePath
+// dan melaporkan pelanggarannya di field validationErrors.
+const debugValidatePaths = true
@@ -0,0 +1,192 @@
+// src/backend/api/diff.go
+package api
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/search"
+)
+
+// ElementChange mencatat perubahan satu nilai (tier atau panjang jalur
+// terpendek) sebuah elemen antara dua snapshot. -1 berarti elemen tidak bisa
+// dibuat di snapshot tersebut.
+type ElementChange struct {
+	Name string `json:"name"`
+	Old  int    `json:"old"`
+	New  int    `json:"new"`
+}
+
+// RecipeDiff adalah hasil perbandingan dua snapshot []dataset.Recipe. Resep
+// dibandingkan lewat getRecipeID, jadi urutan bahan dan duplikat diabaikan.
+type RecipeDiff struct {
+	OldRecipes int `json:"oldRecipes"` // Jumlah resep unik
+	NewRecipes int `json:"newRecipes"`
+
+	AddedRecipes    []dataset.Recipe `json:"addedRecipes"`
+	RemovedRecipes  []dataset.Recipe `json:"removedRecipes"`
+	AddedElements   []string         `json:"addedElements"`
+	RemovedElements []string         `json:"removedElements"`
+
+	// Hanya untuk elemen yang ada di kedua snapshot
+	TierChanges       []ElementChange `json:"tierChanges"`
+	PathLengthChanges []ElementChange `json:"pathLengthChanges"` // Panjang jalur BFS terpendek
+}
+
+// IsEmpty bernilai true jika kedua snapshot berisi resep yang sama.
+func (diff RecipeDiff) IsEmpty() bool {
+	return len(diff.AddedRecipes) == 0 && len(diff.RemovedRecipes) == 0
+}
+
+// recipeSnapshot adalah satu sisi perbandingan: resep unik per getRecipeID
+// beserta dataset dan tier yang dibangun darinya.
+type recipeSnapshot struct {
+	byID     map[string]dataset.Recipe
+	elements map[string]bool
+	dataset  *dataset.Dataset
+	tiers    map[string]int
+	// unreachableTier: tier yang diberikan calculateElementTiers untuk elemen
+	// yang tidak bisa dibuat
+	unreachableTier int
+}
+
+func newRecipeSnapshot(recipes []dataset.Recipe, baseElements []string) *recipeSnapshot {
+	s := &recipeSnapshot{byID: make(map[string]dataset.Recipe, len(recipes))}
+	inputRecipeMap := make(map[string][]dataset.Recipe)
+	for _, r := range recipes {
+		id := dataset.RecipeID(r)
+		if _, dup := s.byID[id]; dup {
+			continue
+		}
+		s.byID[id] = r
+		inputRecipeMap[r.Result] = append(inputRecipeMap[r.Result], r)
+	}
+	s.dataset = dataset.New(inputRecipeMap, baseElements)
+	unique := s.dataset.Recipes()
+	s.tiers, s.elements = dataset.ElementTiers(unique, s.dataset.BaseElements())
+	s.unreachableTier = len(unique) + 2
+	return s
+}
+
+func (s *recipeSnapshot) tier(element string) int {
+	if s.tiers[element] >= s.unreachableTier {
+		return -1
+	}
+	return s.tiers[element]
+}
+
+func (s *recipeSnapshot) pathLength(element string) int {
+	path, _, err := search.FindPathBFSCompact(s.dataset, element)
+	if err != nil {
+		return -1
+	}
+	return len(path)
+}
+
+// DiffRecipes membandingkan dua snapshot resep dengan elemen dasar
+// baseElements.
+func DiffRecipes(oldRecipes, newRecipes []dataset.Recipe, baseElements []string) RecipeDiff {
+	before, after := newRecipeSnapshot(oldRecipes, baseElements), newRecipeSnapshot(newRecipes, baseElements)
+	diff := RecipeDiff{
+		OldRecipes:        len(before.byID),
+		NewRecipes:        len(after.byID),
+		AddedRecipes:      []dataset.Recipe{},
+		RemovedRecipes:    []dataset.Recipe{},
+		AddedElements:     []string{},
+		RemovedElements:   []string{},
+		TierChanges:       []ElementChange{},
+		PathLengthChanges: []ElementChange{},
+	}
+
+	diff.AddedRecipes = append(diff.AddedRecipes, missingRecipes(after.byID, before.byID)...)
+	diff.RemovedRecipes = append(diff.RemovedRecipes, missingRecipes(before.byID, after.byID)...)
+
+	var common []string
+	for _, name := range sortedKeys(after.elements) {
+		if !before.elements[name] {
+			diff.AddedElements = append(diff.AddedElements, name)
+		} else {
+			common = append(common, name)
+		}
+	}
+	for _, name := range sortedKeys(before.elements) {
+		if !after.elements[name] {
+			diff.RemovedElements = append(diff.RemovedElements, name)
+		}
+	}
+
+	for _, name := range common {
+		if oldTier, newTier := before.tier(name), after.tier(name); oldTier != newTier {
+			diff.TierChanges = append(diff.TierChanges, ElementChange{Name: name, Old: oldTier, New: newTier})
+		}
+		if oldLen, newLen := before.pathLength(name), after.pathLength(name); oldLen != newLen {
+			diff.PathLengthChanges = append(diff.PathLengthChanges, ElementChange{Name: name, Old: oldLen, New: newLen})
+		}
+	}
+	return diff
+}
+
+// missingRecipes mengembalikan resep di from yang tidak ada di other,
+// terurut ID.
+func missingRecipes(from, other map[string]dataset.Recipe) []dataset.Recipe {
+	var ids []string
+	for id := range from {
+		if _, ok := other[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	recipes := make([]dataset.Recipe, len(ids))
+	for i, id := range ids {
+		recipes[i] = from[id]
+	}
+	return recipes
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WriteDiffText menulis hasil DiffRecipes dalam format teks.
+func WriteDiffText(w io.Writer, diff RecipeDiff) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Resep: %d -> %d (+%d, -%d)\n", diff.OldRecipes, diff.NewRecipes, len(diff.AddedRecipes), len(diff.RemovedRecipes))
+
+	writeRecipeList := func(title, sign string, recipes []dataset.Recipe) {
+		fmt.Fprintf(&b, "\n%s (%d)\n", title, len(recipes))
+		for _, r := range recipes {
+			fmt.Fprintf(&b, "  %s %s + %s = %s\n", sign, r.Ingredient1, r.Ingredient2, r.Result)
+		}
+	}
+	writeRecipeList("Resep baru", "+", diff.AddedRecipes)
+	writeRecipeList("Resep dihapus", "-", diff.RemovedRecipes)
+	dataset.WriteNameList(&b, "Elemen baru", diff.AddedElements)
+	dataset.WriteNameList(&b, "Elemen dihapus", diff.RemovedElements)
+
+	writeChanges := func(title string, changes []ElementChange) {
+		fmt.Fprintf(&b, "\n%s (%d)\n", title, len(changes))
+		for _, c := range changes {
+			fmt.Fprintf(&b, "  %s: %s -> %s\n", c.Name, diffValue(c.Old), diffValue(c.New))
+		}
+	}
+	writeChanges("Perubahan tier", diff.TierChanges)
+	writeChanges("Perubahan panjang jalur terpendek", diff.PathLengthChanges)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func diffValue(v int) string {
+	if v < 0 {
+		return "tidak bisa dibuat"
+	}
+	return fmt.Sprint(v)
+}
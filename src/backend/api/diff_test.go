@@ -0,0 +1,105 @@
+// src/backend/api/diff_test.go
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+func TestDiffRecipes(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	g := defaultGame(t)
+	recipes := g.Recipes()
+	if diff := DiffRecipes(recipes, recipes, dataset.BaseElements); !diff.IsEmpty() || len(diff.TierChanges) != 0 || len(diff.PathLengthChanges) != 0 {
+		t.Fatalf("snapshot yang sama menghasilkan diff: %+v", diff)
+	}
+
+	// Urutan bahan dibalik dan duplikat: tetap resep yang sama menurut dataset.RecipeID
+	var modified []dataset.Recipe
+	for _, r := range recipes {
+		if r.Result == "Metal" {
+			continue
+		}
+		modified = append(modified, dataset.Recipe{Result: r.Result, Ingredient1: r.Ingredient2, Ingredient2: r.Ingredient1})
+	}
+	modified = append(modified, modified[0], dataset.Recipe{Result: "Zeppelin", Ingredient1: "Balloon", Ingredient2: "Engine"})
+
+	diff := DiffRecipes(recipes, modified, dataset.BaseElements)
+	if diff.NewRecipes != diff.OldRecipes-len(g.Dataset().RecipeMap()["Metal"])+1 {
+		t.Errorf("jumlah resep %d -> %d", diff.OldRecipes, diff.NewRecipes)
+	}
+	if len(diff.AddedRecipes) != 1 || diff.AddedRecipes[0].Result != "Zeppelin" {
+		t.Errorf("resep baru = %v", diff.AddedRecipes)
+	}
+	if len(diff.RemovedRecipes) != len(g.Dataset().RecipeMap()["Metal"]) {
+		t.Errorf("resep dihapus = %v", diff.RemovedRecipes)
+	}
+	if len(diff.AddedElements) != 3 || len(diff.RemovedElements) != 0 {
+		t.Errorf("elemen baru = %v, dihapus = %v", diff.AddedElements, diff.RemovedElements)
+	}
+
+	changes := make(map[string]ElementChange)
+	for _, c := range diff.TierChanges {
+		changes[c.Name] = c
+	}
+	if c, ok := changes["Metal"]; !ok || c.New != -1 {
+		t.Errorf("tier Metal seharusnya menjadi tidak bisa dibuat: %+v", c)
+	}
+	if _, ok := changes["Mud"]; ok {
+		t.Errorf("tier Mud tidak seharusnya berubah")
+	}
+	if len(diff.PathLengthChanges) < len(diff.TierChanges) {
+		t.Errorf("%d perubahan panjang jalur, lebih sedikit dari %d perubahan tier", len(diff.PathLengthChanges), len(diff.TierChanges))
+	}
+}
+
+func TestAdminDiffHandler(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+	post := func(token string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/diff", bytes.NewBufferString(body))
+		if token != "" {
+			req.Header.Set("X-Admin-Token", token)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+	body := `{"new": [{"result": "Mud", "ingredient1": "Water", "ingredient2": "Earth"}]}`
+
+	t.Setenv(adminTokenEnv, "")
+	if rec := post("rahasia", body); rec.Code != http.StatusForbidden {
+		t.Errorf("tanpa ADMIN_TOKEN: status = %d, ingin 403", rec.Code)
+	}
+
+	t.Setenv(adminTokenEnv, "rahasia")
+	if rec := post("salah", body); rec.Code != http.StatusUnauthorized {
+		t.Errorf("token salah: status = %d, ingin 401", rec.Code)
+	}
+	if rec := post("rahasia", `{"old": []}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("tanpa 'new': status = %d, ingin 400", rec.Code)
+	}
+
+	rec := post("rahasia", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var diff RecipeDiff
+	if err := json.Unmarshal(rec.Body.Bytes(), &diff); err != nil {
+		t.Fatal(err)
+	}
+	// Tanpa 'old', snapshot lama adalah resep yang sedang dimuat
+	if diff.OldRecipes != len(defaultGame(t).Recipes()) || diff.NewRecipes != 1 || len(diff.AddedRecipes) != 0 {
+		t.Errorf("respons tidak sesuai: %d -> %d resep, %d baru", diff.OldRecipes, diff.NewRecipes, len(diff.AddedRecipes))
+	}
+}
@@ -0,0 +1,266 @@
+// src/backend/api/games.go
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/filter"
+	"tubes2stima/backend/search"
+)
+
+// GameData adalah data satu edisi yang sudah dimuat.
+type GameData struct {
+	Edition dataset.GameEdition
+
+	dataDir      string
+	dataset      *dataset.Dataset
+	imageMap     map[string]string
+	elementNames map[string]bool
+	integrity    dataset.DataIntegrityReport
+	costs        *search.CostTable
+	filterConfig filter.Config
+
+	// profileDatasets: dataset per profil filter (lihat DatasetForProfile)
+	profileMutex    sync.Mutex
+	profileDatasets map[string]*profileDataset
+}
+
+var (
+	// loadedDataDir adalah direktori data utama yang dimuat Init
+	loadedDataDir string
+
+	// strictDataValidation: jika true (flag -strict), pemuatan data gagal
+	// bila validasi data menemukan masalah tingkat error
+	strictDataValidation bool
+
+	// gameDataCache berisi edisi yang sudah berhasil dimuat. Kegagalan tidak
+	// di-cache agar data hasil scraping baru bisa langsung dipakai tanpa
+	// restart server.
+	gameDataCache = make(map[string]*GameData)
+	gameDataMutex sync.Mutex
+)
+
+// Init memuat data game default (la2) dari dataDir. Edisi lain dimuat dari
+// subdirektorinya saat pertama kali diminta lewat GetGame. Dengan strict,
+// pemuatan gagal jika validasi data menemukan masalah tingkat error.
+func Init(dataDir string, strict bool) error {
+	gameDataMutex.Lock()
+	defer gameDataMutex.Unlock()
+	loadedDataDir = dataDir
+	strictDataValidation = strict
+	clear(gameDataCache)
+
+	edition := dataset.DefaultEdition()
+	g, err := loadGameData(edition, dataDir)
+	if err != nil {
+		return err
+	}
+	gameDataCache[edition.Name] = g
+	return nil
+}
+
+// GetGame mengembalikan data edisi name ("" = dataset.DefaultGame). Edisi
+// default dimuat oleh Init; edisi lain dimuat dari subdirektorinya saat
+// pertama kali diminta. Error membungkus dataset.ErrUnknownGame untuk nama
+// yang tidak dikenal, atau os.ErrNotExist jika datanya belum ada.
+func GetGame(name string) (*GameData, error) {
+	edition, ok := dataset.FindEdition(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", dataset.ErrUnknownGame, name)
+	}
+
+	gameDataMutex.Lock()
+	defer gameDataMutex.Unlock()
+	if g, ok := gameDataCache[edition.Name]; ok {
+		return g, nil
+	}
+	if edition.Name == dataset.DefaultGame {
+		return nil, dataset.ErrNotInitialized
+	}
+	g, err := loadGameData(edition, filepath.Join(loadedDataDir, edition.DataDir))
+	if err != nil {
+		return nil, err
+	}
+	gameDataCache[edition.Name] = g
+	return g, nil
+}
+
+// loadGameData memuat dan membangun data satu edisi dari dataDir.
+func loadGameData(edition dataset.GameEdition, dataDir string) (*GameData, error) {
+	files, err := loadGameFiles(dataDir, edition)
+	if err != nil {
+		return nil, fmt.Errorf("game '%s': %w", edition.Name, err)
+	}
+	resultRecipes, imageURLs, elementNames := dataset.BuildMaps(files.recipes, files.images, edition.BaseElements)
+	return &GameData{
+		Edition:      edition,
+		dataDir:      dataDir,
+		dataset:      dataset.New(resultRecipes, edition.BaseElements),
+		imageMap:     imageURLs,
+		elementNames: elementNames,
+		integrity:    files.integrity,
+		costs:        files.costs,
+		filterConfig: files.filterConfig,
+	}, nil
+}
+
+// gameFiles adalah isi direktori data satu edisi game setelah validasi.
+type gameFiles struct {
+	recipes      []dataset.Recipe
+	images       []dataset.ElementImage
+	costs        *search.CostTable
+	filterConfig filter.Config
+	integrity    dataset.DataIntegrityReport
+}
+
+// loadGameFiles memuat resep, gambar, tabel biaya, dan profil filter dari
+// dataDir, lalu memvalidasi resep dan gambar.
+func loadGameFiles(dataDir string, edition dataset.GameEdition) (*gameFiles, error) {
+	fmt.Println("Memulai pemuatan data awal dari direktori:", dataDir)
+	files := &gameFiles{}
+
+	// Load resep
+	tempRecipes, err := dataset.LoadRecipes(filepath.Join(dataDir, dataset.FilteredRecipeFile))
+	if err != nil {
+		return nil, fmt.Errorf("gagal memuat resep: %w", err)
+	}
+	fmt.Printf("Berhasil memuat %d data resep.\n", len(tempRecipes))
+
+	// Load gambar
+	tempImages, err := dataset.LoadImages(filepath.Join(dataDir, "element_images_urls.json"))
+	if err != nil {
+		return nil, fmt.Errorf("gagal memuat gambar: %w", err)
+	}
+	fmt.Printf("Berhasil memuat %d data URL gambar.\n", len(tempImages))
+
+	// Load profil filter (opsional, untuk parameter dataset=)
+	if files.filterConfig, err = filter.LoadConfig(filepath.Join(dataDir, filter.ConfigFile), edition.BaseElements); err != nil {
+		return nil, fmt.Errorf("gagal memuat profil filter: %w", err)
+	}
+
+	// Validasi: buang duplikat/resep rusak, rapikan whitespace, laporkan sisanya
+	files.recipes, files.images, files.integrity = dataset.Validate(tempRecipes, tempImages, edition.BaseElements)
+	files.integrity.Strict = strictDataValidation
+	for _, problem := range files.integrity.Errors {
+		fmt.Printf("Peringatan data: %s\n", problem)
+	}
+	for _, problem := range files.integrity.Warnings {
+		fmt.Printf("Info data: %s\n", problem)
+	}
+	if strictDataValidation && len(files.integrity.Errors) > 0 {
+		return nil, fmt.Errorf("validasi data gagal (-strict): %s", strings.Join(files.integrity.Errors, "; "))
+	}
+
+	// Load tabel biaya (opsional, untuk mode cheapest); tier dihitung dari
+	// resep yang sudah divalidasi
+	if files.costs, err = search.LoadCostTable(filepath.Join(dataDir, search.CostTableFile), files.recipes, edition.BaseElements); err != nil {
+		return nil, fmt.Errorf("gagal memuat tabel biaya: %w", err)
+	}
+	return files, nil
+}
+
+// Dataset mengembalikan dataset resep edisi.
+func (g *GameData) Dataset() *dataset.Dataset {
+	return g.dataset
+}
+
+// Recipes mengembalikan semua resep edisi dalam urutan deterministik.
+func (g *GameData) Recipes() []dataset.Recipe {
+	return g.dataset.Recipes()
+}
+
+// ImageMap mengembalikan map nama elemen -> URL gambar asli.
+func (g *GameData) ImageMap() map[string]string {
+	return g.imageMap
+}
+
+// HasElement memeriksa apakah nama elemen dikenal di edisi ini.
+func (g *GameData) HasElement(name string) bool {
+	return g.elementNames[name]
+}
+
+// ElementNames mengembalikan nama semua elemen edisi, terurut.
+func (g *GameData) ElementNames() []string {
+	names := make([]string, 0, len(g.elementNames))
+	for name := range g.elementNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ElementCount mengembalikan jumlah elemen unik (resep, gambar, dan dasar).
+func (g *GameData) ElementCount() int {
+	return len(g.elementNames)
+}
+
+// Integrity mengembalikan laporan validasi data edisi.
+func (g *GameData) Integrity() dataset.DataIntegrityReport {
+	return g.integrity
+}
+
+// Costs mengembalikan tabel biaya mode cheapest edisi.
+func (g *GameData) Costs() *search.CostTable {
+	return g.costs
+}
+
+// FilterConfig mengembalikan profil filter edisi.
+func (g *GameData) FilterConfig() *filter.Config {
+	return &g.filterConfig
+}
+
+// ResolveElementName mencocokkan nama dari input pengguna dengan elemen edisi
+// ini (lihat resolveElementNameWith).
+func (g *GameData) ResolveElementName(name string) string {
+	return resolveElementNameWith(g.HasElement, name)
+}
+
+// profileDataset adalah dataset hasil satu profil filter, dibangun saat
+// pertama kali diminta lewat parameter dataset=.
+type profileDataset struct {
+	dataset *dataset.Dataset
+	result  filter.Result
+}
+
+// DatasetForProfile mengembalikan dataset game yang difilter dengan profil
+// name beserta hasil filternya. Hasil yang berhasil di-cache di GameData,
+// jadi ikut dibangun ulang saat edisi dimuat ulang; kegagalan tidak di-cache
+// agar recipes_scraped.json yang baru ditambahkan langsung terpakai.
+func (g *GameData) DatasetForProfile(name string) (*dataset.Dataset, filter.Result, error) {
+	profile := g.filterConfig.Profile(name)
+	if profile == nil {
+		return nil, filter.Result{}, fmt.Errorf("profil dataset '%s' tidak dikenal", name)
+	}
+
+	g.profileMutex.Lock()
+	defer g.profileMutex.Unlock()
+	if entry, ok := g.profileDatasets[name]; ok {
+		return entry.dataset, entry.result, nil
+	}
+
+	var source []dataset.Recipe
+	if profile.Source == "filtered" {
+		source = g.Recipes()
+	} else {
+		var err error
+		source, err = dataset.LoadRecipes(filepath.Join(g.dataDir, filter.ScrapedRecipeFile))
+		if err != nil {
+			return nil, filter.Result{}, err
+		}
+	}
+	result, err := profile.Run(source, g.Edition.BaseElements)
+	if err != nil {
+		return nil, filter.Result{}, err
+	}
+	entry := &profileDataset{dataset: dataset.FromRecipes(result.Recipes, g.Edition.BaseElements), result: result}
+	if g.profileDatasets == nil {
+		g.profileDatasets = make(map[string]*profileDataset)
+	}
+	g.profileDatasets[name] = entry
+	return entry.dataset, entry.result, nil
+}
@@ -0,0 +1,232 @@
+// src/backend/api/games_test.go
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/filter"
+)
+
+// useGameDataDir mengarahkan pemuatan edisi non-default ke dataDir selama test.
+func useGameDataDir(t *testing.T, dataDir string) {
+	t.Helper()
+	oldDir, oldCache := loadedDataDir, gameDataCache
+	loadedDataDir = dataDir
+	gameDataCache = map[string]*GameData{dataset.DefaultGame: oldCache[dataset.DefaultGame]}
+	t.Cleanup(func() {
+		loadedDataDir, gameDataCache = oldDir, oldCache
+	})
+}
+
+func writeJSONFile(t *testing.T, path string, v any) {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGameParameter(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	root := t.TempDir()
+	useGameDataDir(t, root)
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	// Data la1 belum ada
+	if rec := get("/api/search?target=Mud&game=la1"); rec.Code != http.StatusNotFound {
+		t.Fatalf("tanpa data la1: status = %d, ingin 404", rec.Code)
+	}
+	if rec := get("/api/search?target=Mud&game=la3"); rec.Code != http.StatusBadRequest {
+		t.Errorf("game tidak dikenal: status = %d, ingin 400", rec.Code)
+	}
+
+	la1Dir := filepath.Join(root, "la1")
+	if err := os.MkdirAll(la1Dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeJSONFile(t, filepath.Join(la1Dir, "recipes_final_filtered.json"), []dataset.Recipe{
+		{Result: "Lava", Ingredient1: "Earth", Ingredient2: "Fire"},
+		{Result: "Stone", Ingredient1: "Lava", Ingredient2: "Air"},
+		{Result: "Sand", Ingredient1: "Stone", Ingredient2: "Air"},
+	})
+	writeJSONFile(t, filepath.Join(la1Dir, "element_images_urls.json"), []dataset.ElementImage{
+		{Name: "Stone", ImageURL: "https://example.com/stone.png"},
+	})
+
+	rec := get("/api/search?target=stone&game=la1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp MultiSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.PathFound || resp.Game != "la1" || len(resp.Path) != 2 {
+		t.Fatalf("respons tidak sesuai: %+v", resp)
+	}
+	if !strings.HasSuffix(resp.ImageURLs["Stone"], "&game=la1") {
+		t.Errorf("URL gambar = %q, ingin memakai game=la1", resp.ImageURLs["Stone"])
+	}
+
+	// Elemen la2 tidak ada di la1, dan sebaliknya
+	for path, want := range map[string]int{
+		"/api/search?target=Human&game=la1":       http.StatusBadRequest,
+		"/api/search?target=Sand":                 http.StatusOK,
+		"/api/search?target=Sand&game=la1":        http.StatusOK,
+		"/api/element/Sand/ancestors?game=la1":    http.StatusOK,
+		"/api/element/Human/required?game=la1":    http.StatusNotFound,
+		"/api/meta?game=la1":                      http.StatusOK,
+		"/api/stats?game=LA1":                     http.StatusOK,
+		"/api/image?elementName=Lava&game=la1":    http.StatusNotFound,
+		"/api/datasets?game=tidak-ada":            http.StatusBadRequest,
+		"/api/element/Human/descendants?game=la2": http.StatusOK,
+	} {
+		if rec := get(path); rec.Code != want {
+			t.Errorf("%s: status = %d, ingin %d", path, rec.Code, want)
+		}
+	}
+
+	var meta MetaResponse
+	if err := json.Unmarshal(get("/api/meta?game=la1").Body.Bytes(), &meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Game != "la1" || meta.Recipes != 3 || meta.Elements != 7 {
+		t.Errorf("meta la1 = %+v", meta)
+	}
+
+	var games []GameInfo
+	if err := json.Unmarshal(get("/api/games").Body.Bytes(), &games); err != nil {
+		t.Fatal(err)
+	}
+	if len(games) != len(dataset.Editions) {
+		t.Fatalf("/api/games berisi %d edisi", len(games))
+	}
+	for _, info := range games {
+		if !info.Available || info.Default != (info.Name == dataset.DefaultGame) {
+			t.Errorf("edisi %+v", info)
+		}
+	}
+}
+
+func TestDatasetForProfileCache(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	root := t.TempDir()
+	useGameDataDir(t, root)
+	la1Dir := filepath.Join(root, "la1")
+	if err := os.MkdirAll(la1Dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	recipes := []dataset.Recipe{
+		{Result: "Lava", Ingredient1: "Earth", Ingredient2: "Fire"},
+		{Result: "Stone", Ingredient1: "Lava", Ingredient2: "Air"},
+	}
+	writeJSONFile(t, filepath.Join(la1Dir, "recipes_final_filtered.json"), recipes)
+	writeJSONFile(t, filepath.Join(la1Dir, "element_images_urls.json"), []dataset.ElementImage{})
+
+	g, err := GetGame("la1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// recipes_scraped.json belum ada: gagal, tetapi kegagalan tidak di-cache
+	if _, _, err := g.DatasetForProfile("raw"); err == nil {
+		t.Fatal("profil raw tanpa recipes_scraped.json seharusnya error")
+	}
+	writeJSONFile(t, filepath.Join(la1Dir, filter.ScrapedRecipeFile), recipes)
+	d, _, err := g.DatasetForProfile("raw")
+	if err != nil || !d.HasElement("Stone") {
+		t.Fatalf("setelah file ditulis: %v", err)
+	}
+	if again, _, _ := g.DatasetForProfile("raw"); again != d {
+		t.Error("dataset profil tidak di-cache")
+	}
+
+	// Edisi yang dimuat ulang membangun dataset profil dari data barunya
+	writeJSONFile(t, filepath.Join(la1Dir, filter.ScrapedRecipeFile), recipes[:1])
+	useGameDataDir(t, root)
+	reloaded, err := GetGame("la1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, _, err := reloaded.DatasetForProfile("raw"); err != nil || d.HasElement("Stone") {
+		t.Errorf("dataset profil setelah reload masih memakai data lama (err %v)", err)
+	}
+}
+
+func TestGameWithOwnBaseElements(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	// Edisi la1 dengan elemen dasar berbeda dari la2: Fire harus dibuat
+	oldEditions := dataset.Editions
+	dataset.Editions = slices.Clone(oldEditions)
+	for i := range dataset.Editions {
+		if dataset.Editions[i].Name == "la1" {
+			dataset.Editions[i].BaseElements = []string{"Sun", "Wood"}
+		}
+	}
+	t.Cleanup(func() { dataset.Editions = oldEditions })
+
+	root := t.TempDir()
+	useGameDataDir(t, root)
+	la1Dir := filepath.Join(root, "la1")
+	if err := os.MkdirAll(la1Dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeJSONFile(t, filepath.Join(la1Dir, "recipes_final_filtered.json"), []dataset.Recipe{
+		{Result: "Fire", Ingredient1: "Sun", Ingredient2: "Wood"},
+		{Result: "Ash", Ingredient1: "Fire", Ingredient2: "Wood"},
+	})
+	writeJSONFile(t, filepath.Join(la1Dir, "element_images_urls.json"), []dataset.ElementImage{})
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	for _, algo := range []string{"bfs", "dfs", "bds"} {
+		rec := get("/api/search?target=Ash&game=la1&algo=" + algo)
+		var resp MultiSearchResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d: %s", algo, rec.Code, rec.Body.String())
+		}
+		if !resp.PathFound || len(resp.Path) != 2 {
+			t.Errorf("%s: jalur ke Ash = %+v, ingin 2 langkah", algo, resp.Path)
+		}
+	}
+
+	var meta MetaResponse
+	if err := json.Unmarshal(get("/api/meta?game=la1").Body.Bytes(), &meta); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(meta.BaseElements, []string{"Sun", "Wood"}) || len(meta.Integrity.Errors) != 0 {
+		t.Errorf("meta la1 = %+v", meta)
+	}
+	if body := get("/api/export?game=la1&format=dot").Body.String(); !strings.Contains(body, `"Sun" [style=filled`) || strings.Contains(body, `"Fire" [style=filled`) {
+		t.Errorf("ekspor DOT tidak memakai elemen dasar edisi:\n%s", body)
+	}
+}
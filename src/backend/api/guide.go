@@ -0,0 +1,235 @@
+// src/backend/api/guide.go
+package api
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"tubes2stima/backend/dataset"
+)
+
+// GuideStep adalah satu langkah panduan: gabungkan dua bahan untuk
+// mendapatkan hasil.
+type GuideStep struct {
+	Number int            `json:"number"`
+	Recipe dataset.Recipe `json:"recipe"`
+}
+
+// GuideSection mengelompokkan langkah untuk satu sub-tujuan, yaitu salah
+// satu bahan langsung target. Section terakhir berisi langkah target.
+type GuideSection struct {
+	Goal  string      `json:"goal"`
+	Final bool        `json:"final,omitempty"`
+	Steps []GuideStep `json:"steps"`
+}
+
+// ReusedElement adalah elemen perantara yang dipakai di lebih dari satu langkah.
+type ReusedElement struct {
+	Name   string `json:"name"`
+	MadeIn int    `json:"madeIn"`
+	UsedIn []int  `json:"usedIn"`
+}
+
+// Guide adalah panduan langkah demi langkah untuk membuat target dari satu jalur.
+type Guide struct {
+	Target     string          `json:"target"`
+	TotalSteps int             `json:"totalSteps"`
+	Sections   []GuideSection  `json:"sections"`
+	Reused     []ReusedElement `json:"reused"`
+}
+
+// BuildGuide menyusun langkah path dalam urutan topologis (bahan selalu
+// dibuat sebelum dipakai), dikelompokkan per bahan langsung target.
+func BuildGuide(target string, path []dataset.Recipe) Guide {
+	guide := Guide{Target: target, Sections: []GuideSection{}, Reused: []ReusedElement{}}
+	stepFor, _ := RecipeTreeSteps(path, target)
+	finalStep, ok := stepFor[target]
+	if !ok {
+		return guide
+	}
+
+	madeIn := make(map[string]int)
+	var section *GuideSection
+	var emit func(name string)
+	emit = func(name string) {
+		step, ok := stepFor[name]
+		if !ok || madeIn[name] != 0 {
+			return
+		}
+		madeIn[name] = -1 // Penjaga siklus
+		emit(step.Ingredient1)
+		emit(step.Ingredient2)
+		guide.TotalSteps++
+		madeIn[name] = guide.TotalSteps
+		section.Steps = append(section.Steps, GuideStep{Number: guide.TotalSteps, Recipe: step})
+	}
+
+	madeIn[target] = -1
+	for _, goal := range []string{finalStep.Ingredient1, finalStep.Ingredient2} {
+		section = &GuideSection{Goal: goal}
+		emit(goal)
+		if len(section.Steps) > 0 {
+			guide.Sections = append(guide.Sections, *section)
+		}
+	}
+	guide.TotalSteps++
+	madeIn[target] = guide.TotalSteps
+	guide.Sections = append(guide.Sections, GuideSection{
+		Goal:  target,
+		Final: true,
+		Steps: []GuideStep{{Number: guide.TotalSteps, Recipe: finalStep}},
+	})
+
+	// Elemen perantara yang dipakai sebagai bahan di lebih dari satu langkah
+	usedIn := make(map[string][]int)
+	var order []string
+	for _, section := range guide.Sections {
+		for _, step := range section.Steps {
+			for i, ingredient := range []string{step.Recipe.Ingredient1, step.Recipe.Ingredient2} {
+				if madeIn[ingredient] <= 0 || (i == 1 && step.Recipe.Ingredient1 == ingredient) {
+					continue
+				}
+				if _, seen := usedIn[ingredient]; !seen {
+					order = append(order, ingredient)
+				}
+				usedIn[ingredient] = append(usedIn[ingredient], step.Number)
+			}
+		}
+	}
+	for _, name := range order {
+		if len(usedIn[name]) > 1 {
+			guide.Reused = append(guide.Reused, ReusedElement{Name: name, MadeIn: madeIn[name], UsedIn: usedIn[name]})
+		}
+	}
+	sort.Slice(guide.Reused, func(i, j int) bool { return guide.Reused[i].MadeIn < guide.Reused[j].MadeIn })
+	return guide
+}
+
+// reusedIn mengembalikan langkah pembuat elemen jika elemen tersebut
+// dipakai ulang (0 jika tidak).
+func (g Guide) reusedIn(name string) int {
+	for _, reused := range g.Reused {
+		if reused.Name == name {
+			return reused.MadeIn
+		}
+	}
+	return 0
+}
+
+// guideIngredient memformat nama bahan; emphasize dipakai untuk Markdown.
+func (g Guide) guideIngredient(name string, emphasize func(string) string) string {
+	text := emphasize(name)
+	if step := g.reusedIn(name); step != 0 {
+		text += fmt.Sprintf(" (dari langkah %d)", step)
+	}
+	return text
+}
+
+func joinStepNumbers(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// writeGuide menulis satu panduan. markdown memilih format Markdown atau
+// teks biasa.
+func writeGuide(b *strings.Builder, guide Guide, markdown bool) {
+	emphasize := func(s string) string { return s }
+	if markdown {
+		emphasize = func(s string) string { return "**" + markdownEscape(s) + "**" }
+	}
+
+	if guide.TotalSteps == 0 {
+		fmt.Fprintf(b, "%s adalah elemen dasar, tidak perlu dibuat.\n", emphasize(guide.Target))
+		return
+	}
+	fmt.Fprintf(b, "Cara membuat %s (%d langkah)\n", emphasize(guide.Target), guide.TotalSteps)
+
+	goalNumber := 0
+	for _, section := range guide.Sections {
+		title := ""
+		if section.Final {
+			title = "Terakhir: " + emphasize(section.Goal)
+		} else {
+			goalNumber++
+			title = fmt.Sprintf("Sub-tujuan %d: %s", goalNumber, emphasize(section.Goal))
+		}
+		if markdown {
+			fmt.Fprintf(b, "\n%s\n\n", title)
+		} else {
+			fmt.Fprintf(b, "\n%s\n", title)
+		}
+		for _, step := range section.Steps {
+			verb := "gabungkan"
+			if markdown {
+				verb = "Gabungkan"
+			}
+			line := fmt.Sprintf("%s %s + %s untuk mendapatkan %s", verb,
+				guide.guideIngredient(step.Recipe.Ingredient1, emphasize),
+				guide.guideIngredient(step.Recipe.Ingredient2, emphasize),
+				emphasize(step.Recipe.Result))
+			if markdown {
+				// Nomor list Markdown mengikuti nomor langkah
+				fmt.Fprintf(b, "%d. %s\n", step.Number, line)
+			} else {
+				fmt.Fprintf(b, "  Langkah %d: %s\n", step.Number, line)
+			}
+		}
+	}
+
+	if len(guide.Reused) > 0 {
+		if markdown {
+			b.WriteString("\nElemen perantara yang dipakai ulang:\n\n")
+		} else {
+			b.WriteString("\nElemen perantara yang dipakai ulang:\n")
+		}
+		bullet := "  - "
+		if markdown {
+			bullet = "- "
+		}
+		for _, reused := range guide.Reused {
+			fmt.Fprintf(b, "%s%s (dibuat di langkah %d, dipakai di langkah %s)\n", bullet, emphasize(reused.Name), reused.MadeIn, joinStepNumbers(reused.UsedIn))
+		}
+	}
+}
+
+// markdownEscape meng-escape karakter Markdown di nama elemen.
+func markdownEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`", `[`, `\[`, `]`, `\]`).Replace(s)
+}
+
+// WriteGuides menulis panduan untuk semua jalur. Pada lebih dari satu jalur
+// setiap panduan diberi judul "Jalur i".
+func WriteGuides(w io.Writer, guides []Guide, markdown bool) error {
+	var b strings.Builder
+	for i, guide := range guides {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if len(guides) > 1 {
+			if markdown {
+				fmt.Fprintf(&b, "## Jalur %d\n\n", i+1)
+			} else {
+				fmt.Fprintf(&b, "=== Jalur %d ===\n", i+1)
+			}
+		}
+		writeGuide(&b, guide, markdown)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// BuildGuides membuat panduan untuk setiap jalur hasil pencarian.
+func BuildGuides(target string, paths [][]dataset.Recipe) []Guide {
+	paths = renderPaths(paths)
+	guides := make([]Guide, len(paths))
+	for i, path := range paths {
+		guides[i] = BuildGuide(target, path)
+	}
+	return guides
+}
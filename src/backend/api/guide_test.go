@@ -0,0 +1,79 @@
+// src/backend/api/guide_test.go
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+func TestBuildGuide(t *testing.T) {
+	// Urutan path sengaja tidak topologis; Stone dipakai di dua sub-tujuan
+	path := []dataset.Recipe{
+		{Result: "Golem", Ingredient1: "Clay", Ingredient2: "Wall"},
+		{Result: "Wall", Ingredient1: "Stone", Ingredient2: "Stone"},
+		{Result: "Clay", Ingredient1: "Mud", Ingredient2: "Stone"},
+		{Result: "Stone", Ingredient1: "Earth", Ingredient2: "Pressure"},
+		{Result: "Pressure", Ingredient1: "Air", Ingredient2: "Air"},
+		{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"},
+	}
+	guide := BuildGuide("Golem", path)
+
+	var goals, order []string
+	for _, section := range guide.Sections {
+		goals = append(goals, section.Goal)
+		for _, step := range section.Steps {
+			order = append(order, step.Recipe.Result)
+		}
+	}
+	if want := []string{"Clay", "Wall", "Golem"}; !reflect.DeepEqual(goals, want) {
+		t.Errorf("sub-tujuan = %v, ingin %v", goals, want)
+	}
+	if want := []string{"Mud", "Pressure", "Stone", "Clay", "Wall", "Golem"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("urutan langkah = %v, ingin %v", order, want)
+	}
+	if want := []ReusedElement{{Name: "Stone", MadeIn: 3, UsedIn: []int{4, 5}}}; !reflect.DeepEqual(guide.Reused, want) {
+		t.Errorf("dipakai ulang = %+v, ingin %+v", guide.Reused, want)
+	}
+
+	var b strings.Builder
+	if err := WriteGuides(&b, []Guide{guide}, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Langkah 1: gabungkan Water + Earth untuk mendapatkan Mud",
+		"Langkah 5: gabungkan Stone (dari langkah 3) + Stone (dari langkah 3) untuk mendapatkan Wall",
+		"Terakhir: Golem",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("panduan tidak berisi %q:\n%s", want, b.String())
+		}
+	}
+
+	if base := BuildGuide("Fire", nil); base.TotalSteps != 0 || len(base.Sections) != 0 {
+		t.Errorf("panduan elemen dasar = %+v", base)
+	}
+}
+
+func TestSearchGuideFormats(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+	for path, want := range map[string]string{
+		"/api/search?target=Brick&format=text":                     "Langkah 2: gabungkan Mud + Fire untuk mendapatkan Brick",
+		"/api/search?target=Brick&format=md":                       "2. Gabungkan **Mud** + **Fire** untuk mendapatkan **Brick**",
+		"/api/search?target=Stone&mode=multiple&max=2&format=text": "=== Jalur 2 ===",
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("%s: status = %d, ingin berisi %q:\n%s", path, rec.Code, want, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,779 @@
+// src/backend/api/handlers.go
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/filter"
+	"tubes2stima/backend/graph"
+	"tubes2stima/backend/search"
+)
+
+// MultiSearchResponse struct untuk struktur respons JSON ke frontend
+type MultiSearchResponse struct {
+	SearchTarget   string             `json:"searchTarget"`
+	Algorithm      string             `json:"algorithm"`
+	Mode           string             `json:"mode"`
+	Parallel       bool               `json:"parallel,omitempty"`   // BFS paralel (lihat search/bfs_parallel.go)
+	LowMemory      bool               `json:"lowMemory,omitempty"`  // IDDFS tanpa cache (lihat search/iddfs.go)
+	MaxRecipes     int                `json:"maxRecipes,omitempty"` // Hanya ada jika mode multiple
+	Seed           *int64             `json:"seed,omitempty"`       // Seed mode deterministik (jika diminta)
+	Diversity      *float64           `json:"diversity,omitempty"`  // Bobot diversity (jika diminta)
+	PathFound      bool               `json:"pathFound"`
+	Path           []dataset.Recipe   `json:"path,omitempty"`      // Untuk mode shortest
+	Paths          [][]dataset.Recipe `json:"paths,omitempty"`     // Untuk mode multiple
+	ImageURLs      map[string]string  `json:"imageURLs,omitempty"` // URL gambar untuk elemen yang relevan
+	NodesVisited   int                `json:"nodesVisited"`
+	DurationMillis int64              `json:"durationMillis"`
+	Error          string             `json:"error,omitempty"` // Pesan error jika ada
+	// DistanceMatrix[i][j] = 1 - Jaccard(paths[i], paths[j]), hanya pada mode diversity
+	DistanceMatrix [][]float64 `json:"distanceMatrix,omitempty"`
+	// TotalCost/StepCosts hanya pada mode cheapest (StepCosts[i] = biaya Path[i])
+	TotalCost *float64  `json:"totalCost,omitempty"`
+	StepCosts []float64 `json:"stepCosts,omitempty"`
+	// MandatoryElements: elemen yang ada di SETIAP pohon resep target (tanpa
+	// memperhitungkan avoid/require), lihat graph/mandatory.go
+	MandatoryElements []string `json:"mandatoryElements,omitempty"`
+	// Iterations: node per batas kedalaman, hanya untuk algo iddfs
+	Iterations []search.IDDFSIteration `json:"iterations,omitempty"`
+	// Avoid/Require: batasan pencarian yang diminta (lihat search/constraints.go)
+	Avoid   []string `json:"avoid,omitempty"`
+	Require []string `json:"require,omitempty"`
+	// Dataset: profil filter yang dipakai (parameter dataset=), kosong = default
+	Dataset string `json:"dataset,omitempty"`
+	// Game: edisi game yang dipakai (parameter game=, lihat games.go)
+	Game string `json:"game"`
+	// ValidationErrors hanya diisi pada build debug (lihat debug_on.go)
+	ValidationErrors []string `json:"validationErrors,omitempty"`
+}
+
+// ElementRelationResponse adalah respons /api/element/{name}/ancestors dan
+// /api/element/{name}/descendants.
+type ElementRelationResponse struct {
+	Game          string                 `json:"game"`
+	Element       string                 `json:"element"`
+	Relation      string                 `json:"relation"`           // "ancestors" atau "descendants"
+	MaxDepth      int                    `json:"maxDepth,omitempty"` // Batas kedalaman (jika diminta)
+	Count         int                    `json:"count"`
+	CountsByDepth []int                  `json:"countsByDepth"` // countsByDepth[i] = jumlah elemen di kedalaman i+1
+	Elements      []graph.RelatedElement `json:"elements"`
+	// Contains menjawab parameter 'contains': apakah elemen tsb ada di hasil
+	Contains map[string]bool `json:"contains,omitempty"`
+}
+
+// RequiredElementsResponse adalah respons /api/element/{name}/required.
+type RequiredElementsResponse struct {
+	Game     string   `json:"game"`
+	Element  string   `json:"element"`
+	Count    int      `json:"count"`
+	Required []string `json:"required"` // Elemen yang ada di setiap pohon resep
+}
+
+// MetaResponse adalah respons /api/meta: ringkasan data yang dimuat server
+// beserta hasil validasinya.
+type MetaResponse struct {
+	Game         string                      `json:"game"`
+	Elements     int                         `json:"elements"`
+	Recipes      int                         `json:"recipes"`
+	Images       int                         `json:"images"`
+	BaseElements []string                    `json:"baseElements"`
+	Integrity    dataset.DataIntegrityReport `json:"integrity"`
+}
+
+// DatasetProfileInfo adalah satu profil filter di respons /api/datasets,
+// lengkap dengan jumlah resep yang dibuang setiap rule.
+type DatasetProfileInfo struct {
+	filter.Profile
+	Recipes  int                  `json:"recipes"`
+	Elements int                  `json:"elements"`
+	Rounds   int                  `json:"rounds"`
+	Removals []filter.RuleRemoval `json:"removals"`
+	Error    string               `json:"error,omitempty"`
+}
+
+// GameInfo adalah satu edisi game di respons /api/games.
+type GameInfo struct {
+	dataset.GameEdition
+	Default   bool   `json:"default"`
+	Available bool   `json:"available"` // Data edisi berhasil dimuat
+	Recipes   int    `json:"recipes"`
+	Elements  int    `json:"elements"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DiffRequest adalah body POST /api/admin/diff. Jika Old kosong, resep yang
+// sedang dimuat server dipakai sebagai snapshot lama.
+type DiffRequest struct {
+	Old []dataset.Recipe `json:"old,omitempty"`
+	New []dataset.Recipe `json:"new"`
+}
+
+// adminTokenEnv adalah environment variable berisi token untuk endpoint
+// /api/admin/*. Jika kosong, endpoint admin dinonaktifkan.
+const adminTokenEnv = "ADMIN_TOKEN"
+
+// maxAdminBodyBytes membatasi ukuran body request admin.
+const maxAdminBodyBytes = 32 << 20
+
+// apiRoutes adalah semua rute API. Setiap pattern harus terdokumentasi di
+// openapi.json (lihat openapi_test.go).
+var apiRoutes = []struct {
+	pattern string
+	handler http.HandlerFunc
+}{
+	{"/api/search", searchHandler},
+	{"/api/image", imageHandler},
+	{"/api/element/{name}/ancestors", elementAncestorsHandler},
+	{"/api/element/{name}/descendants", elementDescendantsHandler},
+	{"/api/element/{name}/required", elementRequiredHandler},
+	{"/api/stats", statsHandler},
+	{"/api/admin/diff", adminDiffHandler},
+	{"/api/meta", metaHandler},
+	{"/api/datasets", datasetsHandler},
+	{"/api/games", gamesHandler},
+	{"/api/export", exportHandler},
+	{"/api/openapi.json", openAPIHandler},
+}
+
+// RegisterRoutes mendaftarkan semua rute API ke mux.
+func RegisterRoutes(mux *http.ServeMux) {
+	for _, route := range apiRoutes {
+		mux.HandleFunc(route.pattern, route.handler)
+	}
+}
+
+// gameFromRequest mengambil data edisi dari parameter 'game' (kosong =
+// dataset.DefaultGame) dan menulis respons error jika edisi tidak dikenal atau
+// datanya tidak bisa dimuat.
+func gameFromRequest(w http.ResponseWriter, r *http.Request) (*GameData, bool) {
+	name := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("game")))
+	g, err := GetGame(name)
+	switch {
+	case err == nil:
+		return g, true
+	case errors.Is(err, dataset.ErrUnknownGame):
+		http.Error(w, fmt.Sprintf("Parameter 'game': %v", err), http.StatusBadRequest)
+	case errors.Is(err, os.ErrNotExist):
+		http.Error(w, fmt.Sprintf("Data game '%s' belum tersedia (jalankan 'go run . -scrapeonly -game %s' di direktori backend)", name, name), http.StatusNotFound)
+	case errors.Is(err, dataset.ErrNotInitialized):
+		http.Error(w, "Data belum dimuat", http.StatusServiceUnavailable)
+	default:
+		log.Printf("Gagal memuat data game '%s': %v", name, err)
+		http.Error(w, fmt.Sprintf("Gagal memuat data game '%s'", name), http.StatusInternalServerError)
+	}
+	return nil, false
+}
+
+// imageHandler berfungsi sebagai proxy untuk mengambil gambar elemen dari URL aslinya.
+// Ini membantu menghindari masalah CORS di frontend.
+func imageHandler(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers agar frontend bisa mengakses
+	w.Header().Set("Access-Control-Allow-Origin", "*")             // Izinkan akses dari origin manapun
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type") // Header yang diizinkan
+
+	// Hanya izinkan metode GET
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Ambil nama elemen dari query parameter 'elementName'
+	queryParams := r.URL.Query()
+	elementName := queryParams.Get("elementName")
+
+	if elementName == "" {
+		http.Error(w, "Parameter 'elementName' diperlukan", http.StatusBadRequest)
+		return
+	}
+
+	g, ok := gameFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	log.Printf("Menerima permintaan gambar untuk elemen: %s\n", elementName)
+
+	// Dapatkan map URL gambar dari data game yang sudah dimuat (dari games.go)
+	imageMap := g.ImageMap()
+
+	// Cari URL gambar asli untuk elemen ini
+	originalImageURL, found := imageMap[elementName]
+	if !found || originalImageURL == "" {
+		log.Printf("URL gambar tidak ditemukan untuk elemen: %s\n", elementName)
+		http.Error(w, "URL gambar tidak ditemukan", http.StatusNotFound)
+		return
+	}
+
+	// Ambil gambar lewat cache (lihat image_cache.go); unduhan ke URL asli
+	// dilakukan DARI BACKEND hanya jika gambar belum ada di cache
+	img, err := GetCachedImage(originalImageURL)
+	if err != nil {
+		log.Printf("Gagal mengambil gambar %s: %v\n", originalImageURL, err)
+		var fetchErr *imageFetchError
+		if errors.As(err, &fetchErr) {
+			// Teruskan status code error dari server sumber
+			http.Error(w, fmt.Sprintf("Gagal mengambil gambar dari sumber (status: %d)", fetchErr.StatusCode), fetchErr.StatusCode)
+		} else {
+			http.Error(w, "Gagal mengambil gambar dari sumber eksternal", http.StatusBadGateway)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", img.ContentType)
+	if _, err := w.Write(img.Data); err != nil {
+		log.Printf("Gagal menulis gambar %s: %v\n", originalImageURL, err)
+		// Tidak mengirim http.Error lagi karena header mungkin sudah terkirim
+		return
+	}
+
+	log.Printf("Gambar untuk elemen %s berhasil dilayani.\n", elementName)
+}
+
+// searchHandler menangani permintaan pencarian resep dari frontend.
+func searchHandler(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	// Hanya izinkan metode GET
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	g, ok := gameFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	req, status, err := ParseSearchRequest(g, r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	response := req.Execute()
+
+	// format=dot/svg: kirim pohon resep yang dirender server (lihat render.go),
+	// format=text/markdown: panduan langkah demi langkah (lihat guide.go)
+	if req.Format != "json" {
+		writeRenderedSearch(w, g, response, req.Format)
+		return
+	}
+
+	// Encode Response ke JSON dan Kirim
+	writeJSON(w, response)
+}
+
+// writeJSON mengirim v sebagai JSON (MarshalIndent untuk pretty print).
+// Error saat menulis hanya dicatat karena header sudah terkirim.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	jsonResponse, jsonErr := json.MarshalIndent(v, "", "  ")
+	if jsonErr != nil {
+		log.Printf("Error saat marshal JSON response: %v", jsonErr)
+		http.Error(w, "Internal Server Error saat membuat respons JSON", http.StatusInternalServerError)
+		return
+	}
+	if _, writeErr := w.Write(jsonResponse); writeErr != nil {
+		log.Printf("Error saat menulis JSON response: %v", writeErr)
+	}
+}
+
+// searchFormatContentTypes memetakan format hasil pencarian non-JSON ke
+// Content-Type respons HTTP.
+var searchFormatContentTypes = map[string]string{
+	"text":     "text/plain; charset=utf-8",
+	"markdown": "text/markdown; charset=utf-8",
+	"dot":      dataset.RecipeFormatContentTypes["dot"],
+	"svg":      "image/svg+xml",
+}
+
+// writeRenderedSearch menulis hasil pencarian sebagai graf DOT, gambar SVG,
+// atau panduan teks/Markdown.
+func writeRenderedSearch(w http.ResponseWriter, g *GameData, response MultiSearchResponse, format string) {
+	if !response.PathFound {
+		message := fmt.Sprintf("Jalur untuk '%s' tidak ditemukan", response.SearchTarget)
+		if response.Error != "" {
+			message += ": " + response.Error
+		}
+		http.Error(w, message, http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", searchFormatContentTypes[format])
+	if err := WriteSearchDocument(w, g, response, format); err != nil {
+		log.Printf("Error saat menulis respons %s: %v", format, err)
+	}
+}
+
+// WriteSearchDocument menulis jalur hasil pencarian dalam format text,
+// markdown, dot, atau svg. Gambar elemen untuk SVG diambil dari cache
+// gambar (image_cache.go).
+func WriteSearchDocument(w io.Writer, g *GameData, response MultiSearchResponse, format string) error {
+	paths := response.Paths
+	if response.Mode != "multiple" && len(response.Path) > 0 {
+		paths = [][]dataset.Recipe{response.Path}
+	}
+
+	switch format {
+	case "text":
+		return WriteGuides(w, BuildGuides(response.SearchTarget, paths), false)
+	case "markdown":
+		return WriteGuides(w, BuildGuides(response.SearchTarget, paths), true)
+	case "dot":
+		return WriteRecipeTreesDOT(w, response.SearchTarget, paths)
+	}
+	imageMap := g.ImageMap()
+	var urls []string
+	for elementName := range response.ImageURLs {
+		urls = append(urls, imageMap[elementName])
+	}
+	fetched := FetchImages(urls)
+	images := make(map[string]string, len(fetched))
+	for elementName := range response.ImageURLs {
+		if img, ok := fetched[imageMap[elementName]]; ok {
+			images[elementName] = img.DataURI()
+		}
+	}
+	return WriteRecipeTreesSVG(w, response.SearchTarget, paths, images)
+}
+
+// validateResponsePaths menjalankan ValidatePath dataset d untuk semua jalur
+// di response dan mengembalikan pesan pelanggarannya.
+func validateResponsePaths(d *dataset.Dataset, response MultiSearchResponse) []string {
+	paths := response.Paths
+	if response.Mode != "multiple" {
+		paths = [][]dataset.Recipe{response.Path}
+	}
+	var issues []string
+	for i, path := range paths {
+		if err := search.ValidatePath(d, path, response.SearchTarget); err != nil {
+			issues = append(issues, fmt.Sprintf("jalur #%d: %v", i+1, err))
+		}
+	}
+	return issues
+}
+
+// parseElementList memecah daftar elemen dipisah koma dan mencocokkan setiap
+// nama dengan elemen game g. Error jika ada elemen yang tidak dikenal.
+func parseElementList(g *GameData, raw string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var elements []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name := g.ResolveElementName(part)
+		if !g.HasElement(name) {
+			return nil, fmt.Errorf("elemen '%s' tidak ditemukan", part)
+		}
+		if !seen[name] {
+			seen[name] = true
+			elements = append(elements, name)
+		}
+	}
+	return elements, nil
+}
+
+// resolveElementNameWith mencocokkan nama elemen dari input pengguna dengan
+// beberapa variasi kapitalisasi, memakai exists untuk memeriksa keberadaan
+// elemen. Jika tidak ada yang cocok, dikembalikan format title case.
+func resolveElementNameWith(exists func(string) bool, name string) string {
+	// Coba format yang berbeda untuk meningkatkan peluang menemukan elemen
+	// Format 1: Title case untuk setiap kata (Grilled Cheese)
+	titleCaseTarget := toTitleCase(name)
+
+	// Format 2: Huruf pertama kapital saja (Grilled cheese)
+	firstCapTarget := ""
+	if len(name) > 0 {
+		firstCapTarget = strings.ToUpper(string(name[0]))
+		if len(name) > 1 {
+			firstCapTarget += strings.ToLower(name[1:])
+		}
+	}
+
+	// Format 3: Semua huruf kecil (grilled cheese)
+	lowerCaseTarget := strings.ToLower(name)
+
+	// Format 4: Semua huruf kapital (GRILLED CHEESE)
+	upperCaseTarget := strings.ToUpper(name)
+
+	// Coba semua format satu per satu
+	potentialTargets := []string{titleCaseTarget, firstCapTarget, name, lowerCaseTarget, upperCaseTarget}
+
+	// Variabel untuk menyimpan target yang valid
+	validTarget := ""
+
+	// Cek satu per satu
+	for _, potTarget := range potentialTargets {
+		if exists(potTarget) {
+			validTarget = potTarget
+			break
+		}
+	}
+
+	// Jika tidak ada yang cocok, gunakan format title case
+	if validTarget == "" {
+		validTarget = titleCaseTarget
+	}
+
+	return validTarget
+}
+
+// Fungsi untuk mengubah format string menjadi Title Case
+// (huruf pertama tiap kata besar, sisanya kecil)
+// Ganti fungsi toTitleCase dengan fungsi ini
+func toTitleCase(input string) string {
+	// Pisahkan string menjadi kata-kata
+	words := strings.Fields(input)
+	result := make([]string, len(words))
+
+	for i, word := range words {
+		if len(word) == 0 {
+			continue
+		}
+		// Untuk setiap kata, buat huruf pertama kapital dan sisanya kecil
+		firstChar := strings.ToUpper(string(word[0]))
+		restOfWord := ""
+		if len(word) > 1 {
+			restOfWord = strings.ToLower(word[1:])
+		}
+		result[i] = firstChar + restOfWord
+	}
+
+	// Gabungkan kembali menjadi satu string
+	return strings.Join(result, " ")
+}
+
+// elementAncestorsHandler: semua elemen yang bisa muncul di pohon resep {name}.
+func elementAncestorsHandler(w http.ResponseWriter, r *http.Request) {
+	elementRelationHandler(w, r, "ancestors")
+}
+
+// elementDescendantsHandler: semua elemen yang bisa dibuat dengan bantuan {name}.
+func elementDescendantsHandler(w http.ResponseWriter, r *http.Request) {
+	elementRelationHandler(w, r, "descendants")
+}
+
+// elementRelationHandler melayani kedua endpoint relasi elemen. Parameter
+// opsional: 'depth' (batas kedalaman) dan 'contains' (daftar elemen dipisah
+// koma yang ingin dicek keberadaannya di hasil).
+func elementRelationHandler(w http.ResponseWriter, r *http.Request, relation string) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rawName := strings.TrimSpace(r.PathValue("name"))
+	if rawName == "" {
+		http.Error(w, "Nama elemen diperlukan", http.StatusBadRequest)
+		return
+	}
+	g, ok := gameFromRequest(w, r)
+	if !ok {
+		return
+	}
+	element := g.ResolveElementName(rawName)
+	if !g.HasElement(element) {
+		http.Error(w, fmt.Sprintf("Elemen '%s' tidak ditemukan", rawName), http.StatusNotFound)
+		return
+	}
+
+	maxDepth := 0
+	if depthStr := strings.TrimSpace(r.URL.Query().Get("depth")); depthStr != "" {
+		var convErr error
+		maxDepth, convErr = strconv.Atoi(depthStr)
+		if convErr != nil || maxDepth <= 0 {
+			http.Error(w, "Parameter 'depth' harus berupa angka positif", http.StatusBadRequest)
+			return
+		}
+	}
+	contains, listErr := parseElementList(g, strings.TrimSpace(r.URL.Query().Get("contains")))
+	if listErr != nil {
+		http.Error(w, fmt.Sprintf("Parameter 'contains': %v", listErr), http.StatusBadRequest)
+		return
+	}
+
+	var related []graph.RelatedElement
+	var err error
+	if relation == "ancestors" {
+		related, err = graph.Ancestors(g.Dataset(), element, maxDepth)
+	} else {
+		related, err = graph.Descendants(g.Dataset(), element, maxDepth)
+	}
+	if err != nil {
+		log.Printf("Gagal menghitung %s untuk %s: %v", relation, element, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := ElementRelationResponse{
+		Game:          g.Edition.Name,
+		Element:       element,
+		Relation:      relation,
+		MaxDepth:      maxDepth,
+		Count:         len(related),
+		CountsByDepth: graph.CountsByDepth(related),
+		Elements:      related,
+	}
+	if response.Elements == nil {
+		response.Elements = []graph.RelatedElement{}
+		response.CountsByDepth = []int{}
+	}
+	if len(contains) > 0 {
+		found := make(map[string]bool, len(related))
+		for _, el := range related {
+			found[el.Name] = true
+		}
+		response.Contains = make(map[string]bool, len(contains))
+		for _, name := range contains {
+			response.Contains[name] = found[name]
+		}
+	}
+
+	writeJSON(w, response)
+}
+
+// elementRequiredHandler: elemen yang pasti dipakai di setiap pohon resep {name}.
+func elementRequiredHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rawName := strings.TrimSpace(r.PathValue("name"))
+	if rawName == "" {
+		http.Error(w, "Nama elemen diperlukan", http.StatusBadRequest)
+		return
+	}
+	g, ok := gameFromRequest(w, r)
+	if !ok {
+		return
+	}
+	element := g.ResolveElementName(rawName)
+	if !g.HasElement(element) {
+		http.Error(w, fmt.Sprintf("Elemen '%s' tidak ditemukan", rawName), http.StatusNotFound)
+		return
+	}
+
+	required, err := graph.MandatoryElements(g.Dataset(), element)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	response := RequiredElementsResponse{
+		Game:     g.Edition.Name,
+		Element:  element,
+		Count:    len(required),
+		Required: required,
+	}
+	if response.Required == nil {
+		response.Required = []string{}
+	}
+
+	writeJSON(w, response)
+}
+
+// statsHandler mengembalikan statistik dataset dan analitik graf (lihat dataset/stats.go).
+func statsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+	g, ok := gameFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, g.Dataset().Stats())
+}
+
+// checkAdmin memeriksa header X-Admin-Token terhadap ADMIN_TOKEN dan menulis
+// respons error jika tidak cocok.
+func checkAdmin(w http.ResponseWriter, r *http.Request) bool {
+	token := os.Getenv(adminTokenEnv)
+	if token == "" {
+		http.Error(w, "Endpoint admin dinonaktifkan (ADMIN_TOKEN tidak diatur)", http.StatusForbidden)
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Token")), []byte(token)) != 1 {
+		http.Error(w, "Token admin tidak valid", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// adminDiffHandler membandingkan dua snapshot resep (lihat DiffRecipes).
+func adminDiffHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Admin-Token")
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+	if !checkAdmin(w, r) {
+		return
+	}
+	g, ok := gameFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req DiffRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAdminBodyBytes)).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Body JSON tidak valid: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(req.New) == 0 {
+		http.Error(w, "Field 'new' (daftar resep) diperlukan", http.StatusBadRequest)
+		return
+	}
+	if req.Old == nil {
+		req.Old = g.Recipes()
+	}
+
+	writeJSON(w, DiffRecipes(req.Old, req.New, g.Edition.BaseElements))
+}
+
+// metaHandler mengembalikan ringkasan data dan laporan validasi Init.
+func metaHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	g, ok := gameFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	response := MetaResponse{
+		Game:         g.Edition.Name,
+		Elements:     g.ElementCount(),
+		Recipes:      len(g.Recipes()),
+		Images:       len(g.ImageMap()),
+		BaseElements: g.Edition.BaseElements,
+		Integrity:    g.Integrity(),
+	}
+
+	writeJSON(w, response)
+}
+
+// datasetsHandler mendaftar profil filter yang bisa dipakai di parameter
+// dataset= pada /api/search.
+func datasetsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	g, ok := gameFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	profiles := g.FilterConfig().Profiles
+	response := make([]DatasetProfileInfo, len(profiles))
+	for i, profile := range profiles {
+		info := DatasetProfileInfo{Profile: profile, Removals: []filter.RuleRemoval{}}
+		d, result, err := g.DatasetForProfile(profile.Name)
+		if err != nil {
+			info.Error = err.Error()
+		} else {
+			info.Recipes = len(result.Recipes)
+			info.Elements = graph.Of(d).NumElements()
+			info.Rounds = result.Rounds
+			info.Removals = result.Removals
+		}
+		response[i] = info
+	}
+
+	writeJSON(w, response)
+}
+
+// gamesHandler mendaftar edisi game yang bisa dipakai di parameter game=.
+func gamesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := make([]GameInfo, len(dataset.Editions))
+	for i, edition := range dataset.Editions {
+		info := GameInfo{GameEdition: edition, Default: edition.Name == dataset.DefaultGame}
+		g, err := GetGame(edition.Name)
+		if err != nil {
+			info.Error = err.Error()
+		} else {
+			info.Available = true
+			info.Recipes = len(g.Recipes())
+			info.Elements = g.ElementCount()
+		}
+		response[i] = info
+	}
+
+	writeJSON(w, response)
+}
+
+// exportHandler mengunduh resep game dalam format parameter 'format' (lihat
+// dataset/recipe_io.go), default json.
+func exportHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format == "" {
+		format = "json"
+	}
+	contentType, ok := dataset.RecipeFormatContentTypes[format]
+	if !ok {
+		http.Error(w, fmt.Sprintf("Parameter 'format' harus salah satu dari: %s", strings.Join(dataset.RecipeFormats, ", ")), http.StatusBadRequest)
+		return
+	}
+	g, ok := gameFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "recipes-"+g.Edition.Name+"."+format))
+	if err := dataset.ExportRecipes(w, g.Recipes(), format, g.Edition.BaseElements); err != nil {
+		log.Printf("Error saat menulis ekspor %s: %v", format, err)
+	}
+}
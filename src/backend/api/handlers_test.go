@@ -0,0 +1,188 @@
+// src/backend/api/handlers_test.go
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+func TestMain(m *testing.M) {
+	restore := silenceStdout()
+	err := Init(filepath.Join("..", "data"), false)
+	restore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gagal memuat data untuk test: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// defaultGame mengembalikan data game default yang dimuat TestMain.
+func defaultGame(t *testing.T) *GameData {
+	t.Helper()
+	g, err := GetGame("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// silenceStdout membuang output log pemuatan data dan algoritma selama test.
+func silenceStdout() func() {
+	original := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return func() {}
+	}
+	os.Stdout = devNull
+	return func() {
+		os.Stdout = original
+		devNull.Close()
+	}
+}
+
+func TestMetaHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/meta", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp MetaResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Recipes != len(defaultGame(t).Recipes()) || resp.Integrity.RecipesLoaded != resp.Recipes || len(resp.BaseElements) != 4 {
+		t.Errorf("respons tidak sesuai: %+v", resp)
+	}
+}
+
+func TestExportHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+	for path, want := range map[string]int{
+		"/api/export?format=csv":     http.StatusOK,
+		"/api/export?format=GraphML": http.StatusOK,
+		"/api/export":                http.StatusOK,
+		"/api/export?format=xlsx":    http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Errorf("%s: status = %d, ingin %d", path, rec.Code, want)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/export?format=csv", nil))
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="recipes-la2.csv"` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+	if lines := strings.Count(rec.Body.String(), "\n"); lines != len(defaultGame(t).Recipes())+1 {
+		t.Errorf("CSV berisi %d baris, ingin %d", lines, len(defaultGame(t).Recipes())+1)
+	}
+}
+
+func TestStatsHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/stats", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp dataset.DatasetStats
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Elements == 0 || len(resp.MostUsedIngredients) != dataset.StatsTopN {
+		t.Errorf("respons tidak sesuai: %d elemen, %d bahan teratas", resp.Elements, len(resp.MostUsedIngredients))
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/stats", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: status = %d, ingin 405", rec.Code)
+	}
+}
+
+func TestElementRelationHandler(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/element/human/ancestors?depth=1&contains=Clay,Time", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp ElementRelationResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Element != "Human" || resp.Count != len(resp.Elements) || len(resp.CountsByDepth) != 1 {
+		t.Errorf("respons tidak sesuai: %+v", resp)
+	}
+	if !resp.Contains["Clay"] || resp.Contains["Time"] {
+		t.Errorf("contains = %v, ingin Clay true dan Time false", resp.Contains)
+	}
+
+	for path, want := range map[string]int{
+		"/api/element/Unobtainium/descendants":  http.StatusNotFound,
+		"/api/element/Fire/descendants?depth=0": http.StatusBadRequest,
+		"/api/element/Fire/descendants?depth=x": http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Errorf("%s: status = %d, ingin %d", path, rec.Code, want)
+		}
+	}
+}
+
+func TestElementRequiredHandler(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/element/human/required", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp RequiredElementsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	// Human hanya bisa dibuat dari Clay + Life
+	if !slices.Contains(resp.Required, "Life") || !slices.Contains(resp.Required, "Clay") || resp.Count != len(resp.Required) {
+		t.Errorf("elemen wajib Human: %+v", resp)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/search?target=Human", nil))
+	var search MultiSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &search); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(search.MandatoryElements, resp.Required) {
+		t.Errorf("mandatoryElements = %v, ingin %v", search.MandatoryElements, resp.Required)
+	}
+}
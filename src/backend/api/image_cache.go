@@ -0,0 +1,212 @@
+// src/backend/api/image_cache.go
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedImage adalah gambar elemen yang sudah diunduh.
+type CachedImage struct {
+	ContentType string
+	Data        []byte
+}
+
+// DataURI mengembalikan gambar sebagai data: URI untuk disisipkan ke SVG.
+func (img CachedImage) DataURI() string {
+	return "data:" + img.ContentType + ";base64," + base64.StdEncoding.EncodeToString(img.Data)
+}
+
+// imageFetchError menyimpan status HTTP non-OK dari server sumber gambar.
+type imageFetchError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *imageFetchError) Error() string {
+	return fmt.Sprintf("server sumber gambar %s mengembalikan status %d", e.URL, e.StatusCode)
+}
+
+const (
+	maxImageBytes      = 2 << 20          // Gambar lebih besar dari ini ditolak
+	imageFailureTTL    = 10 * time.Minute // Jeda sebelum URL yang gagal dicoba lagi
+	imageFetchWorkers  = 8                // Unduhan paralel pada FetchImages
+	imageFetchTimeout  = 10 * time.Second
+	imageUserAgent     = "Mozilla/5.0 (compatible; MyLittleAlchemyApp/1.0; +http://localhost)"
+	imageCacheFileMode = 0644
+)
+
+var (
+	// imageCacheDir adalah direktori cache gambar di disk (diatur saat server
+	// start). Kosong = cache hanya di memori. File di direktori ini juga
+	// dipakai tanpa jaringan, misalnya di container offline.
+	imageCacheDir string
+
+	// imageFetcher mengunduh satu gambar (diganti di test).
+	imageFetcher = fetchImageHTTP
+
+	imageCacheMutex    sync.Mutex
+	imageCacheEntries  = make(map[string]CachedImage)
+	imageCacheFailures = make(map[string]time.Time)
+)
+
+// SetImageCacheDir mengatur direktori cache gambar di disk. Dipanggil sekali
+// sebelum server mulai melayani request.
+func SetImageCacheDir(dir string) {
+	imageCacheMutex.Lock()
+	defer imageCacheMutex.Unlock()
+	imageCacheDir = dir
+}
+
+// GetCachedImage mengembalikan gambar dari URL asli, dari memori, disk, atau
+// server sumber (lalu disimpan ke cache).
+func GetCachedImage(url string) (CachedImage, error) {
+	imageCacheMutex.Lock()
+	if img, ok := imageCacheEntries[url]; ok {
+		imageCacheMutex.Unlock()
+		return img, nil
+	}
+	if failedAt, ok := imageCacheFailures[url]; ok && time.Since(failedAt) < imageFailureTTL {
+		imageCacheMutex.Unlock()
+		return CachedImage{}, fmt.Errorf("gambar %s gagal diunduh sebelumnya", url)
+	}
+	imageCacheMutex.Unlock()
+
+	img, err := readImageCacheFile(url)
+	if err != nil {
+		img, err = imageFetcher(url)
+	}
+
+	imageCacheMutex.Lock()
+	if err != nil {
+		imageCacheFailures[url] = time.Now()
+		imageCacheMutex.Unlock()
+		return CachedImage{}, err
+	}
+	delete(imageCacheFailures, url)
+	imageCacheEntries[url] = img
+	path := imageCachePath(url)
+	imageCacheMutex.Unlock()
+
+	// File ditulis di luar lock agar request lain tidak menunggu disk
+	writeImageCacheFile(path, img)
+	return img, nil
+}
+
+// FetchImages mengambil gambar untuk banyak URL secara paralel. URL yang
+// gagal tidak ada di hasil.
+func FetchImages(urls []string) map[string]CachedImage {
+	result := make(map[string]CachedImage, len(urls))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, imageFetchWorkers)
+	for _, url := range urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if img, err := GetCachedImage(url); err == nil {
+				mu.Lock()
+				result[url] = img
+				mu.Unlock()
+			}
+		}(url)
+	}
+	wg.Wait()
+	return result
+}
+
+// fetchImageHTTP mengunduh gambar dari server sumber.
+func fetchImageHTTP(url string) (CachedImage, error) {
+	client := http.Client{Timeout: imageFetchTimeout}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return CachedImage{}, err
+	}
+	req.Header.Set("User-Agent", imageUserAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return CachedImage{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return CachedImage{}, &imageFetchError{URL: url, StatusCode: resp.StatusCode}
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return CachedImage{}, err
+	}
+	if len(data) > maxImageBytes {
+		return CachedImage{}, fmt.Errorf("gambar %s lebih besar dari %d byte", url, maxImageBytes)
+	}
+	return CachedImage{ContentType: imageContentType(url, resp.Header.Get("Content-Type"), data), Data: data}, nil
+}
+
+// imageContentType memakai header Content-Type server sumber hanya jika
+// berupa image/* yang valid (tanpa parameter); selain itu tipe ditebak dari
+// isi gambar. Nilai ini masuk ke data: URI di SVG.
+func imageContentType(url, header string, data []byte) string {
+	if mediaType, _, err := mime.ParseMediaType(header); err == nil && strings.HasPrefix(mediaType, "image/") {
+		return mediaType
+	}
+	return detectImageType(url, data)
+}
+
+// detectImageType menebak Content-Type dari isi gambar (SVG dikenali dari
+// ekstensi atau tag <svg>).
+func detectImageType(url string, data []byte) string {
+	if strings.HasSuffix(strings.ToLower(url), ".svg") || bytes.Contains(data[:min(len(data), 512)], []byte("<svg")) {
+		return "image/svg+xml"
+	}
+	return http.DetectContentType(data)
+}
+
+// imageCachePath mengembalikan nama file cache untuk URL ("" jika cache disk
+// tidak aktif).
+func imageCachePath(url string) string {
+	if imageCacheDir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(imageCacheDir, hex.EncodeToString(sum[:16]))
+}
+
+func readImageCacheFile(url string) (CachedImage, error) {
+	path := imageCachePath(url)
+	if path == "" {
+		return CachedImage{}, os.ErrNotExist
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return CachedImage{}, err
+	}
+	return CachedImage{ContentType: detectImageType(url, data), Data: data}, nil
+}
+
+// writeImageCacheFile menyimpan gambar ke file cache path (dari
+// imageCachePath, "" = cache disk tidak aktif). Kegagalan diabaikan, gambar
+// tetap ada di cache memori.
+func writeImageCacheFile(path string, img CachedImage) {
+	if path == "" {
+		return
+	}
+	if _, err := os.Stat(path); err == nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return
+	}
+	os.WriteFile(path, img.Data, imageCacheFileMode)
+}
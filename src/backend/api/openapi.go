@@ -0,0 +1,31 @@
+// src/backend/api/openapi.go
+package api
+
+import (
+	_ "embed"
+	"log"
+	"net/http"
+)
+
+// openAPISpec adalah spesifikasi OpenAPI 3 semua endpoint di apiRoutes.
+// openapi_test.go memvalidasi request dan respons handler terhadap spesifikasi
+// ini, jadi setiap perubahan respons harus diikuti perubahan openapi.json.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// openAPIHandler menyajikan openapi.json apa adanya.
+func openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(openAPISpec); err != nil {
+		log.Printf("Error saat menulis spesifikasi OpenAPI: %v", err)
+	}
+}
@@ -0,0 +1,464 @@
+// src/backend/api/openapi_test.go
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Subset OpenAPI 3 / JSON Schema yang dipakai openapi.json. Validator ini
+// sengaja lebih ketat dari JSON Schema: objek tanpa additionalProperties
+// tidak boleh punya properti yang tidak terdokumentasi, sehingga field baru
+// di struct respons harus ikut ditambahkan ke spesifikasi.
+type openAPIDocument struct {
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components struct {
+		Parameters map[string]*openAPIParameter `json:"parameters"`
+		Responses  map[string]*openAPIResponse  `json:"responses"`
+		Schemas    map[string]*openAPISchema    `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPIOperation struct {
+	Parameters  []*openAPIParameter `json:"parameters"`
+	RequestBody *struct {
+		Required bool                    `json:"required"`
+		Content  map[string]openAPIMedia `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Ref      string         `json:"$ref"`
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+type openAPIResponse struct {
+	Ref     string                  `json:"$ref"`
+	Content map[string]openAPIMedia `json:"content"`
+}
+
+type openAPIMedia struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref"`
+	Type                 string                    `json:"type"`
+	Nullable             bool                      `json:"nullable"`
+	Enum                 []any                     `json:"enum"`
+	Minimum              *float64                  `json:"minimum"`
+	Maximum              *float64                  `json:"maximum"`
+	Required             []string                  `json:"required"`
+	Properties           map[string]*openAPISchema `json:"properties"`
+	AdditionalProperties json.RawMessage           `json:"additionalProperties"`
+	Items                *openAPISchema            `json:"items"`
+}
+
+func loadOpenAPIDocument(t *testing.T) *openAPIDocument {
+	t.Helper()
+	var doc openAPIDocument
+	if err := json.Unmarshal(openAPISpec, &doc); err != nil {
+		t.Fatalf("openapi.json tidak valid: %v", err)
+	}
+	return &doc
+}
+
+// componentName mengembalikan nama komponen dari $ref lokal.
+func componentName(ref, kind string) string {
+	return strings.TrimPrefix(ref, "#/components/"+kind+"/")
+}
+
+func (doc *openAPIDocument) schema(s *openAPISchema) *openAPISchema {
+	for s != nil && s.Ref != "" {
+		s = doc.Components.Schemas[componentName(s.Ref, "schemas")]
+	}
+	return s
+}
+
+func (doc *openAPIDocument) parameter(p *openAPIParameter) *openAPIParameter {
+	if p.Ref != "" {
+		return doc.Components.Parameters[componentName(p.Ref, "parameters")]
+	}
+	return p
+}
+
+func (doc *openAPIDocument) response(r *openAPIResponse) *openAPIResponse {
+	if r != nil && r.Ref != "" {
+		return doc.Components.Responses[componentName(r.Ref, "responses")]
+	}
+	return r
+}
+
+// validate memeriksa value (hasil decode JSON dengan UseNumber) terhadap s dan
+// mengembalikan semua pelanggaran dengan lokasinya.
+func (doc *openAPIDocument) validate(s *openAPISchema, value any, at string) []string {
+	ref := s.Ref
+	if s = doc.schema(s); s == nil {
+		return []string{fmt.Sprintf("%s: $ref %q tidak ditemukan", at, ref)}
+	}
+	if value == nil {
+		if s.Nullable {
+			return nil
+		}
+		return []string{at + ": null tidak diizinkan"}
+	}
+	var problems []string
+	if len(s.Enum) > 0 {
+		found := false
+		for _, allowed := range s.Enum {
+			found = found || fmt.Sprint(allowed) == fmt.Sprint(value)
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: %v tidak ada di enum %v", at, value, s.Enum))
+		}
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return append(problems, fmt.Sprintf("%s: ingin object, dapat %T", at, value))
+		}
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: properti wajib %q tidak ada", at, name))
+			}
+		}
+		var additional *openAPISchema
+		allowAdditional := false
+		if len(s.AdditionalProperties) > 0 && json.Unmarshal(s.AdditionalProperties, &allowAdditional) != nil {
+			additional = &openAPISchema{}
+			json.Unmarshal(s.AdditionalProperties, additional)
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			switch property, ok := s.Properties[name]; {
+			case ok:
+				problems = append(problems, doc.validate(property, object[name], at+"."+name)...)
+			case additional != nil:
+				problems = append(problems, doc.validate(additional, object[name], at+"."+name)...)
+			case !allowAdditional:
+				problems = append(problems, fmt.Sprintf("%s: properti %q tidak terdokumentasi", at, name))
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return append(problems, fmt.Sprintf("%s: ingin array, dapat %T", at, value))
+		}
+		for i, item := range array {
+			problems = append(problems, doc.validate(s.Items, item, fmt.Sprintf("%s[%d]", at, i))...)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			problems = append(problems, fmt.Sprintf("%s: ingin string, dapat %T", at, value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			problems = append(problems, fmt.Sprintf("%s: ingin boolean, dapat %T", at, value))
+		}
+	case "integer", "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			return append(problems, fmt.Sprintf("%s: ingin %s, dapat %T", at, s.Type, value))
+		}
+		if _, err := number.Int64(); s.Type == "integer" && err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s bukan integer", at, number))
+		}
+		f, _ := number.Float64()
+		if (s.Minimum != nil && f < *s.Minimum) || (s.Maximum != nil && f > *s.Maximum) {
+			problems = append(problems, fmt.Sprintf("%s: %s di luar batas", at, number))
+		}
+	}
+	return problems
+}
+
+// validateParameter memeriksa nilai parameter request (string) terhadap
+// schema parameter.
+func (doc *openAPIDocument) validateParameter(p *openAPIParameter, raw string) []string {
+	at := p.In + " " + p.Name
+	var value any = raw
+	switch doc.schema(p.Schema).Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return []string{fmt.Sprintf("%s: %q bukan angka", at, raw)}
+		}
+		value = json.Number(raw)
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return []string{fmt.Sprintf("%s: %q bukan boolean", at, raw)}
+		}
+		value = b
+	}
+	return doc.validate(p.Schema, value, at)
+}
+
+// operation mencari path template dan operasi spesifikasi untuk request.
+func (doc *openAPIDocument) operation(method, path string) (*openAPIOperation, map[string]string) {
+	segments := strings.Split(path, "/")
+	for template, operations := range doc.Paths {
+		parts := strings.Split(template, "/")
+		if len(parts) != len(segments) {
+			continue
+		}
+		pathParams := make(map[string]string)
+		for i, part := range parts {
+			if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+				pathParams[part[1:len(part)-1]] = segments[i]
+			} else if part != segments[i] {
+				pathParams = nil
+				break
+			}
+		}
+		if pathParams != nil {
+			return operations[strings.ToLower(method)], pathParams
+		}
+	}
+	return nil, nil
+}
+
+// validateRequest memeriksa parameter dan body request terhadap operasi.
+func (doc *openAPIDocument) validateRequest(op *openAPIOperation, r *http.Request, pathParams map[string]string, body []byte) []string {
+	var problems []string
+	declared := make(map[string]bool)
+	for _, p := range op.Parameters {
+		p = doc.parameter(p)
+		declared[p.In+" "+p.Name] = true
+		var raw string
+		var present bool
+		switch p.In {
+		case "query":
+			present = r.URL.Query().Has(p.Name)
+			raw = r.URL.Query().Get(p.Name)
+		case "path":
+			raw, present = pathParams[p.Name]
+		case "header":
+			raw = r.Header.Get(p.Name)
+			present = raw != ""
+		}
+		if !present {
+			if p.Required {
+				problems = append(problems, fmt.Sprintf("%s %s wajib", p.In, p.Name))
+			}
+			continue
+		}
+		problems = append(problems, doc.validateParameter(p, raw)...)
+	}
+	for name := range r.URL.Query() {
+		if !declared["query "+name] {
+			problems = append(problems, fmt.Sprintf("query %s tidak terdokumentasi", name))
+		}
+	}
+	if op.RequestBody != nil {
+		media, ok := op.RequestBody.Content[r.Header.Get("Content-Type")]
+		if !ok {
+			return append(problems, fmt.Sprintf("Content-Type body %q tidak terdokumentasi", r.Header.Get("Content-Type")))
+		}
+		problems = append(problems, doc.validateJSON(media.Schema, body, "body")...)
+	}
+	return problems
+}
+
+// validateResponse memeriksa status, Content-Type, dan body JSON respons.
+func (doc *openAPIDocument) validateResponse(op *openAPIOperation, rec *httptest.ResponseRecorder) []string {
+	response := doc.response(op.Responses[strconv.Itoa(rec.Code)])
+	if response == nil {
+		return []string{fmt.Sprintf("status %d tidak terdokumentasi", rec.Code)}
+	}
+	contentType, _, _ := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	media, ok := response.Content[contentType]
+	if !ok {
+		media, ok = response.Content[strings.Split(contentType, "/")[0]+"/*"]
+	}
+	if !ok {
+		return []string{fmt.Sprintf("Content-Type %q untuk status %d tidak terdokumentasi", contentType, rec.Code)}
+	}
+	if contentType != "application/json" {
+		return nil
+	}
+	return doc.validateJSON(media.Schema, rec.Body.Bytes(), "respons")
+}
+
+func (doc *openAPIDocument) validateJSON(s *openAPISchema, data []byte, at string) []string {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return []string{fmt.Sprintf("%s: JSON tidak valid: %v", at, err)}
+	}
+	return doc.validate(s, value, at)
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	doc := loadOpenAPIDocument(t)
+	patterns := make(map[string]bool)
+	for _, route := range apiRoutes {
+		patterns[route.pattern] = true
+		if len(doc.Paths[route.pattern]) == 0 {
+			t.Errorf("rute %s tidak ada di openapi.json", route.pattern)
+		}
+	}
+	for path := range doc.Paths {
+		if !patterns[path] {
+			t.Errorf("openapi.json mendokumentasikan %s yang tidak terdaftar di apiRoutes", path)
+		}
+	}
+
+	// Semua $ref harus bisa di-resolve
+	var refs []string
+	var collect func(v any)
+	collect = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"].(string); ok {
+				refs = append(refs, ref)
+			}
+			for _, child := range v {
+				collect(child)
+			}
+		case []any:
+			for _, child := range v {
+				collect(child)
+			}
+		}
+	}
+	var raw any
+	json.Unmarshal(openAPISpec, &raw)
+	collect(raw)
+	components := raw.(map[string]any)["components"].(map[string]any)
+	for _, ref := range refs {
+		parts := strings.Split(strings.TrimPrefix(ref, "#/components/"), "/")
+		if kind, ok := components[parts[0]].(map[string]any); !ok || len(parts) != 2 || kind[parts[1]] == nil {
+			t.Errorf("$ref %q tidak ditemukan", ref)
+		}
+	}
+}
+
+func TestOpenAPIConformance(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+	fakeImageFetcher(t, func(url string) (CachedImage, error) { return pngImage, nil })
+	t.Setenv(adminTokenEnv, "rahasia")
+
+	doc := loadOpenAPIDocument(t)
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+
+	diffBody := `{"new": [{"result": "Mud", "ingredient1": "Water", "ingredient2": "Earth"}]}`
+	tests := []struct {
+		method, path, token, body string
+		status                    int
+	}{
+		{"GET", "/api/search?target=Mud", "", "", http.StatusOK},
+		{"GET", "/api/search?target=Brick&mode=multiple&max=2&seed=1&diversity=0.5", "", "", http.StatusOK},
+		{"GET", "/api/search?target=Brick&mode=cheapest", "", "", http.StatusOK},
+		{"GET", "/api/search?target=Brick&algo=iddfs&maxDepth=5", "", "", http.StatusOK},
+		{"GET", "/api/search?target=Brick&algo=iddfs&lowMemory=true", "", "", http.StatusOK},
+		{"GET", "/api/search?target=Brick&parallel=true&dataset=raw", "", "", http.StatusOK},
+		{"GET", "/api/search?target=Brick&algo=kbest&mode=multiple&max=2&require=Stone", "", "", http.StatusOK},
+		{"GET", "/api/search?target=Brick&avoid=Fire,Mud", "", "", http.StatusOK},
+		{"GET", "/api/search?target=Brick&format=dot", "", "", http.StatusOK},
+		{"GET", "/api/search?target=Mud&format=svg", "", "", http.StatusOK},
+		{"GET", "/api/search?target=Mud&format=markdown", "", "", http.StatusOK},
+		{"GET", "/api/search?target=Bukanelemen", "", "", http.StatusBadRequest},
+		{"GET", "/api/search?target=Mud&game=zz", "", "", http.StatusBadRequest},
+		{"GET", "/api/image?elementName=Mud", "", "", http.StatusOK},
+		{"GET", "/api/element/Brick/ancestors?depth=2&contains=Fire,Air", "", "", http.StatusOK},
+		{"GET", "/api/element/Brick/descendants", "", "", http.StatusOK},
+		{"GET", "/api/element/Brick/required", "", "", http.StatusOK},
+		{"GET", "/api/element/Bukanelemen/required", "", "", http.StatusNotFound},
+		{"GET", "/api/stats", "", "", http.StatusOK},
+		{"GET", "/api/meta", "", "", http.StatusOK},
+		{"GET", "/api/datasets", "", "", http.StatusOK},
+		{"GET", "/api/games", "", "", http.StatusOK},
+		{"GET", "/api/export", "", "", http.StatusOK},
+		{"GET", "/api/export?format=graphml", "", "", http.StatusOK},
+		{"GET", "/api/openapi.json", "", "", http.StatusOK},
+		{"POST", "/api/admin/diff", "rahasia", diffBody, http.StatusOK},
+		{"POST", "/api/admin/diff", "salah", diffBody, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		name := tt.method + " " + tt.path
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		if tt.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if tt.token != "" {
+			req.Header.Set("X-Admin-Token", tt.token)
+		}
+		op, pathParams := doc.operation(tt.method, req.URL.Path)
+		if op == nil {
+			t.Errorf("%s: operasi tidak ada di openapi.json", name)
+			continue
+		}
+		for _, problem := range doc.validateRequest(op, req, pathParams, []byte(tt.body)) {
+			t.Errorf("%s: request: %s", name, problem)
+		}
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, ingin %d: %s", name, rec.Code, tt.status, rec.Body.String())
+		}
+		for _, problem := range doc.validateResponse(op, rec) {
+			t.Errorf("%s: %s", name, problem)
+		}
+	}
+}
+
+func TestOpenAPIValidatorRejects(t *testing.T) {
+	doc := loadOpenAPIDocument(t)
+	recipe := &openAPISchema{Ref: "#/components/schemas/Recipe"}
+	for input, want := range map[string]string{
+		`{"result": "Mud", "ingredient1": "Water"}`:                                    `properti wajib "ingredient2"`,
+		`{"result": "Mud", "ingredient1": "Water", "ingredient2": 1}`:                  "ingin string",
+		`{"result": "Mud", "ingredient1": "Water", "ingredient2": "Earth", "x": true}`: `properti "x" tidak terdokumentasi`,
+	} {
+		problems := doc.validateJSON(recipe, []byte(input), "recipe")
+		if len(problems) != 1 || !strings.Contains(problems[0], want) {
+			t.Errorf("validate(%s) = %v, ingin %q", input, problems, want)
+		}
+	}
+
+	op, _ := doc.operation("GET", "/api/search")
+	req := httptest.NewRequest("GET", "/api/search?algo=astar&max=abc&unknown=1", nil)
+	got := doc.validateRequest(op, req, nil, nil)
+	sort.Strings(got)
+	want := []string{
+		"query algo: astar tidak ada di enum [bfs dfs bds kbest iddfs]",
+		`query max: "abc" bukan angka`,
+		"query target wajib",
+		"query unknown tidak terdokumentasi",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("validateRequest = %q, ingin %q", got, want)
+	}
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openAPIHandler(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	body, _ := io.ReadAll(rec.Body)
+	if rec.Code != http.StatusOK || !bytes.Equal(body, openAPISpec) || rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("status = %d, Content-Type = %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+}
@@ -0,0 +1,265 @@
+// src/backend/api/render.go
+package api
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"tubes2stima/backend/dataset"
+)
+
+// Ukuran layout SVG (dalam piksel).
+const (
+	renderNodeHeight = 40
+	renderImageSize  = 28
+	renderPadding    = 10
+	renderCharWidth  = 7
+	renderLevelGap   = 60
+	renderNodeGap    = 20
+	renderTreeGap    = 40
+	renderMargin     = 20
+	renderTitleSize  = 24
+)
+
+// treeNode adalah satu elemen di layout pohon resep.
+type treeNode struct {
+	name  string
+	level int  // 0 = elemen dasar / daun, target di level tertinggi
+	base  bool // Tidak dihasilkan langkah mana pun di jalur
+	x, y  int
+	width int
+}
+
+// treeLayout adalah hasil layout satu jalur: elemen disusun per level
+// (level hasil = 1 + level bahan tertinggi), target di atas.
+type treeLayout struct {
+	nodes  []*treeNode
+	byName map[string]*treeNode
+	steps  []dataset.Recipe // Resep yang dipakai, satu per elemen hasil
+	width  int
+	height int
+}
+
+// RecipeTreeSteps memetakan setiap hasil di path ke resep pertamanya, lalu
+// mengembalikan resep yang benar-benar dipakai target dalam urutan DFS.
+// Elemen tanpa resep di stepFor adalah elemen dasar edisi game jalur itu
+// (jalur valid tidak pernah menghasilkan elemen dasar).
+func RecipeTreeSteps(path []dataset.Recipe, target string) (map[string]dataset.Recipe, []string) {
+	stepFor := make(map[string]dataset.Recipe, len(path))
+	for _, step := range path {
+		if _, ok := stepFor[step.Result]; !ok {
+			stepFor[step.Result] = step
+		}
+	}
+	var order []string
+	visited := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		order = append(order, name)
+		if step, ok := stepFor[name]; ok {
+			visit(step.Ingredient1)
+			visit(step.Ingredient2)
+		}
+	}
+	visit(target)
+	return stepFor, order
+}
+
+// layoutRecipeTree menyusun posisi node untuk satu jalur. imageSpace
+// menambah ruang gambar di kiri nama elemen.
+func layoutRecipeTree(path []dataset.Recipe, target string, imageSpace func(string) bool) *treeLayout {
+	stepFor, order := RecipeTreeSteps(path, target)
+	layout := &treeLayout{byName: make(map[string]*treeNode, len(order))}
+
+	levels := make(map[string]int, len(order))
+	var levelOf func(name string) int
+	levelOf = func(name string) int {
+		if lvl, ok := levels[name]; ok {
+			return lvl
+		}
+		levels[name] = 0 // Penjaga siklus
+		step, ok := stepFor[name]
+		if !ok {
+			return 0
+		}
+		lvl := 1 + max(levelOf(step.Ingredient1), levelOf(step.Ingredient2))
+		levels[name] = lvl
+		return lvl
+	}
+
+	maxLevel := 0
+	for _, name := range order {
+		maxLevel = max(maxLevel, levelOf(name))
+	}
+	rows := make([][]*treeNode, maxLevel+1)
+	for _, name := range order {
+		_, produced := stepFor[name]
+		node := &treeNode{name: name, level: levels[name], base: !produced}
+		node.width = 2*renderPadding + renderCharWidth*utf8.RuneCountInString(name)
+		if imageSpace(name) {
+			node.width += renderImageSize + renderPadding/2
+		}
+		layout.nodes = append(layout.nodes, node)
+		layout.byName[name] = node
+		rows[node.level] = append(rows[node.level], node)
+		if step, ok := stepFor[name]; ok {
+			layout.steps = append(layout.steps, step)
+		}
+	}
+
+	rowWidths := make([]int, len(rows))
+	for lvl, row := range rows {
+		for i, node := range row {
+			if i > 0 {
+				rowWidths[lvl] += renderNodeGap
+			}
+			rowWidths[lvl] += node.width
+		}
+		layout.width = max(layout.width, rowWidths[lvl])
+	}
+	for lvl, row := range rows {
+		x := (layout.width - rowWidths[lvl]) / 2
+		y := (maxLevel - lvl) * (renderNodeHeight + renderLevelGap)
+		for _, node := range row {
+			node.x, node.y = x, y
+			x += node.width + renderNodeGap
+		}
+	}
+	layout.height = (maxLevel+1)*renderNodeHeight + maxLevel*renderLevelGap
+	return layout
+}
+
+// renderPaths mengembalikan jalur yang dirender; target elemen dasar (tanpa
+// langkah) tetap dirender sebagai satu node.
+func renderPaths(paths [][]dataset.Recipe) [][]dataset.Recipe {
+	if len(paths) == 0 {
+		return [][]dataset.Recipe{nil}
+	}
+	return paths
+}
+
+// WriteRecipeTreesDOT menulis pohon resep target sebagai graf graphviz. Pada
+// mode multiple setiap jalur menjadi subgraph cluster sendiri.
+func WriteRecipeTreesDOT(w io.Writer, target string, paths [][]dataset.Recipe) error {
+	paths = renderPaths(paths)
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "digraph recipe_tree {")
+	fmt.Fprintln(b, "  rankdir=BT;")
+	fmt.Fprintln(b, "  node [shape=box, style=rounded];")
+	fmt.Fprintf(b, "  label=%s;\n", dataset.DotQuote(target))
+	fmt.Fprintln(b, "  labelloc=t;")
+	for i, path := range paths {
+		indent := "  "
+		if len(paths) > 1 {
+			fmt.Fprintf(b, "  subgraph cluster_%d {\n", i)
+			fmt.Fprintf(b, "    label=%s;\n", dataset.DotQuote(fmt.Sprintf("Jalur %d", i+1)))
+			indent = "    "
+		}
+		id := func(name string) string { return dataset.DotQuote(fmt.Sprintf("%d:%s", i, name)) }
+		stepFor, order := RecipeTreeSteps(path, target)
+		for _, name := range order {
+			if _, produced := stepFor[name]; !produced {
+				fmt.Fprintf(b, "%s%s [label=%s, style=\"rounded,filled\", fillcolor=lightblue];\n", indent, id(name), dataset.DotQuote(name))
+			} else {
+				fmt.Fprintf(b, "%s%s [label=%s];\n", indent, id(name), dataset.DotQuote(name))
+			}
+		}
+		for _, name := range order {
+			step, ok := stepFor[name]
+			if !ok {
+				continue
+			}
+			recipeID := id("=" + name)
+			fmt.Fprintf(b, "%s%s [shape=point];\n", indent, recipeID)
+			fmt.Fprintf(b, "%s%s -> %s;\n", indent, id(step.Ingredient1), recipeID)
+			fmt.Fprintf(b, "%s%s -> %s;\n", indent, id(step.Ingredient2), recipeID)
+			fmt.Fprintf(b, "%s%s -> %s;\n", indent, recipeID, id(name))
+		}
+		if len(paths) > 1 {
+			fmt.Fprintln(b, "  }")
+		}
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// svgEscape meng-escape teks untuk isi elemen atau atribut SVG.
+func svgEscape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// WriteRecipeTreesSVG merender pohon resep target sebagai SVG mandiri.
+// images berisi data: URI per nama elemen (lihat CachedImage.DataURI);
+// elemen tanpa gambar hanya ditampilkan namanya. Pada mode multiple setiap
+// jalur dirender di bawah jalur sebelumnya dengan judul sendiri.
+func WriteRecipeTreesSVG(w io.Writer, target string, paths [][]dataset.Recipe, images map[string]string) error {
+	paths = renderPaths(paths)
+	hasImage := func(name string) bool { return images[name] != "" }
+	layouts := make([]*treeLayout, len(paths))
+	width, height := 0, renderMargin+renderTitleSize
+	for i, path := range paths {
+		layouts[i] = layoutRecipeTree(path, target, hasImage)
+		width = max(width, layouts[i].width)
+		if len(paths) > 1 {
+			height += renderTitleSize
+		}
+		height += layouts[i].height + renderTreeGap
+	}
+	width += 2 * renderMargin
+	height += renderMargin - renderTreeGap
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n", width, height, width, height)
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+	fmt.Fprintf(b, `<text x="%d" y="%d" font-size="16" font-weight="bold">%s</text>`+"\n", renderMargin, renderMargin+16, svgEscape(target))
+
+	offsetY := renderMargin + renderTitleSize
+	for i, layout := range layouts {
+		if len(paths) > 1 {
+			fmt.Fprintf(b, `<text x="%d" y="%d" font-size="14">Jalur %d</text>`+"\n", renderMargin, offsetY+14, i+1)
+			offsetY += renderTitleSize
+		}
+		offsetX := renderMargin + (width-2*renderMargin-layout.width)/2
+		fmt.Fprintf(b, `<g transform="translate(%d,%d)">`+"\n", offsetX, offsetY)
+
+		// Garis resep: bahan -> titik resep -> hasil
+		for _, step := range layout.steps {
+			result := layout.byName[step.Result]
+			jx, jy := result.x+result.width/2, result.y+renderNodeHeight+renderLevelGap/3
+			for _, ingredient := range []string{step.Ingredient1, step.Ingredient2} {
+				node := layout.byName[ingredient]
+				fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#888"/>`+"\n", node.x+node.width/2, node.y, jx, jy)
+			}
+			fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#888"/>`+"\n", jx, jy, jx, result.y+renderNodeHeight)
+			fmt.Fprintf(b, `<circle cx="%d" cy="%d" r="3" fill="#555"/>`+"\n", jx, jy)
+		}
+
+		for _, node := range layout.nodes {
+			fill := "#fff8e7"
+			if node.base {
+				fill = "#dbeafe"
+			}
+			fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="%s" stroke="#555"/>`+"\n", node.x, node.y, node.width, renderNodeHeight, fill)
+			textX := node.x + renderPadding
+			if uri := images[node.name]; uri != "" {
+				fmt.Fprintf(b, `<image x="%d" y="%d" width="%d" height="%d" xlink:href="%s"/>`+"\n", textX, node.y+(renderNodeHeight-renderImageSize)/2, renderImageSize, renderImageSize, svgEscape(uri))
+				textX += renderImageSize + renderPadding/2
+			}
+			fmt.Fprintf(b, `<text x="%d" y="%d">%s</text>`+"\n", textX, node.y+renderNodeHeight/2+4, svgEscape(node.name))
+		}
+		fmt.Fprintln(b, "</g>")
+		offsetY += layout.height + renderTreeGap
+	}
+	fmt.Fprintln(b, "</svg>")
+	return b.Flush()
+}
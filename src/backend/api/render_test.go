@@ -0,0 +1,174 @@
+// src/backend/api/render_test.go
+package api
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeImageFetcher mengganti unduhan gambar selama test dan menghitung
+// jumlah unduhan.
+func fakeImageFetcher(t *testing.T, fetch func(url string) (CachedImage, error)) *int {
+	t.Helper()
+	calls := 0
+	oldFetcher, oldDir := imageFetcher, imageCacheDir
+	imageFetcher = func(url string) (CachedImage, error) {
+		calls++
+		return fetch(url)
+	}
+	resetImageCache := func() {
+		imageCacheEntries = make(map[string]CachedImage)
+		imageCacheFailures = make(map[string]time.Time)
+	}
+	resetImageCache()
+	t.Cleanup(func() {
+		imageFetcher, imageCacheDir = oldFetcher, oldDir
+		resetImageCache()
+	})
+	return &calls
+}
+
+var pngImage = CachedImage{ContentType: "image/png", Data: []byte("\x89PNG\r\n\x1a\n")}
+
+func TestImageCache(t *testing.T) {
+	calls := fakeImageFetcher(t, func(url string) (CachedImage, error) {
+		if strings.Contains(url, "rusak") {
+			return CachedImage{}, &imageFetchError{URL: url, StatusCode: http.StatusNotFound}
+		}
+		return pngImage, nil
+	})
+	imageCacheDir = t.TempDir()
+
+	for i := 0; i < 2; i++ {
+		if img, err := GetCachedImage("https://example.com/a.png"); err != nil || !bytes.Equal(img.Data, pngImage.Data) {
+			t.Fatalf("GetCachedImage = %v, %v", img, err)
+		}
+		var fetchErr *imageFetchError
+		if _, err := GetCachedImage("https://example.com/rusak.png"); !errors.As(err, &fetchErr) && i == 0 {
+			t.Errorf("error = %v, ingin imageFetchError", err)
+		}
+	}
+	if *calls != 2 {
+		t.Errorf("%d unduhan, ingin 2 (satu per URL)", *calls)
+	}
+
+	// Cache memori dikosongkan: gambar tetap dibaca dari disk tanpa jaringan
+	imageCacheEntries = make(map[string]CachedImage)
+	imageFetcher = func(url string) (CachedImage, error) { return CachedImage{}, errors.New("offline") }
+	img, err := GetCachedImage("https://example.com/a.png")
+	if err != nil || img.ContentType != "image/png" {
+		t.Errorf("dari disk: %v, %v", img, err)
+	}
+}
+
+func TestImageContentTypeFromUpstream(t *testing.T) {
+	header := "image/png"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", header)
+		w.Write(pngImage.Data)
+	}))
+	defer server.Close()
+
+	for sent, want := range map[string]string{
+		"image/PNG; charset=binary":   "image/png",
+		`image/png" onload="alert(1)`: "image/png",
+		"text/html":                   "image/png",
+		"image/svg+xml":               "image/svg+xml",
+	} {
+		header = sent
+		img, err := fetchImageHTTP(server.URL)
+		if err != nil || img.ContentType != want {
+			t.Errorf("Content-Type %q: tipe = %q (%v), ingin %q", sent, img.ContentType, err, want)
+		}
+	}
+}
+
+func TestSVGEscapesImageURI(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+	fakeImageFetcher(t, func(url string) (CachedImage, error) {
+		return CachedImage{ContentType: `image/png" onload="alert(1)`, Data: pngImage.Data}, nil
+	})
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/search?target=Mud&format=svg", nil))
+	decoder := xml.NewDecoder(rec.Body)
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("SVG tidak valid: %v", err)
+		}
+		if el, ok := tok.(xml.StartElement); ok {
+			for _, attr := range el.Attr {
+				if attr.Name.Local == "onload" {
+					t.Fatalf("Content-Type keluar dari atribut xlink:href: %v", el)
+				}
+			}
+		}
+	}
+}
+
+func TestSearchRenderFormats(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+	fakeImageFetcher(t, func(url string) (CachedImage, error) { return pngImage, nil })
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/api/search?target=Mud&format=svg")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/svg+xml" {
+		t.Fatalf("svg: status = %d, Content-Type = %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	svg := rec.Body.String()
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("SVG tidak valid: %v\n%s", err, svg)
+		}
+	}
+	for _, want := range []string{">Mud</text>", ">Earth</text>", ">Water</text>", "data:image/png;base64,"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG tidak berisi %q", want)
+		}
+	}
+
+	dot := get("/api/search?target=Mud&format=dot").Body.String()
+	for _, want := range []string{`"0:Mud" [label="Mud"];`, `-> "0:Mud";`, `"0:Water" [label="Water", style="rounded,filled", fillcolor=lightblue];`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT tidak berisi %q:\n%s", want, dot)
+		}
+	}
+	if dot := get("/api/search?target=Stone&mode=multiple&max=3&format=dot").Body.String(); !strings.Contains(dot, "subgraph cluster_1") {
+		t.Errorf("DOT mode multiple tidak berisi beberapa cluster:\n%s", dot)
+	}
+
+	for path, want := range map[string]int{
+		"/api/search?target=Fire&format=svg":  http.StatusOK,
+		"/api/search?target=Mud&format=pdf":   http.StatusBadRequest,
+		"/api/search?target=Mud&format=JSON":  http.StatusOK,
+		"/api/search?target=Brick&format=dot": http.StatusOK,
+	} {
+		if rec := get(path); rec.Code != want {
+			t.Errorf("%s: status = %d, ingin %d", path, rec.Code, want)
+		}
+	}
+}
@@ -0,0 +1,413 @@
+// src/backend/api/search_request.go
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/graph"
+	"tubes2stima/backend/search"
+)
+
+// SearchRequest adalah parameter pencarian yang sudah divalidasi. Dipakai
+// searchHandler dan subcommand search agar keduanya menjalankan pencarian
+// yang sama persis.
+type SearchRequest struct {
+	Game        *GameData
+	Dataset     *dataset.Dataset // Dataset game atau hasil profil filter (dataset=)
+	DatasetName string
+	Target      string
+	Algo        string // Nama algoritma di respons ("knuth" pada mode cheapest)
+	SearchAlgo  string // Varian yang dijalankan search.Run (misalnya "bfs-parallel")
+	Mode        string
+	Format      string
+	MaxRecipes  int
+	SearchMax   int // Jumlah jalur yang dicari (lebih besar dari MaxRecipes pada mode diversity)
+	MaxDepth    int
+	LowMemory   bool // IDDFS tanpa cache, memori O(kedalaman)
+	Parallel    bool
+	Seed        *int64
+	Diversity   *float64
+	Constraints search.SearchConstraints
+}
+
+// ParseSearchRequest membaca dan memvalidasi parameter pencarian untuk game
+// g. Jika tidak valid, dikembalikan error beserta status HTTP-nya.
+func ParseSearchRequest(g *GameData, query url.Values) (*SearchRequest, int, error) {
+	// 1. Ambil Query Parameters
+	targetElement := strings.TrimSpace(query.Get("target"))
+
+	targetElement = g.ResolveElementName(targetElement)
+
+	algo := strings.ToLower(strings.TrimSpace(query.Get("algo")))
+	mode := strings.ToLower(strings.TrimSpace(query.Get("mode")))
+	maxRecipesStr := query.Get("max")
+	seedStr := strings.TrimSpace(query.Get("seed"))
+	diversityStr := strings.TrimSpace(query.Get("diversity"))
+	avoidStr := strings.TrimSpace(query.Get("avoid"))
+	requireStr := strings.TrimSpace(query.Get("require"))
+	maxDepthStr := strings.TrimSpace(query.Get("maxDepth"))
+	lowMemoryStr := strings.ToLower(strings.TrimSpace(query.Get("lowMemory")))
+	parallelStr := strings.ToLower(strings.TrimSpace(query.Get("parallel")))
+	datasetName := strings.TrimSpace(query.Get("dataset"))
+	format := strings.ToLower(strings.TrimSpace(query.Get("format")))
+
+	// Default values jika parameter tidak ada
+	if algo == "" {
+		algo = "bfs" // Default ke BFS
+	}
+	if mode == "" {
+		mode = "shortest" // Default ke mode shortest
+	}
+	if format == "" {
+		format = "json"
+	}
+
+	// 2. Validasi Input Dasar
+	if targetElement == "" {
+		return nil, http.StatusBadRequest, errors.New("Parameter 'target' diperlukan")
+	}
+	if !g.HasElement(targetElement) {
+		return nil, http.StatusBadRequest, fmt.Errorf("Elemen target '%s' tidak valid atau tidak ditemukan", targetElement)
+	}
+
+	// Parameter 'dataset' (opsional): cari di resep hasil profil filter lain
+	// (lihat filter/pipeline.go). Tanpa parameter ini dipakai dataset game.
+	d := g.Dataset()
+	if datasetName != "" {
+		if g.FilterConfig().Profile(datasetName) == nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("Parameter 'dataset': profil '%s' tidak dikenal", datasetName)
+		}
+		var datasetErr error
+		d, _, datasetErr = g.DatasetForProfile(datasetName)
+		if datasetErr != nil {
+			log.Printf("Error membangun dataset profil '%s': %v", datasetName, datasetErr)
+			return nil, http.StatusInternalServerError, fmt.Errorf("Gagal membangun dataset '%s'", datasetName)
+		}
+		if !d.HasElement(targetElement) {
+			return nil, http.StatusBadRequest, fmt.Errorf("Elemen target '%s' tidak ada di dataset '%s'", targetElement, datasetName)
+		}
+	}
+	if format == "md" {
+		format = "markdown"
+	}
+	if format != "json" && format != "dot" && format != "svg" && format != "text" && format != "markdown" { // Validasi format respons
+		return nil, http.StatusBadRequest, errors.New("Parameter 'format' harus 'json', 'dot', 'svg', 'text', atau 'markdown'")
+	}
+	if algo != "bfs" && algo != "dfs" && algo != "bds" && algo != "kbest" && algo != "iddfs" { // Validasi algoritma
+		return nil, http.StatusBadRequest, errors.New("Parameter 'algo' harus 'bfs', 'dfs', 'bds', 'kbest', atau 'iddfs'")
+	}
+	if mode != "shortest" && mode != "multiple" && mode != "cheapest" { // Validasi mode
+		return nil, http.StatusBadRequest, errors.New("Parameter 'mode' harus 'shortest', 'multiple', atau 'cheapest'")
+	}
+	if mode == "cheapest" {
+		// Mode cheapest selalu memakai algoritma Knuth (lihat search/costs.go)
+		algo = "knuth"
+	}
+	if algo == "iddfs" && mode == "multiple" {
+		return nil, http.StatusBadRequest, errors.New("Algoritma 'iddfs' hanya mendukung mode 'shortest'")
+	}
+
+	// Parameter 'parallel' (opsional): BFS shortest level-synchronous paralel.
+	// searchAlgo adalah nama varian yang dijalankan search.Run.
+	searchAlgo := algo
+	parallel := false
+	if parallelStr != "" {
+		var convErr error
+		parallel, convErr = strconv.ParseBool(parallelStr)
+		if convErr != nil {
+			return nil, http.StatusBadRequest, errors.New("Parameter 'parallel' harus 'true' atau 'false'")
+		}
+		if parallel && (algo != "bfs" || mode != "shortest") {
+			return nil, http.StatusBadRequest, errors.New("Parameter 'parallel' hanya berlaku untuk algo 'bfs' mode 'shortest'")
+		}
+		if parallel {
+			searchAlgo = "bfs-parallel"
+		}
+	}
+
+	// Parameter 'maxDepth' (opsional, khusus iddfs): batas kedalaman maksimum
+	maxDepth := 0
+	if maxDepthStr != "" {
+		if algo != "iddfs" {
+			return nil, http.StatusBadRequest, errors.New("Parameter 'maxDepth' hanya berlaku untuk algo 'iddfs'")
+		}
+		var convErr error
+		maxDepth, convErr = strconv.Atoi(maxDepthStr)
+		if convErr != nil || maxDepth <= 0 {
+			return nil, http.StatusBadRequest, errors.New("Parameter 'maxDepth' harus berupa angka positif")
+		}
+	}
+
+	// Parameter 'lowMemory' (opsional, khusus iddfs): IDDFS tanpa cache per
+	// elemen (search.FindPathIDDFSNoCache)
+	lowMemory := false
+	if lowMemoryStr != "" {
+		var convErr error
+		lowMemory, convErr = strconv.ParseBool(lowMemoryStr)
+		if convErr != nil {
+			return nil, http.StatusBadRequest, errors.New("Parameter 'lowMemory' harus 'true' atau 'false'")
+		}
+		if lowMemory && algo != "iddfs" {
+			return nil, http.StatusBadRequest, errors.New("Parameter 'lowMemory' hanya berlaku untuk algo 'iddfs'")
+		}
+	}
+
+	// 3. Proses parameter 'max' jika mode 'multiple'
+	maxRecipes := 1 // Default untuk mode 'shortest' atau jika 'max' tidak valid
+	if mode == "multiple" {
+		if maxRecipesStr != "" {
+			var convErr error
+			maxRecipes, convErr = strconv.Atoi(maxRecipesStr)
+			if convErr != nil || maxRecipes <= 0 {
+				return nil, http.StatusBadRequest, errors.New("Parameter 'max' harus berupa angka positif lebih besar dari 0 untuk mode 'multiple'")
+			}
+		} else {
+			// Jika mode multiple tapi 'max' tidak disediakan, bisa set default atau error
+			// Untuk sekarang, kita error jika tidak ada 'max' di mode multiple
+			return nil, http.StatusBadRequest, errors.New("Parameter 'max' diperlukan untuk mode 'multiple'")
+		}
+	}
+
+	// Parameter 'seed' (opsional) mengaktifkan mode deterministik untuk 'multiple'
+	var seed *int64
+	if seedStr != "" {
+		if mode != "multiple" {
+			return nil, http.StatusBadRequest, errors.New("Parameter 'seed' hanya berlaku untuk mode 'multiple'")
+		}
+		parsedSeed, convErr := strconv.ParseInt(seedStr, 10, 64)
+		if convErr != nil {
+			return nil, http.StatusBadRequest, errors.New("Parameter 'seed' harus berupa bilangan bulat")
+		}
+		seed = &parsedSeed
+	}
+
+	// Parameter 'diversity' (opsional, 0..1): pilih jalur yang saling berbeda
+	// dari kumpulan kandidat yang lebih besar (lihat search.SelectDiversePaths)
+	var diversity *float64
+	searchMax := maxRecipes
+	if diversityStr != "" {
+		if mode != "multiple" {
+			return nil, http.StatusBadRequest, errors.New("Parameter 'diversity' hanya berlaku untuk mode 'multiple'")
+		}
+		parsedDiversity, convErr := strconv.ParseFloat(diversityStr, 64)
+		if convErr != nil || parsedDiversity < 0 || parsedDiversity > 1 {
+			return nil, http.StatusBadRequest, errors.New("Parameter 'diversity' harus berupa angka antara 0 dan 1")
+		}
+		diversity = &parsedDiversity
+		searchMax = maxRecipes * search.DiversityPoolFactor
+	}
+
+	// Parameter 'avoid' dan 'require' (opsional): daftar elemen dipisah koma
+	var constraints search.SearchConstraints
+	var listErr error
+	if constraints.Avoid, listErr = parseElementList(g, avoidStr); listErr != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("Parameter 'avoid': %v", listErr)
+	}
+	if constraints.Require, listErr = parseElementList(g, requireStr); listErr != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("Parameter 'require': %v", listErr)
+	}
+	if len(constraints.Require) > search.MaxRequiredElements {
+		return nil, http.StatusBadRequest, fmt.Errorf("Parameter 'require' berisi paling banyak %d elemen", search.MaxRequiredElements)
+	}
+	for _, el := range constraints.Avoid {
+		if el == targetElement {
+			return nil, http.StatusBadRequest, fmt.Errorf("Elemen target '%s' tidak boleh ada di 'avoid'", targetElement)
+		}
+		for _, req := range constraints.Require {
+			if el == req {
+				return nil, http.StatusBadRequest, fmt.Errorf("Elemen '%s' tidak bisa ada di 'avoid' dan 'require' sekaligus", el)
+			}
+		}
+	}
+
+	return &SearchRequest{
+		Game:        g,
+		Dataset:     d,
+		DatasetName: datasetName,
+		Target:      targetElement,
+		Algo:        algo,
+		SearchAlgo:  searchAlgo,
+		Mode:        mode,
+		Format:      format,
+		MaxRecipes:  maxRecipes,
+		SearchMax:   searchMax,
+		MaxDepth:    maxDepth,
+		LowMemory:   lowMemory,
+		Parallel:    parallel,
+		Seed:        seed,
+		Diversity:   diversity,
+		Constraints: constraints,
+	}, http.StatusOK, nil
+}
+
+// Execute menjalankan pencarian dan menyusun respons, termasuk URL gambar
+// proxy untuk semua elemen di jalur yang ditemukan.
+func (req *SearchRequest) Execute() MultiSearchResponse {
+	g, ds, targetElement := req.Game, req.Dataset, req.Target
+	algo, searchAlgo, mode, datasetName := req.Algo, req.SearchAlgo, req.Mode, req.DatasetName
+	maxRecipes, searchMax, maxDepth, parallel := req.MaxRecipes, req.SearchMax, req.MaxDepth, req.Parallel
+	lowMemory := req.LowMemory
+	seed, diversity, constraints := req.Seed, req.Diversity, req.Constraints
+
+	// 4. Panggil Fungsi Algoritma & Ukur Waktu
+	startTime := time.Now()
+
+	var singlePath []dataset.Recipe
+	var multiplePaths [][]dataset.Recipe
+	var nodesVisited int
+	var errSearch error // Ubah nama variabel error agar tidak bentrok dengan package 'errors'
+	var pathFound bool
+
+	log.Printf("Memulai pencarian: Target=%s, Algo=%s, Mode=%s, MaxRecipes=%d\n", targetElement, algo, mode, maxRecipes)
+
+	// --- Struktur Response Awal ---
+	response := MultiSearchResponse{
+		SearchTarget: targetElement,
+		Algorithm:    algo,
+		Mode:         mode,
+		Parallel:     parallel,
+		LowMemory:    lowMemory,
+		Dataset:      datasetName,
+		Game:         g.Edition.Name,
+	}
+	if mode == "multiple" {
+		response.MaxRecipes = maxRecipes // Set max recipes jika mode multiple
+		response.Seed = seed
+		response.Diversity = diversity
+	}
+	response.Avoid = constraints.Avoid
+	response.Require = constraints.Require
+
+	// --- Logika Pemilihan Algoritma ---
+	// Tanpa batasan, pencarian berjalan langsung di dataset terpilih; dengan
+	// avoid/require, pencarian berjalan di view dataset yang sudah difilter.
+	run := func(d *dataset.Dataset, target string) ([][]dataset.Recipe, int, error) {
+		if algo == "iddfs" {
+			// IDDFS dijalankan langsung agar data per iterasi ikut dikirim
+			findIDDFS := search.FindPathIDDFSIterations
+			if lowMemory {
+				findIDDFS = search.FindPathIDDFSNoCache
+			}
+			path, iterations, err := findIDDFS(d, target, maxDepth)
+			response.Iterations = append(response.Iterations, iterations...)
+			nodes := 0
+			for _, it := range iterations {
+				nodes += it.NodesVisited
+			}
+			if err != nil || len(path) == 0 {
+				return nil, nodes, err
+			}
+			return [][]dataset.Recipe{path}, nodes, nil
+		}
+		return search.Run(d, g.Costs(), searchAlgo, mode, target, searchMax, seed)
+	}
+	if constraints.IsEmpty() {
+		multiplePaths, nodesVisited, errSearch = run(ds, targetElement)
+	} else {
+		multiplePaths, nodesVisited, errSearch = search.SearchWithConstraints(ds, targetElement, constraints, run)
+	}
+	if mode != "multiple" { // shortest atau cheapest
+		if len(multiplePaths) > 0 {
+			singlePath = multiplePaths[0]
+		}
+		response.Path = singlePath
+		// pathFound true jika tidak ada error DAN (path tidak kosong ATAU target adalah elemen dasar)
+		pathFound = errSearch == nil && (len(singlePath) > 0 || ds.IsBase(targetElement))
+	} else { // mode == "multiple"
+		response.Paths = multiplePaths
+		pathFound = errSearch == nil && (len(multiplePaths) > 0 || ds.IsBase(targetElement))
+	}
+
+	// Elemen wajib target, untuk petunjuk seperti "Life selalu dibutuhkan"
+	if pathFound && !ds.IsBase(targetElement) {
+		if mandatory, err := graph.MandatoryElements(ds, targetElement); err == nil {
+			response.MandatoryElements = mandatory
+		}
+	}
+
+	// Mode cheapest: sertakan biaya per langkah dan total biaya jalur
+	if mode == "cheapest" && pathFound {
+		stepCosts, totalCost := g.Costs().PathCosts(singlePath)
+		response.StepCosts = stepCosts
+		response.TotalCost = &totalCost
+	}
+
+	// Mode diversity: pilih maxRecipes jalur yang paling saling berbeda
+	if diversity != nil && mode == "multiple" {
+		response.Paths = search.SelectDiversePaths(response.Paths, maxRecipes, *diversity)
+		response.DistanceMatrix = search.PathDistanceMatrix(response.Paths)
+	}
+
+	duration := time.Since(startTime)
+	log.Printf("Pencarian selesai: Durasi=%v, Nodes Dikeluarkan dari Queue/Stack (Perkiraan)=%d, Path Ditemukan=%t, Error=%v\n", duration, nodesVisited, pathFound, errSearch)
+
+	// --- Isi sisa response ---
+	response.PathFound = pathFound
+	response.NodesVisited = nodesVisited
+	response.DurationMillis = duration.Milliseconds()
+
+	if errSearch != nil {
+		response.Error = errSearch.Error()
+	}
+
+	// Build debug: verifikasi setiap jalur sebelum dikirim ke frontend
+	if debugValidatePaths && response.PathFound {
+		response.ValidationErrors = validateResponsePaths(ds, response)
+		for _, issue := range response.ValidationErrors {
+			log.Printf("DEBUG: %s\n", issue)
+		}
+	}
+
+	// --- Ambil URL Gambar untuk SEMUA elemen yang relevan ---
+	if response.PathFound {
+		imgMap := g.ImageMap()
+		elementsInPaths := make(map[string]bool)
+
+		// Kumpulkan semua elemen unik dari semua jalur resep yang berhasil ditemukan
+		pathsToProcess := [][]dataset.Recipe{}
+		if response.Mode != "multiple" && response.Path != nil {
+			if len(response.Path) > 0 { // Hanya tambahkan path jika tidak kosong
+				pathsToProcess = append(pathsToProcess, response.Path)
+			}
+		} else if response.Mode == "multiple" && response.Paths != nil {
+			if len(response.Paths) > 0 { // Hanya tambahkan paths jika tidak kosong
+				pathsToProcess = response.Paths
+			}
+		}
+		// Tambahkan target elemen ke elementsInPaths jika belum ada (khususnya jika elemen dasar)
+		elementsInPaths[response.SearchTarget] = true
+
+		for _, path := range pathsToProcess {
+			for _, step := range path {
+				elementsInPaths[step.Ingredient1] = true
+				elementsInPaths[step.Ingredient2] = true
+				elementsInPaths[step.Result] = true // Tambahkan juga elemen hasil di setiap langkah
+			}
+		}
+
+		response.ImageURLs = make(map[string]string) // Inisialisasi map gambar di sini
+		for elementName := range elementsInPaths {
+			if imgActualUrl, ok := imgMap[elementName]; ok && imgActualUrl != "" {
+				// BUAT URL YANG MENGARAH ke endpoint backend proxy /api/image
+				proxyUrl := fmt.Sprintf("/api/image?elementName=%s", url.QueryEscape(elementName))
+				if g.Edition.Name != dataset.DefaultGame {
+					proxyUrl += "&game=" + url.QueryEscape(g.Edition.Name)
+				}
+				if _, exists := response.ImageURLs[elementName]; !exists {
+					response.ImageURLs[elementName] = proxyUrl
+				}
+			}
+		}
+	}
+	// END --- Ambil URL Gambar ---
+
+	return response
+}
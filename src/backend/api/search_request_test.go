@@ -0,0 +1,289 @@
+// src/backend/api/search_request_test.go
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/filter"
+	"tubes2stima/backend/search"
+)
+
+// pathUses memeriksa apakah element dipakai sebagai bahan atau hasil di path.
+func pathUses(path []dataset.Recipe, element string) bool {
+	for _, r := range path {
+		if r.Ingredient1 == element || r.Ingredient2 == element || r.Result == element {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSearchHandlerParallelBFS(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	get := func(query string) (int, MultiSearchResponse) {
+		rec := httptest.NewRecorder()
+		searchHandler(rec, httptest.NewRequest(http.MethodGet, "/api/search?"+query, nil))
+		var resp MultiSearchResponse
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return rec.Code, resp
+	}
+
+	code, parallel := get("target=Human&algo=bfs&parallel=true")
+	if code != http.StatusOK || !parallel.Parallel {
+		t.Fatalf("status = %d, parallel = %t", code, parallel.Parallel)
+	}
+	_, sequential := get("target=Human&algo=bfs")
+	if search.PathIdentifier(parallel.Path) != search.PathIdentifier(sequential.Path) {
+		t.Errorf("jalur paralel berbeda:\n  %v\n  %v", parallel.Path, sequential.Path)
+	}
+
+	for _, query := range []string{
+		"target=Human&algo=dfs&parallel=true",
+		"target=Human&algo=bfs&mode=multiple&max=2&parallel=true",
+		"target=Human&algo=bfs&parallel=kadang",
+	} {
+		if code, _ := get(query); code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, ingin 400", query, code)
+		}
+	}
+}
+
+func TestSearchHandlerIDDFS(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	rec := httptest.NewRecorder()
+	searchHandler(rec, httptest.NewRequest(http.MethodGet, "/api/search?target=Metal&algo=iddfs", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp MultiSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.PathFound || len(resp.Iterations) == 0 {
+		t.Fatalf("respons iddfs tidak lengkap: %+v", resp)
+	}
+	total := 0
+	for _, it := range resp.Iterations {
+		total += it.NodesVisited
+	}
+	if total != resp.NodesVisited {
+		t.Errorf("jumlah node per iterasi %d != nodesVisited %d", total, resp.NodesVisited)
+	}
+
+	rec = httptest.NewRecorder()
+	searchHandler(rec, httptest.NewRequest(http.MethodGet, "/api/search?target=Metal&algo=iddfs&lowMemory=true", nil))
+	var lowMemory MultiSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &lowMemory); err != nil {
+		t.Fatal(err)
+	}
+	if !lowMemory.PathFound || !lowMemory.LowMemory || len(lowMemory.Iterations) != len(resp.Iterations) {
+		t.Errorf("respons iddfs lowMemory: %+v, ingin %d iterasi", lowMemory, len(resp.Iterations))
+	}
+
+	for _, query := range []string{
+		"target=Metal&algo=iddfs&mode=multiple&max=2",
+		"target=Metal&algo=bfs&maxDepth=3",
+		"target=Metal&algo=bfs&lowMemory=true",
+		"target=Metal&algo=iddfs&lowMemory=kadang",
+		"target=Metal&algo=iddfs&maxDepth=0",
+	} {
+		rec := httptest.NewRecorder()
+		searchHandler(rec, httptest.NewRequest(http.MethodGet, "/api/search?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, ingin 400", query, rec.Code)
+		}
+	}
+}
+
+func TestSearchHandlerEchoesSeed(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	rec := httptest.NewRecorder()
+	searchHandler(rec, httptest.NewRequest(http.MethodGet, "/api/search?target=Steam&algo=bfs&mode=multiple&max=2&seed=-3", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp MultiSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Seed == nil || *resp.Seed != -3 {
+		t.Errorf("seed tidak dikembalikan: %v", resp.Seed)
+	}
+
+	for _, query := range []string{
+		"target=Steam&mode=multiple&max=2&seed=abc",
+		"target=Steam&mode=shortest&seed=1",
+	} {
+		rec := httptest.NewRecorder()
+		searchHandler(rec, httptest.NewRequest(http.MethodGet, "/api/search?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, ingin 400", query, rec.Code)
+		}
+	}
+}
+
+func TestSearchHandlerCheapest(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	rec := httptest.NewRecorder()
+	searchHandler(rec, httptest.NewRequest(http.MethodGet, "/api/search?target=Human&mode=cheapest", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp MultiSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.PathFound || resp.Algorithm != "knuth" || resp.TotalCost == nil {
+		t.Fatalf("respons cheapest tidak lengkap: %+v", resp)
+	}
+	if len(resp.StepCosts) != len(resp.Path) {
+		t.Fatalf("len(stepCosts) = %d, len(path) = %d", len(resp.StepCosts), len(resp.Path))
+	}
+	sum := 0.0
+	for _, c := range resp.StepCosts {
+		sum += c
+	}
+	if math.Abs(sum-*resp.TotalCost) > 1e-9 {
+		t.Errorf("totalCost = %v, jumlah stepCosts = %v", *resp.TotalCost, sum)
+	}
+}
+
+func TestSearchHandlerDiversity(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	rec := httptest.NewRecorder()
+	searchHandler(rec, httptest.NewRequest(http.MethodGet, "/api/search?target=Human&algo=kbest&mode=multiple&max=3&diversity=1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp MultiSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Paths) != 3 || len(resp.DistanceMatrix) != 3 {
+		t.Fatalf("ingin 3 jalur dan matriks 3x3, dapat %d dan %d", len(resp.Paths), len(resp.DistanceMatrix))
+	}
+	if resp.Diversity == nil || *resp.Diversity != 1 {
+		t.Errorf("diversity tidak dikembalikan: %v", resp.Diversity)
+	}
+
+	rec = httptest.NewRecorder()
+	searchHandler(rec, httptest.NewRequest(http.MethodGet, "/api/search?target=Human&mode=multiple&max=3&diversity=2", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("diversity di luar 0..1: status = %d, ingin 400", rec.Code)
+	}
+}
+
+func TestSearchHandlerConstraints(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	rec := httptest.NewRecorder()
+	searchHandler(rec, httptest.NewRequest(http.MethodGet, "/api/search?target=human&algo=bfs&avoid=swamp", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp MultiSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.PathFound || pathUses(resp.Path, "Swamp") {
+		t.Errorf("jalur Human tanpa Swamp salah: found=%t path=%v", resp.PathFound, resp.Path)
+	}
+	if len(resp.Avoid) != 1 || resp.Avoid[0] != "Swamp" {
+		t.Errorf("avoid tidak dikembalikan: %v", resp.Avoid)
+	}
+
+	for _, query := range []string{
+		"target=Human&avoid=Unobtainium",
+		"target=Human&avoid=Human",
+		"target=Human&avoid=Metal&require=Metal",
+	} {
+		rec := httptest.NewRecorder()
+		searchHandler(rec, httptest.NewRequest(http.MethodGet, "/api/search?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, ingin 400", query, rec.Code)
+		}
+	}
+}
+
+func TestSearchHandlerDatasetProfile(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/api/search?target=Mud&dataset=no-self-combinations")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp MultiSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.PathFound || resp.Dataset != "no-self-combinations" {
+		t.Fatalf("respons tidak sesuai: %+v", resp)
+	}
+	for _, step := range resp.Path {
+		if step.Ingredient1 == step.Ingredient2 {
+			t.Errorf("jalur memakai resep %s + %s", step.Ingredient1, step.Ingredient2)
+		}
+	}
+
+	// Human tidak tercapai tanpa resep A + A
+	for path, want := range map[string]int{
+		"/api/search?target=Human&dataset=no-self-combinations": http.StatusBadRequest,
+		"/api/search?target=Human&dataset=tidak-ada":            http.StatusBadRequest,
+		"/api/search?target=Human&dataset=raw":                  http.StatusOK,
+	} {
+		if rec := get(path); rec.Code != want {
+			t.Errorf("%s: status = %d, ingin %d", path, rec.Code, want)
+		}
+	}
+
+	rec = get("/api/datasets")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("/api/datasets: status = %d", rec.Code)
+	}
+	var profiles []DatasetProfileInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &profiles); err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]DatasetProfileInfo)
+	for _, info := range profiles {
+		if info.Error != "" {
+			t.Errorf("profil %s: %s", info.Name, info.Error)
+		}
+		byName[info.Name] = info
+	}
+	if byName[filter.DefaultProfile].Recipes != len(defaultGame(t).Recipes()) {
+		t.Errorf("profil default berisi %d resep, ingin %d", byName[filter.DefaultProfile].Recipes, len(defaultGame(t).Recipes()))
+	}
+	if byName["raw"].Recipes <= byName[filter.DefaultProfile].Recipes {
+		t.Errorf("profil raw (%d resep) seharusnya lebih besar dari default", byName["raw"].Recipes)
+	}
+}
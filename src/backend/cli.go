@@ -0,0 +1,244 @@
+// src/backend/cli.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"tubes2stima/backend/api"
+	"tubes2stima/backend/dataset"
+)
+
+// subcommand adalah satu perintah CLI: `backend <nama> [flag...]`.
+type subcommand struct {
+	name    string
+	summary string
+	run     func(args []string)
+}
+
+var subcommands = []subcommand{
+	{"serve", "Jalankan server HTTP dengan data lokal (tanpa scraping)", runServeCommand},
+	{"scrape", "Scraping wiki lalu filter resep", runScrapeCommand},
+	{"filter", "Filter ulang recipes_scraped.json lokal", runFilterCommand},
+	{"search", "Cari resep di data lokal dan cetak hasilnya (text/json/guide/markdown/dot/svg)", runSearchCommand},
+	{"stats", "Cetak statistik dataset", runStatsCommand},
+	{"tui", "Penjelajah interaktif di terminal (cari elemen, resep, BFS/DFS/BDS)", runTUICommand},
+}
+
+// runSubcommand menjalankan subcommand jika args[0] adalah nama subcommand.
+// Mengembalikan false jika bukan, agar main memakai flag lama.
+func runSubcommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	for _, cmd := range subcommands {
+		if cmd.name == args[0] {
+			cmd.run(args[1:])
+			return true
+		}
+	}
+	return false
+}
+
+// printUsage menampilkan daftar subcommand dan flag lama.
+func printUsage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Penggunaan: %s <subcommand> [flag...]\n\nSubcommand:\n", os.Args[0])
+	for _, cmd := range subcommands {
+		fmt.Fprintf(out, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(out, "\nJalankan '%s <subcommand> -h' untuk flag tiap subcommand.\n", os.Args[0])
+	fmt.Fprintf(out, "Tanpa subcommand: scraping, filter, lalu server (flag lama di bawah).\n\n")
+	flag.PrintDefaults()
+}
+
+// newCommandFlags membuat FlagSet untuk satu subcommand.
+func newCommandFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Penggunaan: %s %s [flag...]\n", os.Args[0], name)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func runServeCommand(args []string) {
+	fs := newCommandFlags("serve")
+	port := fs.String("port", "8080", "Port server HTTP")
+	strict := fs.Bool("strict", false, "Gagal memuat data jika validasi menemukan resep duplikat, rusak, atau tidak bisa dibuat")
+	fs.Parse(args)
+	strictDataValidation = *strict
+	runServer("data", *port)
+}
+
+func runScrapeCommand(args []string) {
+	fs := newCommandFlags("scrape")
+	gameName := fs.String("game", dataset.DefaultGame, "Edisi game yang di-scrape ('la2', 'la1', atau 'all')")
+	runFilter := fs.Bool("filter", true, "Jalankan filter setelah scraping")
+	fs.Parse(args)
+	scrapeAndFilter(*gameName, *runFilter)
+}
+
+func runFilterCommand(args []string) {
+	fs := newCommandFlags("filter")
+	gameName := fs.String("game", dataset.DefaultGame, "Edisi game yang difilter ('la2', 'la1', atau 'all')")
+	fs.Parse(args)
+	filterEditions(*gameName)
+}
+
+func runStatsCommand(args []string) {
+	fs := newCommandFlags("stats")
+	gameName := fs.String("game", dataset.DefaultGame, "Edisi game")
+	format := fs.String("format", "text", "Format output: 'text' atau 'json'")
+	fs.Parse(args)
+	runStatsMode(*gameName, *format)
+}
+
+// searchCommandParams memetakan flag subcommand search ke parameter
+// /api/search dengan nama yang sama.
+var searchCommandParams = []struct{ name, usage string }{
+	{"target", "Elemen target (wajib)"},
+	{"algo", "Algoritma: bfs, dfs, bds, kbest, atau iddfs (default bfs)"},
+	{"mode", "Mode: shortest, multiple, atau cheapest (default shortest)"},
+	{"max", "Jumlah jalur untuk mode multiple"},
+	{"seed", "Seed mode multiple deterministik"},
+	{"diversity", "Bobot diversity 0..1 untuk mode multiple"},
+	{"avoid", "Elemen yang dihindari (dipisah koma)"},
+	{"require", "Elemen yang wajib dipakai (dipisah koma)"},
+	{"maxDepth", "Batas kedalaman untuk algo iddfs"},
+	{"parallel", "BFS paralel (true/false)"},
+	{"dataset", "Profil filter dataset (lihat filter_profiles.json)"},
+}
+
+// runSearchCommand menjalankan pencarian yang sama dengan /api/search pada
+// data lokal. Keluar dengan status 1 jika jalur tidak ditemukan.
+func runSearchCommand(args []string) {
+	fs := newCommandFlags("search")
+	gameName := fs.String("game", dataset.DefaultGame, "Edisi game")
+	format := fs.String("format", "text", "Format output: 'text', 'json', 'guide', 'markdown', 'dot', atau 'svg'")
+	params := make(map[string]*string, len(searchCommandParams))
+	for _, p := range searchCommandParams {
+		params[p.name] = fs.String(p.name, "", p.usage)
+	}
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		log.Fatalf("FATAL: argumen tidak dikenal: %s", strings.Join(fs.Args(), " "))
+	}
+
+	query := url.Values{}
+	for name, value := range params {
+		if *value != "" {
+			query.Set(name, *value)
+		}
+	}
+	switch *format {
+	case "text", "json":
+	case "guide":
+		// Panduan langkah demi langkah sama dengan format=text di /api/search
+		query.Set("format", "text")
+	default:
+		query.Set("format", *format)
+	}
+
+	g := loadLocalGame(*gameName)
+	req, _, err := api.ParseSearchRequest(g, query)
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	// Log algoritma juga ditulis ke stdout, jadi dibuang
+	restore := silenceStdout()
+	response := req.Execute()
+	restore()
+
+	switch *format {
+	case "text":
+		err = WriteSearchText(os.Stdout, response)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(response)
+	default:
+		if !response.PathFound {
+			fmt.Fprintf(os.Stderr, "Jalur untuk '%s' tidak ditemukan\n", response.SearchTarget)
+			os.Exit(1)
+		}
+		err = api.WriteSearchDocument(os.Stdout, g, response, req.Format)
+	}
+	if err != nil {
+		log.Fatalf("FATAL: Gagal menulis hasil: %v", err)
+	}
+	if !response.PathFound {
+		os.Exit(1)
+	}
+}
+
+// WriteSearchText menulis ringkasan hasil pencarian dan setiap jalur dalam
+// urutan yang dikembalikan algoritma.
+func WriteSearchText(w io.Writer, response api.MultiSearchResponse) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Target    : %s\n", response.SearchTarget)
+	fmt.Fprintf(&b, "Algoritma : %s (mode %s", response.Algorithm, response.Mode)
+	if response.MaxRecipes > 0 {
+		fmt.Fprintf(&b, ", max %d", response.MaxRecipes)
+	}
+	b.WriteString(")\n")
+	if response.Dataset != "" {
+		fmt.Fprintf(&b, "Dataset   : %s\n", response.Dataset)
+	}
+	if len(response.Avoid) > 0 {
+		fmt.Fprintf(&b, "Hindari   : %s\n", strings.Join(response.Avoid, ", "))
+	}
+	if len(response.Require) > 0 {
+		fmt.Fprintf(&b, "Wajib     : %s\n", strings.Join(response.Require, ", "))
+	}
+	fmt.Fprintf(&b, "Node      : %d dikunjungi dalam %d ms\n", response.NodesVisited, response.DurationMillis)
+	if response.Error != "" {
+		fmt.Fprintf(&b, "Error     : %s\n", response.Error)
+	}
+	if !response.PathFound {
+		b.WriteString("Jalur tidak ditemukan.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	paths := response.Paths
+	if response.Mode != "multiple" {
+		paths = [][]dataset.Recipe{response.Path}
+	}
+	for i, path := range paths {
+		title := "Jalur"
+		if len(paths) > 1 {
+			title += " " + strconv.Itoa(i+1)
+		}
+		fmt.Fprintf(&b, "\n%s (%d langkah)", title, len(path))
+		if response.TotalCost != nil && i == 0 {
+			fmt.Fprintf(&b, ", biaya %g", *response.TotalCost)
+		}
+		b.WriteString("\n")
+		for j, step := range path {
+			fmt.Fprintf(&b, "  %2d. %s + %s = %s\n", j+1, step.Ingredient1, step.Ingredient2, step.Result)
+		}
+	}
+	if len(response.MandatoryElements) > 0 {
+		fmt.Fprintf(&b, "\nElemen wajib: %s\n", strings.Join(response.MandatoryElements, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func runTUICommand(args []string) {
+	fs := newCommandFlags("tui")
+	gameName := fs.String("game", dataset.DefaultGame, "Edisi game")
+	fs.Parse(args)
+	g := loadLocalGame(*gameName)
+	if err := runTUI(g, os.Stdin, os.Stdout); err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+// src/backend/cli_test.go
+package main
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"tubes2stima/backend/api"
+	"tubes2stima/backend/dataset"
+)
+
+func TestRunSubcommandUnknown(t *testing.T) {
+	for _, args := range [][]string{nil, {"-stats"}, {"unknown"}} {
+		if runSubcommand(args) {
+			t.Errorf("runSubcommand(%q) = true, ingin false", args)
+		}
+	}
+}
+
+func TestWriteSearchText(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	g, err := api.GetGame(dataset.DefaultGame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _, err := api.ParseSearchRequest(g, url.Values{"target": {"stone"}, "mode": {"multiple"}, "max": {"2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	if err := WriteSearchText(&b, req.Execute()); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Target    : Stone",
+		"Algoritma : bfs (mode multiple, max 2)",
+		"Jalur 2 (2 langkah)",
+		"   2. Earth + Pressure = Stone",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("output tidak berisi %q:\n%s", want, b.String())
+		}
+	}
+}
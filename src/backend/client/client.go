@@ -0,0 +1,298 @@
+// src/backend/client/client.go
+
+// Package client adalah klien Go untuk API pencarian resep Little Alchemy.
+// Endpoint dan bentuk respons mengikuti spesifikasi OpenAPI yang disajikan
+// server di /api/openapi.json.
+//
+//	c := client.New("http://localhost:8080")
+//	res, err := c.Search(ctx, client.SearchParams{Target: "Human", Algo: "bds", Mode: "multiple", Max: 5})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Client memanggil API server. Buat dengan New.
+type Client struct {
+	// BaseURL adalah alamat server tanpa garis miring di akhir, misalnya
+	// "http://localhost:8080".
+	BaseURL string
+	// HTTPClient dipakai untuk semua request (default http.DefaultClient).
+	HTTPClient *http.Client
+	// AdminToken dikirim sebagai header X-Admin-Token ke endpoint /api/admin/*.
+	AdminToken string
+}
+
+// New membuat Client untuk server di baseURL.
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), HTTPClient: http.DefaultClient}
+}
+
+// Error adalah respons non-2xx dari server. Message berisi body teks
+// respons (pesan error server).
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("api: status %d: %s", e.StatusCode, e.Message)
+}
+
+// SearchParams adalah parameter /api/search. Field kosong tidak dikirim
+// sehingga server memakai nilai default-nya.
+type SearchParams struct {
+	Target    string
+	Algo      string // bfs, dfs, bds, kbest, atau iddfs
+	Mode      string // shortest, multiple, atau cheapest
+	Max       int    // Wajib untuk mode multiple
+	Seed      *int64
+	Diversity *float64
+	Avoid     []string
+	Require   []string
+	MaxDepth  int  // Hanya untuk algo iddfs
+	LowMemory bool // Hanya untuk algo iddfs
+	Parallel  bool
+	Dataset   string
+	Game      string
+}
+
+func (p SearchParams) values() url.Values {
+	q := url.Values{}
+	q.Set("target", p.Target)
+	setNonEmpty(q, "algo", p.Algo)
+	setNonEmpty(q, "mode", p.Mode)
+	if p.Max > 0 {
+		q.Set("max", strconv.Itoa(p.Max))
+	}
+	if p.Seed != nil {
+		q.Set("seed", strconv.FormatInt(*p.Seed, 10))
+	}
+	if p.Diversity != nil {
+		q.Set("diversity", strconv.FormatFloat(*p.Diversity, 'g', -1, 64))
+	}
+	setNonEmpty(q, "avoid", strings.Join(p.Avoid, ","))
+	setNonEmpty(q, "require", strings.Join(p.Require, ","))
+	if p.MaxDepth > 0 {
+		q.Set("maxDepth", strconv.Itoa(p.MaxDepth))
+	}
+	if p.LowMemory {
+		q.Set("lowMemory", "true")
+	}
+	if p.Parallel {
+		q.Set("parallel", "true")
+	}
+	setNonEmpty(q, "dataset", p.Dataset)
+	setNonEmpty(q, "game", p.Game)
+	return q
+}
+
+// RelationParams adalah parameter opsional Ancestors dan Descendants.
+type RelationParams struct {
+	Depth    int      // Batas kedalaman (0 = tanpa batas)
+	Contains []string // Elemen yang dicek keberadaannya di hasil
+	Game     string
+}
+
+func setNonEmpty(q url.Values, key, value string) {
+	if value != "" {
+		q.Set(key, value)
+	}
+}
+
+func gameQuery(game string) url.Values {
+	q := url.Values{}
+	setNonEmpty(q, "game", game)
+	return q
+}
+
+// Search mencari jalur resep. Jalur yang tidak ditemukan bukan error:
+// periksa SearchResponse.PathFound.
+func (c *Client) Search(ctx context.Context, p SearchParams) (*SearchResponse, error) {
+	var res SearchResponse
+	if err := c.getJSON(ctx, "/api/search", p.values(), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// SearchRendered mencari jalur resep dan mengembalikan hasilnya dalam format
+// dot, svg, text, atau markdown.
+func (c *Client) SearchRendered(ctx context.Context, p SearchParams, format string) ([]byte, error) {
+	q := p.values()
+	q.Set("format", format)
+	body, _, err := c.get(ctx, "/api/search", q)
+	return body, err
+}
+
+// Ancestors mengembalikan semua elemen yang bisa muncul di pohon resep element.
+func (c *Client) Ancestors(ctx context.Context, element string, p RelationParams) (*ElementRelation, error) {
+	return c.relation(ctx, element, "ancestors", p)
+}
+
+// Descendants mengembalikan semua elemen yang bisa dibuat dengan bantuan element.
+func (c *Client) Descendants(ctx context.Context, element string, p RelationParams) (*ElementRelation, error) {
+	return c.relation(ctx, element, "descendants", p)
+}
+
+func (c *Client) relation(ctx context.Context, element, relation string, p RelationParams) (*ElementRelation, error) {
+	q := gameQuery(p.Game)
+	if p.Depth > 0 {
+		q.Set("depth", strconv.Itoa(p.Depth))
+	}
+	setNonEmpty(q, "contains", strings.Join(p.Contains, ","))
+	var res ElementRelation
+	if err := c.getJSON(ctx, "/api/element/"+url.PathEscape(element)+"/"+relation, q, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Required mengembalikan elemen yang ada di setiap pohon resep element.
+func (c *Client) Required(ctx context.Context, element, game string) (*RequiredElements, error) {
+	var res RequiredElements
+	if err := c.getJSON(ctx, "/api/element/"+url.PathEscape(element)+"/required", gameQuery(game), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Stats mengembalikan statistik dataset game ("" = game default server).
+func (c *Client) Stats(ctx context.Context, game string) (*DatasetStats, error) {
+	var res DatasetStats
+	if err := c.getJSON(ctx, "/api/stats", gameQuery(game), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Meta mengembalikan ringkasan data game dan laporan validasinya.
+func (c *Client) Meta(ctx context.Context, game string) (*Meta, error) {
+	var res Meta
+	if err := c.getJSON(ctx, "/api/meta", gameQuery(game), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Datasets mengembalikan profil filter yang bisa dipakai di SearchParams.Dataset.
+func (c *Client) Datasets(ctx context.Context, game string) ([]DatasetProfile, error) {
+	var res []DatasetProfile
+	if err := c.getJSON(ctx, "/api/datasets", gameQuery(game), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Games mengembalikan edisi game yang dikenal server.
+func (c *Client) Games(ctx context.Context) ([]GameInfo, error) {
+	var res []GameInfo
+	if err := c.getJSON(ctx, "/api/games", nil, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Export mengunduh semua resep game dalam format json, csv, ndjson, graphml,
+// atau dot.
+func (c *Client) Export(ctx context.Context, format, game string) ([]byte, error) {
+	q := gameQuery(game)
+	setNonEmpty(q, "format", format)
+	body, _, err := c.get(ctx, "/api/export", q)
+	return body, err
+}
+
+// Image mengunduh gambar elemen lewat proxy server dan mengembalikan data
+// beserta Content-Type-nya.
+func (c *Client) Image(ctx context.Context, element, game string) ([]byte, string, error) {
+	q := gameQuery(game)
+	q.Set("elementName", element)
+	return c.get(ctx, "/api/image", q)
+}
+
+// Diff membandingkan dua snapshot resep lewat /api/admin/diff. Membutuhkan
+// AdminToken.
+func (c *Client) Diff(ctx context.Context, req DiffRequest, game string) (*RecipeDiff, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := c.newRequest(ctx, http.MethodPost, "/api/admin/diff", gameQuery(game), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-Admin-Token", c.AdminToken)
+	data, _, err := c.do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	var res RecipeDiff
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("api: respons /api/admin/diff tidak valid: %w", err)
+	}
+	return &res, nil
+}
+
+// OpenAPI mengunduh spesifikasi OpenAPI server.
+func (c *Client) OpenAPI(ctx context.Context) ([]byte, error) {
+	body, _, err := c.get(ctx, "/api/openapi.json", nil)
+	return body, err
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	return http.NewRequestWithContext(ctx, method, target, body)
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values) ([]byte, string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	return c.do(req)
+}
+
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, out any) error {
+	data, _, err := c.get(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("api: respons %s tidak valid: %w", path, err)
+	}
+	return nil
+}
+
+// do mengirim request dan mengembalikan body serta Content-Type. Status
+// selain 2xx dikembalikan sebagai *Error.
+func (c *Client) do(req *http.Request) ([]byte, string, error) {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", &Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
@@ -0,0 +1,38 @@
+// src/backend/client/client_test.go
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchParamsValues(t *testing.T) {
+	seed, diversity := int64(7), 0.25
+	got := SearchParams{
+		Target: "Human", Algo: "bds", Mode: "multiple", Max: 5, Seed: &seed, Diversity: &diversity,
+		Avoid: []string{"Fire", "Lava"}, Game: "la1",
+	}.values().Encode()
+	want := "algo=bds&avoid=Fire%2CLava&diversity=0.25&game=la1&max=5&mode=multiple&seed=7&target=Human"
+	if got != want {
+		t.Errorf("values = %s, ingin %s", got, want)
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/element/Grilled cheese/required" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		http.Error(w, "Elemen 'Grilled cheese' tidak ditemukan", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := New(server.URL).Required(context.Background(), "Grilled cheese", "")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "Elemen 'Grilled cheese' tidak ditemukan" {
+		t.Errorf("err = %v, ingin *Error 404", err)
+	}
+}
@@ -0,0 +1,222 @@
+// src/backend/client/types.go
+package client
+
+// Tipe di file ini adalah bentuk JSON respons API sesuai openapi.json
+// (components/schemas). Nama field JSON sama persis dengan server.
+
+// Recipe adalah satu resep: Ingredient1 + Ingredient2 = Result.
+type Recipe struct {
+	Result      string `json:"result"`
+	Ingredient1 string `json:"ingredient1"`
+	Ingredient2 string `json:"ingredient2"`
+}
+
+// IDDFSIteration adalah jumlah node yang diekspansi pada satu batas kedalaman.
+type IDDFSIteration struct {
+	DepthLimit   int `json:"depthLimit"`
+	NodesVisited int `json:"nodesVisited"`
+}
+
+// SearchResponse adalah respons /api/search dengan format json.
+type SearchResponse struct {
+	SearchTarget      string            `json:"searchTarget"`
+	Algorithm         string            `json:"algorithm"`
+	Mode              string            `json:"mode"`
+	Parallel          bool              `json:"parallel,omitempty"`
+	LowMemory         bool              `json:"lowMemory,omitempty"`
+	MaxRecipes        int               `json:"maxRecipes,omitempty"`
+	Seed              *int64            `json:"seed,omitempty"`
+	Diversity         *float64          `json:"diversity,omitempty"`
+	PathFound         bool              `json:"pathFound"`
+	Path              []Recipe          `json:"path,omitempty"`  // Mode shortest dan cheapest
+	Paths             [][]Recipe        `json:"paths,omitempty"` // Mode multiple
+	ImageURLs         map[string]string `json:"imageURLs,omitempty"`
+	NodesVisited      int               `json:"nodesVisited"`
+	DurationMillis    int64             `json:"durationMillis"`
+	Error             string            `json:"error,omitempty"`
+	DistanceMatrix    [][]float64       `json:"distanceMatrix,omitempty"`
+	TotalCost         *float64          `json:"totalCost,omitempty"`
+	StepCosts         []float64         `json:"stepCosts,omitempty"`
+	MandatoryElements []string          `json:"mandatoryElements,omitempty"`
+	Iterations        []IDDFSIteration  `json:"iterations,omitempty"`
+	Avoid             []string          `json:"avoid,omitempty"`
+	Require           []string          `json:"require,omitempty"`
+	Dataset           string            `json:"dataset,omitempty"`
+	Game              string            `json:"game"`
+	ValidationErrors  []string          `json:"validationErrors,omitempty"`
+}
+
+// AllPaths mengembalikan semua jalur respons: Paths pada mode multiple,
+// atau Path sebagai satu-satunya jalur pada mode lain.
+func (r *SearchResponse) AllPaths() [][]Recipe {
+	if r.Mode == "multiple" {
+		return r.Paths
+	}
+	if len(r.Path) == 0 {
+		return nil
+	}
+	return [][]Recipe{r.Path}
+}
+
+// RelatedElement adalah elemen hasil ancestors/descendants beserta jarak
+// minimumnya (dalam langkah resep) dari elemen asal.
+type RelatedElement struct {
+	Name  string `json:"name"`
+	Depth int    `json:"depth"`
+}
+
+// ElementRelation adalah respons /api/element/{name}/ancestors dan
+// /api/element/{name}/descendants.
+type ElementRelation struct {
+	Game          string           `json:"game"`
+	Element       string           `json:"element"`
+	Relation      string           `json:"relation"`
+	MaxDepth      int              `json:"maxDepth,omitempty"`
+	Count         int              `json:"count"`
+	CountsByDepth []int            `json:"countsByDepth"`
+	Elements      []RelatedElement `json:"elements"`
+	Contains      map[string]bool  `json:"contains,omitempty"`
+}
+
+// RequiredElements adalah respons /api/element/{name}/required.
+type RequiredElements struct {
+	Game     string   `json:"game"`
+	Element  string   `json:"element"`
+	Count    int      `json:"count"`
+	Required []string `json:"required"`
+}
+
+// TierStats adalah jumlah elemen dan resep di satu tier.
+type TierStats struct {
+	Tier     int `json:"tier"`
+	Elements int `json:"elements"`
+	Recipes  int `json:"recipes"`
+}
+
+// DegreeBucket adalah satu baris histogram derajat.
+type DegreeBucket struct {
+	Degree   int `json:"degree"`
+	Elements int `json:"elements"`
+}
+
+// ElementCount memasangkan elemen dengan sebuah hitungan.
+type ElementCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// DatasetStats adalah respons /api/stats.
+type DatasetStats struct {
+	Elements             int            `json:"elements"`
+	BaseElements         int            `json:"baseElements"`
+	Recipes              int            `json:"recipes"`
+	MaxTier              int            `json:"maxTier"`
+	Tiers                []TierStats    `json:"tiers"`
+	UnreachableElements  []string       `json:"unreachableElements"`
+	UsageDegrees         []DegreeBucket `json:"usageDegrees"`
+	RecipeDegrees        []DegreeBucket `json:"recipeDegrees"`
+	MostUsedIngredients  []ElementCount `json:"mostUsedIngredients"`
+	SingleRecipeElements []string       `json:"singleRecipeElements"`
+	SelfCombiningRecipes []Recipe       `json:"selfCombiningRecipes"`
+	TerminalElements     []string       `json:"terminalElements"`
+	LongestChains        [][]string     `json:"longestChains"`
+}
+
+// DataIntegrityReport adalah hasil validasi data saat server memuat data.
+type DataIntegrityReport struct {
+	Strict              bool     `json:"strict"`
+	RecipesLoaded       int      `json:"recipesLoaded"`
+	RecipesKept         int      `json:"recipesKept"`
+	InvalidRecipes      []Recipe `json:"invalidRecipes"`
+	DuplicateRecipes    []Recipe `json:"duplicateRecipes"`
+	NormalizedRecipes   int      `json:"normalizedRecipes"`
+	UnknownElements     []string `json:"unknownElements"`
+	UnproducibleRecipes []Recipe `json:"unproducibleRecipes"`
+	MissingImages       []string `json:"missingImages"`
+	OrphanElements      []string `json:"orphanElements"`
+	Errors              []string `json:"errors"`
+	Warnings            []string `json:"warnings"`
+}
+
+// Meta adalah respons /api/meta.
+type Meta struct {
+	Game         string              `json:"game"`
+	Elements     int                 `json:"elements"`
+	Recipes      int                 `json:"recipes"`
+	Images       int                 `json:"images"`
+	BaseElements []string            `json:"baseElements"`
+	Integrity    DataIntegrityReport `json:"integrity"`
+}
+
+// FilterRule adalah satu langkah pipeline profil filter.
+type FilterRule struct {
+	Rule     string   `json:"rule"`
+	Elements []string `json:"elements,omitempty"`
+}
+
+// RuleRemoval adalah jumlah resep yang dibuang satu rule.
+type RuleRemoval struct {
+	Rule    string `json:"rule"`
+	Removed int    `json:"removed"`
+}
+
+// DatasetProfile adalah satu profil filter di respons /api/datasets.
+type DatasetProfile struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Source      string        `json:"source,omitempty"`
+	Rules       []FilterRule  `json:"rules"`
+	UntilStable bool          `json:"untilStable,omitempty"`
+	Recipes     int           `json:"recipes"`
+	Elements    int           `json:"elements"`
+	Rounds      int           `json:"rounds"`
+	Removals    []RuleRemoval `json:"removals"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// ScrapeProfile berisi halaman wiki dan selector tabel satu edisi.
+type ScrapeProfile struct {
+	URL           string `json:"url"`
+	TableSelector string `json:"tableSelector"`
+}
+
+// GameInfo adalah satu edisi game di respons /api/games.
+type GameInfo struct {
+	Name         string        `json:"name"`
+	Title        string        `json:"title"`
+	DataDir      string        `json:"dataDir"`
+	BaseElements []string      `json:"baseElements"`
+	Scrape       ScrapeProfile `json:"scrape"`
+	Default      bool          `json:"default"`
+	Available    bool          `json:"available"`
+	Recipes      int           `json:"recipes"`
+	Elements     int           `json:"elements"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// DiffRequest adalah body /api/admin/diff. Jika Old kosong, server memakai
+// resep yang sedang dimuat.
+type DiffRequest struct {
+	Old []Recipe `json:"old,omitempty"`
+	New []Recipe `json:"new"`
+}
+
+// ElementChange adalah perubahan tier atau panjang jalur satu elemen (-1
+// berarti tidak bisa dibuat).
+type ElementChange struct {
+	Name string `json:"name"`
+	Old  int    `json:"old"`
+	New  int    `json:"new"`
+}
+
+// RecipeDiff adalah respons /api/admin/diff.
+type RecipeDiff struct {
+	OldRecipes        int             `json:"oldRecipes"`
+	NewRecipes        int             `json:"newRecipes"`
+	AddedRecipes      []Recipe        `json:"addedRecipes"`
+	RemovedRecipes    []Recipe        `json:"removedRecipes"`
+	AddedElements     []string        `json:"addedElements"`
+	RemovedElements   []string        `json:"removedElements"`
+	TierChanges       []ElementChange `json:"tierChanges"`
+	PathLengthChanges []ElementChange `json:"pathLengthChanges"`
+}
@@ -0,0 +1,242 @@
+// src/backend/compare.go
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"tubes2stima/backend/api"
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/search"
+)
+
+// comparedAlgorithm adalah satu baris konfigurasi untuk mode -compare.
+// Semua algoritma dibungkus ke bentuk multiple ([][]dataset.Recipe) supaya bisa
+// diperlakukan seragam.
+type comparedAlgorithm struct {
+	Name string
+	Mode string
+	Run  func(target string, maxRecipes int) ([][]dataset.Recipe, int, error)
+}
+
+// ComparisonRow adalah hasil satu algoritma untuk satu target.
+type ComparisonRow struct {
+	Target       string
+	Tier         int
+	Algorithm    string
+	Mode         string
+	NodesVisited int
+	Duration     time.Duration
+	PathLength   int // panjang jalur terpendek yang dikembalikan
+	UniquePaths  int // jumlah jalur berbeda (berdasarkan search.PathIdentifier)
+	Error        string
+}
+
+func singlePathAlgorithm(d *dataset.Dataset, find func(*dataset.Dataset, string) ([]dataset.Recipe, int, error)) func(string, int) ([][]dataset.Recipe, int, error) {
+	return func(target string, _ int) ([][]dataset.Recipe, int, error) {
+		path, nodesVisited, err := find(d, target)
+		if err != nil {
+			return nil, nodesVisited, err
+		}
+		return [][]dataset.Recipe{path}, nodesVisited, nil
+	}
+}
+
+func multiplePathAlgorithm(d *dataset.Dataset, find func(*dataset.Dataset, string, int) ([][]dataset.Recipe, int, error)) func(string, int) ([][]dataset.Recipe, int, error) {
+	return func(target string, maxRecipes int) ([][]dataset.Recipe, int, error) {
+		return find(d, target, maxRecipes)
+	}
+}
+
+// comparedAlgorithms mendaftar semua fungsi FindPath*/FindMultiplePaths*
+// pada dataset game g.
+func comparedAlgorithms(g *api.GameData) []comparedAlgorithm {
+	d := g.Dataset()
+	bfsParallel := func(d *dataset.Dataset, target string) ([]dataset.Recipe, int, error) {
+		return search.FindPathBFSParallel(d, target, 0)
+	}
+	cheapest := func(d *dataset.Dataset, target string) ([]dataset.Recipe, int, error) {
+		return search.FindCheapestPath(d, target, g.Costs())
+	}
+	return []comparedAlgorithm{
+		{"bfs", "shortest", singlePathAlgorithm(d, search.FindPathBFS)},
+		{"bfs-compact", "shortest", singlePathAlgorithm(d, search.FindPathBFSCompact)},
+		{"bfs-parallel", "shortest", singlePathAlgorithm(d, bfsParallel)},
+		{"dfs", "shortest", singlePathAlgorithm(d, search.FindPathDFS)},
+		{"dfs-compact", "shortest", singlePathAlgorithm(d, search.FindPathDFSCompact)},
+		{"bds", "shortest", singlePathAlgorithm(d, search.FindPathBDS)},
+		{"bds-compact", "shortest", singlePathAlgorithm(d, search.FindPathBDSCompact)},
+		{"iddfs", "shortest", singlePathAlgorithm(d, search.FindPathIDDFS)},
+		{"knuth", "cheapest", singlePathAlgorithm(d, cheapest)},
+		{"bfs", "multiple", multiplePathAlgorithm(d, search.FindMultiplePathsBFS)},
+		{"dfs", "multiple", multiplePathAlgorithm(d, search.FindMultiplePathsDFS)},
+		{"bds", "multiple", multiplePathAlgorithm(d, search.FindMultiplePathsBDS)},
+		{"kbest", "multiple", multiplePathAlgorithm(d, search.FindKBestPaths)},
+	}
+}
+
+// RunComparison menjalankan semua algoritma untuk setiap target pada game g.
+// Cache BFS dikosongkan sebelum setiap pemanggilan agar durasi tidak
+// terdistorsi, dan log per-node algoritma dibuang selama pengukuran.
+func RunComparison(g *api.GameData, targets []string, maxRecipes int) []ComparisonRow {
+	tiers, _ := dataset.ElementTiers(g.Recipes(), g.Edition.BaseElements)
+	var rows []ComparisonRow
+
+	for _, target := range targets {
+		for _, algo := range comparedAlgorithms(g) {
+			g.Dataset().ResetCache()
+			restore := silenceStdout()
+			start := time.Now()
+			paths, nodesVisited, err := algo.Run(target, maxRecipes)
+			duration := time.Since(start)
+			restore()
+
+			row := ComparisonRow{
+				Target:       target,
+				Tier:         tiers[target],
+				Algorithm:    algo.Name,
+				Mode:         algo.Mode,
+				NodesVisited: nodesVisited,
+				Duration:     duration,
+			}
+			if err != nil {
+				row.Error = err.Error()
+			}
+			uniqueIDs := make(map[string]bool)
+			for i, path := range paths {
+				uniqueIDs[search.PathIdentifier(path)] = true
+				if i == 0 || len(path) < row.PathLength {
+					row.PathLength = len(path)
+				}
+			}
+			row.UniquePaths = len(uniqueIDs)
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// tierRepresentatives memilih satu elemen (alfabetis pertama) dari setiap
+// tier game g.
+func tierRepresentatives(g *api.GameData) []string {
+	tiers, _ := dataset.ElementTiers(g.Recipes(), g.Edition.BaseElements)
+	firstPerTier := make(map[int]string)
+	for element, tier := range tiers {
+		if tier == 0 {
+			continue
+		}
+		if current, ok := firstPerTier[tier]; !ok || element < current {
+			firstPerTier[tier] = element
+		}
+	}
+	tierList := make([]int, 0, len(firstPerTier))
+	for tier := range firstPerTier {
+		tierList = append(tierList, tier)
+	}
+	sort.Ints(tierList)
+	targets := make([]string, 0, len(tierList))
+	for _, tier := range tierList {
+		targets = append(targets, firstPerTier[tier])
+	}
+	return targets
+}
+
+// parseCompareTargets membaca nilai flag -compare: "tiers" untuk satu elemen
+// per tier, atau daftar nama elemen game g dipisah koma.
+func parseCompareTargets(g *api.GameData, value string) ([]string, error) {
+	if strings.EqualFold(strings.TrimSpace(value), "tiers") {
+		return tierRepresentatives(g), nil
+	}
+	var targets []string
+	for _, part := range strings.Split(value, ",") {
+		name := strings.TrimSpace(part)
+		if name == "" {
+			continue
+		}
+		if !g.HasElement(name) {
+			return nil, fmt.Errorf("elemen '%s' tidak ditemukan", name)
+		}
+		targets = append(targets, name)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("daftar target -compare kosong")
+	}
+	return targets, nil
+}
+
+var comparisonHeader = []string{"target", "tier", "algorithm", "mode", "nodesVisited", "durationMillis", "pathLength", "uniquePaths", "error"}
+
+func (row ComparisonRow) fields() []string {
+	return []string{
+		row.Target,
+		strconv.Itoa(row.Tier),
+		row.Algorithm,
+		row.Mode,
+		strconv.Itoa(row.NodesVisited),
+		strconv.FormatFloat(float64(row.Duration.Microseconds())/1000, 'f', 3, 64),
+		strconv.Itoa(row.PathLength),
+		strconv.Itoa(row.UniquePaths),
+		row.Error,
+	}
+}
+
+// WriteComparisonCSV menulis hasil perbandingan dalam format CSV.
+func WriteComparisonCSV(w io.Writer, rows []ComparisonRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(comparisonHeader); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writer.Write(row.fields()); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteComparisonMarkdown menulis hasil perbandingan sebagai tabel Markdown.
+func WriteComparisonMarkdown(w io.Writer, rows []ComparisonRow) error {
+	if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(comparisonHeader, " | ")); err != nil {
+		return err
+	}
+	separators := make([]string, len(comparisonHeader))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(separators, " | ")); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		fields := row.fields()
+		for i, field := range fields {
+			fields[i] = strings.ReplaceAll(field, "|", "\\|")
+		}
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(fields, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// silenceStdout membuang output fmt.Printf dari algoritma (log per-node)
+// supaya tidak ikut terukur dan tidak bercampur dengan tabel hasil.
+// Kembalian fungsi mengembalikan os.Stdout ke semula.
+func silenceStdout() func() {
+	original := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return func() {}
+	}
+	os.Stdout = devNull
+	return func() {
+		os.Stdout = original
+		devNull.Close()
+	}
+}
@@ -0,0 +1,50 @@
+// src/backend/compare_test.go
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunComparisonOutputs(t *testing.T) {
+	g := defaultGame(t)
+	rows := RunComparison(g, []string{"Mud", "Metal"}, 2)
+	if want := 2 * len(comparedAlgorithms(g)); len(rows) != want {
+		t.Fatalf("jumlah baris %d, seharusnya %d", len(rows), want)
+	}
+	for _, row := range rows {
+		if row.Error != "" || row.UniquePaths == 0 || row.PathLength == 0 {
+			t.Errorf("baris tidak lengkap: %+v", row)
+		}
+	}
+
+	var csvOut, mdOut bytes.Buffer
+	if err := WriteComparisonCSV(&csvOut, rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteComparisonMarkdown(&mdOut, rows); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(csvOut.String(), "\n"); lines != len(rows)+1 {
+		t.Errorf("CSV berisi %d baris, seharusnya %d", lines, len(rows)+1)
+	}
+	if lines := strings.Count(mdOut.String(), "\n"); lines != len(rows)+2 {
+		t.Errorf("Markdown berisi %d baris, seharusnya %d", lines, len(rows)+2)
+	}
+}
+
+func TestParseCompareTargets(t *testing.T) {
+	g := defaultGame(t)
+	targets, err := parseCompareTargets(g, " Mud , Human,")
+	if err != nil || len(targets) != 2 || targets[0] != "Mud" || targets[1] != "Human" {
+		t.Errorf("parse daftar: %v %v", targets, err)
+	}
+	if _, err := parseCompareTargets(g, "Bukan Elemen"); err == nil {
+		t.Error("elemen tidak dikenal seharusnya error")
+	}
+	tiers, err := parseCompareTargets(g, "tiers")
+	if err != nil || len(tiers) < 10 {
+		t.Errorf("representatif tier: %v %v", tiers, err)
+	}
+}
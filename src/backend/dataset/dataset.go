@@ -0,0 +1,200 @@
+// src/backend/dataset/dataset.go
+package dataset
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Dataset mengelompokkan semua struktur yang dibaca algoritma pencarian:
+// recipeMap (hasil -> resep) dan graph (bahan -> resep). Algoritma menerima
+// *Dataset sebagai argumen supaya bisa dijalankan pada "view" data yang sudah
+// difilter (misalnya dengan batasan avoid/require) tanpa mengubah data lain.
+//
+// Struktur turunan yang dibangun paket lain (graf kompak, cache jalur BFS,
+// elemen wajib) disimpan bersama dataset lewat Cached.
+type Dataset struct {
+	recipeMap map[string][]Recipe
+	graph     map[string][]Recipe
+
+	// Elemen dasar edisi game dataset (terurut) dan set-nya untuk IsBase
+	baseElements []string
+	baseSet      map[string]bool
+
+	// cache berisi nilai Cached per key; sync.OnceValue memastikan build
+	// hanya dijalankan sekali walaupun diminta beberapa goroutine bersamaan
+	cacheMutex sync.Mutex
+	cache      map[any]func() any
+}
+
+// ErrNotInitialized dikembalikan algoritma jika dataset belum dibangun (nil).
+var ErrNotInitialized = errors.New("alchemy graph not initialized")
+
+// New membangun Dataset (map resep dan adjacency list bahan) dari map resep
+// dengan elemen dasar baseElements (nil = BaseElements bawaan).
+func New(inputRecipeMap map[string][]Recipe, baseElements []string) *Dataset {
+	if baseElements == nil {
+		baseElements = BaseElements
+	}
+	baseElements = slices.Sorted(slices.Values(baseElements))
+	baseSet := make(map[string]bool, len(baseElements))
+	for _, base := range baseElements {
+		baseSet[base] = true
+	}
+	return &Dataset{
+		recipeMap:    inputRecipeMap,
+		graph:        buildAlchemyGraph(inputRecipeMap),
+		baseElements: baseElements,
+		baseSet:      baseSet,
+		cache:        make(map[any]func() any),
+	}
+}
+
+// FromRecipes membangun Dataset dari daftar resep (lihat New).
+func FromRecipes(recipes []Recipe, baseElements []string) *Dataset {
+	inputRecipeMap := make(map[string][]Recipe)
+	for _, r := range recipes {
+		inputRecipeMap[r.Result] = append(inputRecipeMap[r.Result], r)
+	}
+	return New(inputRecipeMap, baseElements)
+}
+
+// buildAlchemyGraph membangun adjacency list bahan -> resep dari map resep.
+func buildAlchemyGraph(inputRecipeMap map[string][]Recipe) map[string][]Recipe {
+	graph := make(map[string][]Recipe)
+
+	// Iterasi melalui semua resep yang sudah dikelompokkan berdasarkan hasil.
+	// Key diurutkan agar isi graph[bahan] selalu dalam urutan yang sama.
+	results := make([]string, 0, len(inputRecipeMap))
+	for result := range inputRecipeMap {
+		results = append(results, result)
+	}
+	sort.Strings(results)
+	for _, result := range results {
+		recipes := inputRecipeMap[result]
+		// Iterasi melalui setiap resep individu
+		for _, recipe := range recipes {
+			// Tambahkan resep ini ke daftar untuk kedua bahannya
+			// Jika key belum ada, append akan membuat slice baru
+			graph[recipe.Ingredient1] = append(graph[recipe.Ingredient1], recipe)
+			graph[recipe.Ingredient2] = append(graph[recipe.Ingredient2], recipe)
+		}
+	}
+	return graph
+}
+
+// RecipeMap mengembalikan map hasil -> resep milik dataset.
+func (d *Dataset) RecipeMap() map[string][]Recipe {
+	if d == nil {
+		return nil
+	}
+	return d.recipeMap
+}
+
+// Graph mengembalikan adjacency list bahan -> resep milik dataset.
+func (d *Dataset) Graph() map[string][]Recipe {
+	if d == nil {
+		return nil
+	}
+	return d.graph
+}
+
+// Recipes mengembalikan semua resep dataset, dikelompokkan per hasil dengan
+// urutan nama hasil (urutan asli dipertahankan per hasil).
+func (d *Dataset) Recipes() []Recipe {
+	if d == nil {
+		return nil
+	}
+	results := make([]string, 0, len(d.recipeMap))
+	for result := range d.recipeMap {
+		results = append(results, result)
+	}
+	sort.Strings(results)
+	var recipes []Recipe
+	for _, result := range results {
+		recipes = append(recipes, d.recipeMap[result]...)
+	}
+	return recipes
+}
+
+// BaseElements mengembalikan elemen dasar dataset secara terurut. Slice
+// tidak boleh diubah pemanggil.
+func (d *Dataset) BaseElements() []string {
+	if d == nil {
+		return BaseElements
+	}
+	return d.baseElements
+}
+
+// IsBase memeriksa apakah name adalah elemen dasar dataset.
+func (d *Dataset) IsBase(name string) bool {
+	if d == nil {
+		return slices.Contains(BaseElements, name)
+	}
+	return d.baseSet[name]
+}
+
+// HasElement memeriksa apakah elemen muncul di resep dataset (sebagai hasil
+// atau bahan) atau merupakan elemen dasar.
+func (d *Dataset) HasElement(name string) bool {
+	if d.IsBase(name) {
+		return true
+	}
+	if d == nil {
+		return false
+	}
+	_, asResult := d.recipeMap[name]
+	_, asIngredient := d.graph[name]
+	return asResult || asIngredient
+}
+
+// Cached mengembalikan nilai turunan dataset untuk key, dibangun dengan build
+// saat pertama kali diminta. Key sebaiknya tipe tak-ekspor milik paket
+// pemanggil (seperti key context.Context) supaya tidak bertabrakan.
+func (d *Dataset) Cached(key any, build func() any) any {
+	d.cacheMutex.Lock()
+	get, ok := d.cache[key]
+	if !ok {
+		get = sync.OnceValue(build)
+		d.cache[key] = get
+	}
+	d.cacheMutex.Unlock()
+	return get()
+}
+
+// ResetCache mengosongkan cache dataset: setiap nilai Cached yang punya
+// method Reset() (misalnya cache jalur BFS) dipanggil Reset-nya. Struktur
+// yang tidak berubah selama dataset hidup, seperti graf kompak, dipertahankan.
+func (d *Dataset) ResetCache() {
+	if d == nil {
+		return
+	}
+	d.cacheMutex.Lock()
+	values := make([]func() any, 0, len(d.cache))
+	for _, get := range d.cache {
+		values = append(values, get)
+	}
+	d.cacheMutex.Unlock()
+
+	for _, get := range values {
+		if r, ok := get().(interface{ Reset() }); ok {
+			r.Reset()
+		}
+	}
+}
+
+// Filter membuat Dataset baru yang hanya berisi resep dengan keep(r) true
+// dan elemen dasar yang sama.
+func (d *Dataset) Filter(keep func(r Recipe) bool) *Dataset {
+	filtered := make(map[string][]Recipe, len(d.recipeMap))
+	for result, recipes := range d.recipeMap {
+		for _, r := range recipes {
+			if keep(r) {
+				filtered[result] = append(filtered[result], r)
+			}
+		}
+	}
+	return New(filtered, d.baseElements)
+}
@@ -0,0 +1,50 @@
+// src/backend/dataset/dataset_test.go
+package dataset
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testDataset dan testIntegrity dimuat sekali dari data bawaan la2.
+var (
+	testDataset   *Dataset
+	testIntegrity DataIntegrityReport
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	testDataset, testIntegrity, err = LoadFile(filepath.Join("..", "data", FilteredRecipeFile), BaseElements)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gagal memuat data untuk test: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+func TestDatasetCached(t *testing.T) {
+	d := FromRecipes([]Recipe{{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}}, nil)
+	type key struct{}
+	builds := 0
+	build := func() any {
+		builds++
+		return &resettable{}
+	}
+	first := d.Cached(key{}, build).(*resettable)
+	if second := d.Cached(key{}, build).(*resettable); second != first || builds != 1 {
+		t.Fatalf("Cached membangun ulang nilai (%d kali)", builds)
+	}
+	d.ResetCache()
+	if first.resets != 1 {
+		t.Errorf("ResetCache memanggil Reset %d kali, ingin 1", first.resets)
+	}
+	if !d.HasElement("Mud") || !d.HasElement("Air") || d.HasElement("Steam") {
+		t.Error("HasElement tidak sesuai resep dataset")
+	}
+}
+
+type resettable struct{ resets int }
+
+func (r *resettable) Reset() { r.resets++ }
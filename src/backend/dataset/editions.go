@@ -0,0 +1,70 @@
+// src/backend/dataset/editions.go
+package dataset
+
+import "errors"
+
+// DefaultGame adalah edisi yang dipakai jika parameter game= tidak diberikan.
+const DefaultGame = "la2"
+
+// ScrapeProfile berisi halaman wiki dan selector tabel untuk satu edisi.
+type ScrapeProfile struct {
+	URL           string `json:"url"`
+	TableSelector string `json:"tableSelector"`
+}
+
+// GameEdition adalah satu edisi game dengan data, elemen dasar, dan profil
+// scraper sendiri.
+type GameEdition struct {
+	Name         string        `json:"name"`
+	Title        string        `json:"title"`
+	DataDir      string        `json:"dataDir"` // Relatif ke direktori data utama ("" = direktori utama)
+	BaseElements []string      `json:"baseElements"`
+	Scrape       ScrapeProfile `json:"scrape"`
+}
+
+// Editions mendaftar semua edisi yang dikenal. la2 tetap memakai
+// direktori data utama agar data lama tidak perlu dipindah. Profil la1
+// bersifat best-effort: selectornya disalin dari la2 dan hanya diuji
+// terhadap fixture tata letak tabel (scrape/scrape_test.go), bukan terhadap
+// halaman wiki Little Alchemy 1 yang asli. Jika tabelnya berbeda, scraping
+// la1 gagal karena tidak ada resep. Data la1 tidak disimpan di repo; buat
+// dengan "go run . -scrapeonly -game la1" (image Docker mencobanya saat
+// build).
+var Editions = []GameEdition{
+	{
+		Name:         "la2",
+		Title:        "Little Alchemy 2",
+		DataDir:      "",
+		BaseElements: []string{"Air", "Earth", "Fire", "Water"},
+		Scrape:       ScrapeProfile{URL: "https://little-alchemy.fandom.com/wiki/Elements_(Little_Alchemy_2)#Tier_15_elements", TableSelector: "table.list-table.col-list.icon-hover"},
+	},
+	{
+		Name:         "la1",
+		Title:        "Little Alchemy",
+		DataDir:      "la1",
+		BaseElements: []string{"Air", "Earth", "Fire", "Water"},
+		Scrape:       ScrapeProfile{URL: "https://little-alchemy.fandom.com/wiki/Elements_(Little_Alchemy_1)", TableSelector: "table.list-table.col-list.icon-hover"},
+	},
+}
+
+// ErrUnknownGame dikembalikan untuk nama edisi yang tidak dikenal.
+var ErrUnknownGame = errors.New("game tidak dikenal")
+
+// FindEdition mencari edisi berdasarkan nama ("" = DefaultGame).
+func FindEdition(name string) (GameEdition, bool) {
+	if name == "" {
+		name = DefaultGame
+	}
+	for _, edition := range Editions {
+		if edition.Name == name {
+			return edition, true
+		}
+	}
+	return GameEdition{}, false
+}
+
+// DefaultEdition mengembalikan edisi DefaultGame.
+func DefaultEdition() GameEdition {
+	edition, _ := FindEdition(DefaultGame)
+	return edition
+}
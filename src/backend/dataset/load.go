@@ -0,0 +1,244 @@
+// src/backend/dataset/load.go
+package dataset
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// LoadRecipes memuat daftar resep dari file JSON.
+func LoadRecipes(filePath string) ([]Recipe, error) {
+	fmt.Printf("Membaca file resep: %s\n", filePath)
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca file %s: %w", filePath, err)
+	}
+	var recipes []Recipe
+	err = json.Unmarshal(bytes, &recipes)
+	if err != nil {
+		return nil, fmt.Errorf("gagal unmarshal JSON resep dari %s: %w", filePath, err)
+	}
+	return recipes, nil
+}
+
+// LoadImages memuat daftar URL gambar elemen dari file JSON.
+func LoadImages(filePath string) ([]ElementImage, error) {
+	fmt.Printf("Membaca file URL gambar: %s\n", filePath)
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca file %s: %w", filePath, err)
+	}
+	var images []ElementImage
+	err = json.Unmarshal(bytes, &images)
+	if err != nil {
+		return nil, fmt.Errorf("gagal unmarshal JSON gambar dari %s: %w", filePath, err)
+	}
+	return images, nil
+}
+
+// DataIntegrityReport merangkum hasil validasi data resep dan gambar saat
+// pemuatan data. Errors berisi masalah yang tersisa setelah data dirapikan
+// dan membuat pemuatan gagal pada mode -strict; Warnings (termasuk
+// normalisasi whitespace) tidak.
+type DataIntegrityReport struct {
+	Strict        bool `json:"strict"`
+	RecipesLoaded int  `json:"recipesLoaded"` // Jumlah resep di file
+	RecipesKept   int  `json:"recipesKept"`   // Jumlah resep setelah validasi
+
+	InvalidRecipes      []Recipe `json:"invalidRecipes"`      // Ada nama kosong, dibuang
+	DuplicateRecipes    []Recipe `json:"duplicateRecipes"`    // Sama menurut RecipeID, dibuang
+	NormalizedRecipes   int      `json:"normalizedRecipes"`   // Whitespace nama elemen dirapikan
+	UnknownElements     []string `json:"unknownElements"`     // Dipakai sebagai bahan tetapi tidak pernah dihasilkan
+	UnproducibleRecipes []Recipe `json:"unproducibleRecipes"` // Bahannya tidak bisa dibuat dari elemen dasar
+	MissingImages       []string `json:"missingImages"`       // Elemen tanpa URL gambar
+	OrphanElements      []string `json:"orphanElements"`      // Punya gambar tetapi tidak muncul di resep mana pun
+
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+}
+
+// Validate memeriksa dan merapikan data mentah: whitespace dinormalisasi,
+// resep dengan nama kosong dan duplikat (menurut RecipeID) dibuang, lalu
+// elemen yang tidak dikenal, resep yang bahannya tidak bisa dibuat, elemen
+// tanpa gambar, dan gambar tanpa resep dilaporkan relatif terhadap elemen
+// dasar baseElements. Urutan resep dipertahankan.
+func Validate(recipes []Recipe, images []ElementImage, baseElements []string) ([]Recipe, []ElementImage, DataIntegrityReport) {
+	report := DataIntegrityReport{
+		RecipesLoaded:       len(recipes),
+		InvalidRecipes:      []Recipe{},
+		DuplicateRecipes:    []Recipe{},
+		UnknownElements:     []string{},
+		UnproducibleRecipes: []Recipe{},
+		MissingImages:       []string{},
+		OrphanElements:      []string{},
+		Errors:              []string{},
+		Warnings:            []string{},
+	}
+
+	isBase := make(map[string]bool, len(baseElements))
+	for _, base := range baseElements {
+		isBase[base] = true
+	}
+	seen := make(map[string]bool, len(recipes))
+	results := make(map[string]bool)
+	elements := make(map[string]bool)
+	validRecipes := make([]Recipe, 0, len(recipes))
+	for _, r := range recipes {
+		normalized := Recipe{
+			Result:      NormalizeElementName(r.Result),
+			Ingredient1: NormalizeElementName(r.Ingredient1),
+			Ingredient2: NormalizeElementName(r.Ingredient2),
+		}
+		if normalized != r {
+			report.NormalizedRecipes++
+		}
+		if normalized.Result == "" || normalized.Ingredient1 == "" || normalized.Ingredient2 == "" {
+			report.InvalidRecipes = append(report.InvalidRecipes, r)
+			continue
+		}
+		id := RecipeID(normalized)
+		if seen[id] {
+			report.DuplicateRecipes = append(report.DuplicateRecipes, normalized)
+			continue
+		}
+		seen[id] = true
+		validRecipes = append(validRecipes, normalized)
+		results[normalized.Result] = true
+		elements[normalized.Result] = true
+		elements[normalized.Ingredient1] = true
+		elements[normalized.Ingredient2] = true
+	}
+	report.RecipesKept = len(validRecipes)
+
+	names := make([]string, 0, len(elements))
+	for name := range elements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !results[name] && !isBase[name] {
+			report.UnknownElements = append(report.UnknownElements, name)
+		}
+	}
+
+	// ElementTiers memberi tier len(resep)+2 untuk elemen yang tidak bisa dibuat
+	tiers, _ := ElementTiers(validRecipes, baseElements)
+	unreachableTier := len(validRecipes) + 2
+	for _, r := range validRecipes {
+		if tiers[r.Ingredient1] >= unreachableTier || tiers[r.Ingredient2] >= unreachableTier {
+			report.UnproducibleRecipes = append(report.UnproducibleRecipes, r)
+		}
+	}
+
+	validImages := make([]ElementImage, len(images))
+	hasImage := make(map[string]bool, len(images))
+	for i, img := range images {
+		validImages[i] = ElementImage{Name: NormalizeElementName(img.Name), ImageURL: strings.TrimSpace(img.ImageURL)}
+		if validImages[i].ImageURL != "" {
+			hasImage[validImages[i].Name] = true
+		}
+	}
+	for _, name := range names {
+		if !hasImage[name] {
+			report.MissingImages = append(report.MissingImages, name)
+		}
+	}
+	orphans := make(map[string]bool)
+	for _, img := range validImages {
+		if !elements[img.Name] && !isBase[img.Name] {
+			orphans[img.Name] = true
+		}
+	}
+	for name := range orphans {
+		report.OrphanElements = append(report.OrphanElements, name)
+	}
+	sort.Strings(report.OrphanElements)
+
+	addProblem := func(list *[]string, count int, format string, examples []string) {
+		if count == 0 {
+			return
+		}
+		message := fmt.Sprintf(format, count)
+		if len(examples) > 0 {
+			if len(examples) > integrityExampleLimit {
+				examples = append(examples[:integrityExampleLimit:integrityExampleLimit], "...")
+			}
+			message += ": " + strings.Join(examples, ", ")
+		}
+		*list = append(*list, message)
+	}
+	recipeIDs := func(recipes []Recipe) []string {
+		ids := make([]string, len(recipes))
+		for i, r := range recipes {
+			ids[i] = RecipeID(r)
+		}
+		return ids
+	}
+	addProblem(&report.Errors, len(report.InvalidRecipes), "%d resep memiliki nama elemen kosong", nil)
+	addProblem(&report.Errors, len(report.DuplicateRecipes), "%d resep duplikat dibuang", recipeIDs(report.DuplicateRecipes))
+	addProblem(&report.Errors, len(report.UnknownElements), "%d elemen dipakai sebagai bahan tetapi tidak punya resep", report.UnknownElements)
+	addProblem(&report.Errors, len(report.UnproducibleRecipes), "%d resep memakai bahan yang tidak bisa dibuat", recipeIDs(report.UnproducibleRecipes))
+	// Whitespace sudah dirapikan, jadi tidak membuat -strict gagal
+	addProblem(&report.Warnings, report.NormalizedRecipes, "%d resep memiliki whitespace yang dirapikan", nil)
+	addProblem(&report.Warnings, len(report.MissingImages), "%d elemen tidak punya gambar", report.MissingImages)
+	addProblem(&report.Warnings, len(report.OrphanElements), "%d elemen punya gambar tetapi tidak muncul di resep", report.OrphanElements)
+	return validRecipes, validImages, report
+}
+
+// integrityExampleLimit: jumlah contoh maksimum per pesan validasi.
+const integrityExampleLimit = 5
+
+// BuildMaps mengelompokkan resep per hasil, memetakan nama elemen ke URL
+// gambar, dan mengumpulkan semua nama elemen (hasil, bahan, gambar, dan
+// baseElements).
+func BuildMaps(recipes []Recipe, images []ElementImage, baseElements []string) (map[string][]Recipe, map[string]string, map[string]bool) {
+	resultRecipes := make(map[string][]Recipe)
+	imageURLs := make(map[string]string)
+	elementNames := make(map[string]bool)
+
+	// Proses resep
+	for _, r := range recipes {
+		resultRecipes[r.Result] = append(resultRecipes[r.Result], r)
+		// Catat semua nama elemen yang terlibat
+		elementNames[r.Result] = true
+		elementNames[r.Ingredient1] = true
+		elementNames[r.Ingredient2] = true
+	}
+
+	// Proses gambar
+	for _, img := range images {
+		imageURLs[img.Name] = img.ImageURL
+		// Catat juga nama elemen dari data gambar (jika ada yg belum tercatat)
+		elementNames[img.Name] = true
+	}
+
+	// Tambahkan elemen dasar secara eksplisit jika belum ada dari scraping
+	for _, base := range baseElements {
+		if _, exists := imageURLs[base]; !exists {
+			// Jika gambar elemen dasar tidak ada di JSON, URL akan kosong
+			// Anda bisa tambahkan placeholder jika diperlukan frontend
+			// imageMap[base] = "/placeholder.svg"
+			fmt.Printf("Info: URL gambar untuk elemen dasar '%s' tidak ditemukan di JSON.\n", base)
+		}
+		elementNames[base] = true
+	}
+	return resultRecipes, imageURLs, elementNames
+}
+
+// FilteredRecipeFile adalah nama file resep hasil filter di direktori data
+// setiap edisi, sumber data pencarian.
+const FilteredRecipeFile = "recipes_final_filtered.json"
+
+// LoadFile memuat resep dari filePath, memvalidasinya dengan Validate, lalu
+// membangun Dataset dengan elemen dasar baseElements dari resep yang lolos.
+func LoadFile(filePath string, baseElements []string) (*Dataset, DataIntegrityReport, error) {
+	recipes, err := LoadRecipes(filePath)
+	if err != nil {
+		return nil, DataIntegrityReport{}, err
+	}
+	recipes, _, report := Validate(recipes, nil, baseElements)
+	return FromRecipes(recipes, baseElements), report, nil
+}
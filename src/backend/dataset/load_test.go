@@ -0,0 +1,75 @@
+// src/backend/dataset/load_test.go
+package dataset
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidateData(t *testing.T) {
+	recipes := []Recipe{
+		{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"},
+		{Result: " Mud", Ingredient1: "Earth ", Ingredient2: "Water"}, // duplikat setelah dirapikan
+		{Result: "Steam", Ingredient1: "Water", Ingredient2: "Fire"},
+		{Result: "Golem", Ingredient1: "Clay", Ingredient2: "Life"}, // Clay dan Life tidak punya resep
+		{Result: "Brick", Ingredient1: "Mud", Ingredient2: "Golem"}, // Golem tidak bisa dibuat
+		{Result: "", Ingredient1: "Air", Ingredient2: "Air"},
+	}
+	images := []ElementImage{
+		{Name: "Mud", ImageURL: "mud.png"},
+		{Name: "Steam ", ImageURL: "steam.png"},
+		{Name: "Ghost", ImageURL: "ghost.png"},
+	}
+	kept, keptImages, report := Validate(recipes, images, BaseElements)
+
+	if report.RecipesLoaded != 6 || report.RecipesKept != 4 || len(kept) != 4 {
+		t.Fatalf("resep %d -> %d (%d dikembalikan)", report.RecipesLoaded, report.RecipesKept, len(kept))
+	}
+	if len(report.InvalidRecipes) != 1 || len(report.DuplicateRecipes) != 1 || report.NormalizedRecipes != 1 {
+		t.Errorf("invalid = %v, duplikat = %v, dirapikan = %d", report.InvalidRecipes, report.DuplicateRecipes, report.NormalizedRecipes)
+	}
+	if !reflect.DeepEqual(report.UnknownElements, []string{"Clay", "Life"}) {
+		t.Errorf("elemen tidak dikenal = %v", report.UnknownElements)
+	}
+	if len(report.UnproducibleRecipes) != 2 {
+		t.Errorf("resep tidak bisa dibuat = %v", report.UnproducibleRecipes)
+	}
+	if keptImages[1].Name != "Steam" || slices.Contains(report.MissingImages, "Steam") || !slices.Contains(report.MissingImages, "Golem") {
+		t.Errorf("gambar tidak dirapikan: %v, tanpa gambar = %v", keptImages, report.MissingImages)
+	}
+	if !reflect.DeepEqual(report.OrphanElements, []string{"Ghost"}) {
+		t.Errorf("elemen yatim = %v", report.OrphanElements)
+	}
+	if len(report.Errors) != 4 || len(report.Warnings) != 3 {
+		t.Errorf("errors = %q, warnings = %q", report.Errors, report.Warnings)
+	}
+}
+
+func TestValidateNormalizedDataIsNotAnError(t *testing.T) {
+	recipes := []Recipe{
+		{Result: " Mud", Ingredient1: "Water ", Ingredient2: "Earth"},
+		{Result: "Steam", Ingredient1: "Water", Ingredient2: " Fire "},
+	}
+	kept, _, report := Validate(recipes, nil, BaseElements)
+	if len(kept) != 2 || kept[0].Result != "Mud" || kept[1].Ingredient2 != "Fire" {
+		t.Fatalf("resep tidak dirapikan: %v", kept)
+	}
+	if report.NormalizedRecipes != 2 || len(report.Errors) != 0 {
+		t.Errorf("dirapikan = %d, errors = %q", report.NormalizedRecipes, report.Errors)
+	}
+	if !slices.ContainsFunc(report.Warnings, func(w string) bool { return strings.Contains(w, "whitespace") }) {
+		t.Errorf("normalisasi tidak dilaporkan di warnings: %q", report.Warnings)
+	}
+}
+
+func TestShippedDataPassesStrictValidation(t *testing.T) {
+	report := testIntegrity
+	if len(report.Errors) != 0 {
+		t.Errorf("data bawaan gagal validasi -strict: %q", report.Errors)
+	}
+	if report.RecipesKept != len(testDataset.Recipes()) {
+		t.Errorf("%d resep lolos validasi, %d dimuat", report.RecipesKept, len(testDataset.Recipes()))
+	}
+}
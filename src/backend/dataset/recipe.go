@@ -0,0 +1,45 @@
+// src/backend/dataset/recipe.go
+
+// Package dataset berisi data resep Little Alchemy: struktur Recipe, pemuatan
+// dan validasi file data, tier elemen, statistik, impor/ekspor, dan Dataset
+// yang dibaca semua algoritma pencarian.
+package dataset
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// --- Definisi Struct ---
+// Struct ini HARUS cocok dengan struktur JSON Anda
+
+type Recipe struct {
+	Result      string `json:"result"`
+	Ingredient1 string `json:"ingredient1"`
+	Ingredient2 string `json:"ingredient2"`
+}
+
+type ElementImage struct {
+	Name     string `json:"name"`
+	ImageURL string `json:"imageURL"`
+}
+
+// BaseElements adalah elemen dasar bawaan, dipakai Dataset yang dibangun
+// tanpa daftar elemen dasar sendiri (lihat New). Elemen dasar setiap edisi
+// ada di GameEdition.BaseElements.
+var BaseElements = []string{"Air", "Earth", "Fire", "Water"}
+
+// RecipeID adalah kunci resep yang tidak bergantung urutan bahan
+// ("A+B=>Hasil" dengan A <= B), dipakai untuk deduplikasi.
+func RecipeID(r Recipe) string {
+	ings := []string{r.Ingredient1, r.Ingredient2}
+	sort.Strings(ings)
+	return fmt.Sprintf("%s+%s=>%s", ings[0], ings[1], r.Result)
+}
+
+// NormalizeElementName merapikan whitespace nama elemen (spasi di awal/akhir
+// dan spasi ganda).
+func NormalizeElementName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
@@ -0,0 +1,572 @@
+// src/backend/dataset/recipe_io.go
+package dataset
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RecipeFormats adalah format yang didukung ExportRecipes dan ImportRecipes.
+var RecipeFormats = []string{"json", "csv", "ndjson", "graphml", "dot"}
+
+// RecipeFormatContentTypes dipakai /api/export untuk header Content-Type.
+var RecipeFormatContentTypes = map[string]string{
+	"json":    "application/json",
+	"csv":     "text/csv; charset=utf-8",
+	"ndjson":  "application/x-ndjson",
+	"graphml": "application/graphml+xml",
+	"dot":     "text/vnd.graphviz; charset=utf-8",
+}
+
+// csvRecipeHeader adalah baris pertama file CSV resep.
+var csvRecipeHeader = []string{"result", "ingredient1", "ingredient2"}
+
+// recipeFormatFromPath menebak format dari ekstensi file ("" jika tidak dikenal).
+func recipeFormatFromPath(path string) string {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	switch ext {
+	case "jsonl":
+		return "ndjson"
+	case "gv":
+		return "dot"
+	case "xml":
+		return "graphml"
+	}
+	for _, format := range RecipeFormats {
+		if ext == format {
+			return format
+		}
+	}
+	return ""
+}
+
+// ExportRecipes menulis resep ke w dalam format yang diminta. baseElements
+// menandai elemen dasar di format graf (GraphML dan DOT).
+func ExportRecipes(w io.Writer, recipes []Recipe, format string, baseElements []string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(recipes)
+	case "csv":
+		return writeRecipesCSV(w, recipes)
+	case "ndjson":
+		encoder := json.NewEncoder(w)
+		for _, r := range recipes {
+			if err := encoder.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "graphml":
+		return writeRecipesGraphML(w, recipes, baseElements)
+	case "dot":
+		return writeRecipesDOT(w, recipes, baseElements)
+	}
+	return fmt.Errorf("format '%s' tidak dikenal (gunakan %s)", format, strings.Join(RecipeFormats, ", "))
+}
+
+// ImportRecipes membaca resep dari r dalam format yang diminta. Resep tidak
+// divalidasi di sini (lihat validateData dan runImportMode).
+func ImportRecipes(r io.Reader, format string) ([]Recipe, error) {
+	switch format {
+	case "json":
+		var recipes []Recipe
+		if err := json.NewDecoder(r).Decode(&recipes); err != nil {
+			return nil, fmt.Errorf("JSON tidak valid: %w", err)
+		}
+		return recipes, nil
+	case "csv":
+		return readRecipesCSV(r)
+	case "ndjson":
+		return readRecipesNDJSON(r)
+	case "graphml":
+		return readRecipesGraphML(r)
+	case "dot":
+		return readRecipesDOT(r)
+	}
+	return nil, fmt.Errorf("format '%s' tidak dikenal (gunakan %s)", format, strings.Join(RecipeFormats, ", "))
+}
+
+// ImportRecipesFile membaca file resep; format kosong ditebak dari ekstensi.
+func ImportRecipesFile(path, format string) ([]Recipe, error) {
+	if format == "" {
+		if format = recipeFormatFromPath(path); format == "" {
+			return nil, fmt.Errorf("format file %s tidak bisa ditebak dari ekstensinya", path)
+		}
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	recipes, err := ImportRecipes(file, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return recipes, nil
+}
+
+func writeRecipesCSV(w io.Writer, recipes []Recipe) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvRecipeHeader); err != nil {
+		return err
+	}
+	for _, r := range recipes {
+		if err := writer.Write([]string{r.Result, r.Ingredient1, r.Ingredient2}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// readRecipesCSV membaca CSV dengan header berisi kolom result, ingredient1,
+// dan ingredient2 (urutan bebas, kolom lain diabaikan) agar file hasil edit
+// spreadsheet bisa langsung dipakai.
+func readRecipesCSV(r io.Reader) ([]Recipe, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("file CSV kosong")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	index := make([]int, len(csvRecipeHeader))
+	for i, name := range csvRecipeHeader {
+		col, ok := columns[name]
+		if !ok {
+			return nil, fmt.Errorf("header CSV tidak memiliki kolom '%s'", name)
+		}
+		index[i] = col
+	}
+
+	var recipes []Recipe
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return recipes, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(i int) string {
+			if index[i] < len(record) {
+				return record[index[i]]
+			}
+			return ""
+		}
+		recipes = append(recipes, Recipe{Result: field(0), Ingredient1: field(1), Ingredient2: field(2)})
+	}
+}
+
+func readRecipesNDJSON(r io.Reader) ([]Recipe, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var recipes []Recipe
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var recipe Recipe
+		if err := json.Unmarshal([]byte(text), &recipe); err != nil {
+			return nil, fmt.Errorf("baris %d: %w", line, err)
+		}
+		recipes = append(recipes, recipe)
+	}
+	return recipes, scanner.Err()
+}
+
+// --- GraphML ---
+//
+// Graf diekspor bipartit: satu node per elemen (kind=element) dan satu node
+// per resep (kind=recipe), dengan edge bahan -> resep (role=ingredient) dan
+// resep -> hasil (role=result). Node resep juga menyimpan result,
+// ingredient1, dan ingredient2 sehingga file bisa diimpor kembali.
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr,omitempty"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr,omitempty"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+var graphMLKeys = []graphMLKey{
+	{ID: "kind", For: "node", Name: "kind", Type: "string"},
+	{ID: "name", For: "node", Name: "name", Type: "string"},
+	{ID: "tier", For: "node", Name: "tier", Type: "int"},
+	{ID: "base", For: "node", Name: "base", Type: "boolean"},
+	{ID: "result", For: "node", Name: "result", Type: "string"},
+	{ID: "ingredient1", For: "node", Name: "ingredient1", Type: "string"},
+	{ID: "ingredient2", For: "node", Name: "ingredient2", Type: "string"},
+	{ID: "role", For: "edge", Name: "role", Type: "string"},
+}
+
+// recipeElements mengembalikan nama semua elemen di resep (termasuk elemen
+// dasar) secara terurut.
+func recipeElements(recipes []Recipe, baseElements []string) []string {
+	set := make(map[string]bool)
+	for _, base := range baseElements {
+		set[base] = true
+	}
+	for _, r := range recipes {
+		set[r.Result] = true
+		set[r.Ingredient1] = true
+		set[r.Ingredient2] = true
+	}
+	return slices.Sorted(maps.Keys(set))
+}
+
+func writeRecipesGraphML(w io.Writer, recipes []Recipe, baseElements []string) error {
+	tiers, _ := ElementTiers(recipes, baseElements)
+	unreachableTier := len(recipes) + 2
+
+	doc := graphMLDocument{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys:  graphMLKeys,
+		Graph: graphMLGraph{ID: "recipes", EdgeDefault: "directed"},
+	}
+	elementIDs := make(map[string]string)
+	for i, name := range recipeElements(recipes, baseElements) {
+		id := "e" + strconv.Itoa(i)
+		elementIDs[name] = id
+		data := []graphMLData{{"kind", "element"}, {"name", name}, {"base", strconv.FormatBool(slices.Contains(baseElements, name))}}
+		if tier, ok := tiers[name]; ok && tier < unreachableTier {
+			data = append(data, graphMLData{"tier", strconv.Itoa(tier)})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{ID: id, Data: data})
+	}
+	for i, r := range recipes {
+		id := "r" + strconv.Itoa(i)
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{ID: id, Data: []graphMLData{
+			{"kind", "recipe"}, {"result", r.Result}, {"ingredient1", r.Ingredient1}, {"ingredient2", r.Ingredient2},
+		}})
+		ingredient := []graphMLData{{"role", "ingredient"}}
+		doc.Graph.Edges = append(doc.Graph.Edges,
+			graphMLEdge{Source: elementIDs[r.Ingredient1], Target: id, Data: ingredient},
+			graphMLEdge{Source: elementIDs[r.Ingredient2], Target: id, Data: ingredient},
+			graphMLEdge{Source: id, Target: elementIDs[r.Result], Data: []graphMLData{{"role", "result"}}},
+		)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// readRecipesGraphML membaca node resep (kind=recipe) dari file GraphML.
+// Kunci data dicocokkan lewat attr.name, jadi id kunci boleh berbeda.
+func readRecipesGraphML(r io.Reader) ([]Recipe, error) {
+	var doc graphMLDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("GraphML tidak valid: %w", err)
+	}
+	keyNames := make(map[string]string, len(doc.Keys))
+	for _, key := range doc.Keys {
+		keyNames[key.ID] = key.Name
+	}
+	var recipes []Recipe
+	for _, node := range doc.Graph.Nodes {
+		values := make(map[string]string, len(node.Data))
+		for _, data := range node.Data {
+			values[keyNames[data.Key]] = data.Value
+		}
+		if values["kind"] != "recipe" {
+			continue
+		}
+		recipes = append(recipes, Recipe{Result: values["result"], Ingredient1: values["ingredient1"], Ingredient2: values["ingredient2"]})
+	}
+	if len(recipes) == 0 {
+		return nil, errors.New("GraphML tidak berisi node resep (kind=recipe)")
+	}
+	return recipes, nil
+}
+
+// --- DOT (graphviz) ---
+
+// DotQuote mengutip string sebagai ID DOT (dipakai juga render pohon resep).
+func DotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// writeRecipesDOT menulis graf bipartit yang sama dengan GraphML: elemen
+// sebagai kotak (elemen dasar diberi warna), resep sebagai titik.
+func writeRecipesDOT(w io.Writer, recipes []Recipe, baseElements []string) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "digraph recipes {")
+	fmt.Fprintln(b, "  rankdir=LR;")
+	fmt.Fprintln(b, "  node [shape=box];")
+	elements := recipeElements(recipes, baseElements)
+	isBase := func(name string) bool { return slices.Contains(baseElements, name) }
+	sort.SliceStable(elements, func(i, j int) bool { return isBase(elements[i]) && !isBase(elements[j]) })
+	for _, name := range elements {
+		if isBase(name) {
+			fmt.Fprintf(b, "  %s [style=filled, fillcolor=lightblue];\n", DotQuote(name))
+		} else {
+			fmt.Fprintf(b, "  %s;\n", DotQuote(name))
+		}
+	}
+	for i, r := range recipes {
+		id := "r" + strconv.Itoa(i)
+		fmt.Fprintf(b, "  %s [shape=point];\n", id)
+		fmt.Fprintf(b, "  %s -> %s;\n", DotQuote(r.Ingredient1), id)
+		fmt.Fprintf(b, "  %s -> %s;\n", DotQuote(r.Ingredient2), id)
+		fmt.Fprintf(b, "  %s -> %s;\n", id, DotQuote(r.Result))
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// dotToken adalah satu token DOT. ID yang dikutip ditandai agar "node" atau
+// "edge" sebagai nama elemen tidak dianggap kata kunci.
+type dotToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeDOT memecah file DOT menjadi ID, "->", dan tanda baca. Komentar
+// (//, #, /* */) dilewati; edge tak berarah "--" ditolak.
+func tokenizeDOT(src string) ([]dotToken, error) {
+	var tokens []dotToken
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '#' || strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, errors.New("komentar /* tidak ditutup")
+			}
+			i += end + 4
+		case strings.HasPrefix(src[i:], "->"):
+			tokens = append(tokens, dotToken{text: "->"})
+			i += 2
+		case strings.HasPrefix(src[i:], "--"):
+			return nil, errors.New("edge tak berarah (--) tidak didukung, gunakan digraph")
+		case strings.ContainsRune("{}[];,=", rune(c)):
+			tokens = append(tokens, dotToken{text: string(c)})
+			i++
+		case c == '"':
+			var sb strings.Builder
+			i++
+			for ; i < len(src) && src[i] != '"'; i++ {
+				if src[i] == '\\' && i+1 < len(src) && (src[i+1] == '"' || src[i+1] == '\\') {
+					i++
+				}
+				sb.WriteByte(src[i])
+			}
+			if i >= len(src) {
+				return nil, errors.New("string DOT tidak ditutup")
+			}
+			tokens = append(tokens, dotToken{text: sb.String(), quoted: true})
+			i++
+		default:
+			start := i
+			for i < len(src) && !strings.ContainsRune(" \t\n\r{}[];,=\"#", rune(src[i])) && !strings.HasPrefix(src[i:], "->") {
+				i++
+			}
+			tokens = append(tokens, dotToken{text: src[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+// readRecipesDOT membaca graf bipartit yang ditulis writeRecipesDOT: node
+// dengan shape=point adalah resep, dua edge masuknya adalah bahan (sesuai
+// urutan di file), dan satu edge keluarnya adalah hasil. Subgraph tidak
+// didukung.
+func readRecipesDOT(r io.Reader) ([]Recipe, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := tokenizeDOT(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("DOT tidak valid: %w", err)
+	}
+	pos := 0
+	peek := func() dotToken {
+		if pos < len(tokens) {
+			return tokens[pos]
+		}
+		return dotToken{}
+	}
+	isBare := func(tok dotToken, text string) bool { return !tok.quoted && tok.text == text }
+
+	// Header: [strict] digraph [ID] {
+	if isBare(peek(), "strict") {
+		pos++
+	}
+	if !isBare(peek(), "digraph") {
+		return nil, errors.New("DOT tidak valid: harus diawali 'digraph'")
+	}
+	pos++
+	if !isBare(peek(), "{") {
+		pos++
+	}
+	if !isBare(peek(), "{") {
+		return nil, errors.New("DOT tidak valid: '{' tidak ditemukan")
+	}
+	pos++
+
+	// readAttrs membaca satu atau lebih daftar [a=b, ...].
+	readAttrs := func() (map[string]string, error) {
+		attrs := make(map[string]string)
+		for isBare(peek(), "[") {
+			pos++
+			for !isBare(peek(), "]") {
+				if pos >= len(tokens) {
+					return nil, errors.New("daftar atribut tidak ditutup")
+				}
+				key := tokens[pos].text
+				pos++
+				if isBare(peek(), "=") && pos+1 < len(tokens) {
+					attrs[key] = tokens[pos+1].text
+					pos += 2
+				}
+				if isBare(peek(), ",") || isBare(peek(), ";") {
+					pos++
+				}
+			}
+			pos++
+		}
+		return attrs, nil
+	}
+
+	var recipeIDs []string
+	isRecipe := make(map[string]bool)
+	incoming := make(map[string][]string)
+	outgoing := make(map[string][]string)
+	closed := false
+	for pos < len(tokens) && !closed {
+		tok := tokens[pos]
+		switch {
+		case isBare(tok, "}"):
+			closed = true
+			pos++
+			continue
+		case isBare(tok, ";") || isBare(tok, ","):
+			pos++
+			continue
+		case isBare(tok, "{") || isBare(tok, "[") || isBare(tok, "]") || isBare(tok, "=") || isBare(tok, "->"):
+			return nil, fmt.Errorf("DOT tidak valid: token '%s' tidak terduga", tok.text)
+		case !tok.quoted && (tok.text == "subgraph"):
+			return nil, errors.New("DOT tidak valid: subgraph tidak didukung")
+		case !tok.quoted && (tok.text == "node" || tok.text == "edge" || tok.text == "graph"):
+			pos++
+			if _, err := readAttrs(); err != nil {
+				return nil, fmt.Errorf("DOT tidak valid: %w", err)
+			}
+			continue
+		}
+
+		// Atribut graf (rankdir=LR), node, atau rantai edge a -> b -> c
+		pos++
+		if isBare(peek(), "=") {
+			pos += 2
+			continue
+		}
+		chain := []string{tok.text}
+		for isBare(peek(), "->") {
+			pos++
+			if pos >= len(tokens) || (!tokens[pos].quoted && strings.ContainsAny(tokens[pos].text, "{}[];,=")) {
+				return nil, errors.New("DOT tidak valid: edge tanpa node tujuan")
+			}
+			chain = append(chain, tokens[pos].text)
+			pos++
+		}
+		attrs, err := readAttrs()
+		if err != nil {
+			return nil, fmt.Errorf("DOT tidak valid: %w", err)
+		}
+		if len(chain) == 1 {
+			if attrs["shape"] == "point" && !isRecipe[tok.text] {
+				isRecipe[tok.text] = true
+				recipeIDs = append(recipeIDs, tok.text)
+			}
+			continue
+		}
+		for i := 0; i+1 < len(chain); i++ {
+			outgoing[chain[i]] = append(outgoing[chain[i]], chain[i+1])
+			incoming[chain[i+1]] = append(incoming[chain[i+1]], chain[i])
+		}
+	}
+	if !closed {
+		return nil, errors.New("DOT tidak valid: '}' penutup tidak ditemukan")
+	}
+	if len(recipeIDs) == 0 {
+		return nil, errors.New("DOT tidak berisi node resep (shape=point)")
+	}
+
+	recipes := make([]Recipe, 0, len(recipeIDs))
+	for _, id := range recipeIDs {
+		in, out := incoming[id], outgoing[id]
+		if len(in) != 2 || len(out) != 1 {
+			return nil, fmt.Errorf("node resep %s memiliki %d edge bahan dan %d edge hasil, ingin 2 dan 1", id, len(in), len(out))
+		}
+		recipes = append(recipes, Recipe{Result: out[0], Ingredient1: in[0], Ingredient2: in[1]})
+	}
+	return recipes, nil
+}
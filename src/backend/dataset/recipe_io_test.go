@@ -0,0 +1,107 @@
+// src/backend/dataset/recipe_io_test.go
+package dataset
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecipeFormatsRoundTrip(t *testing.T) {
+	recipes := testDataset.Recipes()
+	for _, format := range RecipeFormats {
+		var buf bytes.Buffer
+		if err := ExportRecipes(&buf, recipes, format, BaseElements); err != nil {
+			t.Fatalf("%s: ekspor gagal: %v", format, err)
+		}
+		imported, err := ImportRecipes(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: impor gagal: %v", format, err)
+		}
+		if !reflect.DeepEqual(imported, recipes) {
+			t.Errorf("%s: %d resep setelah impor, ingin %d yang sama", format, len(imported), len(recipes))
+		}
+	}
+}
+
+func TestImportRecipesCSVSpreadsheet(t *testing.T) {
+	// Kolom ditukar, kolom tambahan, BOM, dan nama dengan koma
+	input := "\ufeffIngredient2,Result,Ingredient1,catatan\n" +
+		"Earth,Mud,Water,\n" +
+		"Fire,\"Salt, Sea\",Water,baru\n"
+	recipes, err := ImportRecipes(strings.NewReader(input), "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Recipe{
+		{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"},
+		{Result: "Salt, Sea", Ingredient1: "Water", Ingredient2: "Fire"},
+	}
+	if !reflect.DeepEqual(recipes, want) {
+		t.Errorf("resep = %v, ingin %v", recipes, want)
+	}
+
+	for format, input := range map[string]string{
+		"csv":     "result,ingredient1\nMud,Water\n",
+		"ndjson":  "{\"result\":\"Mud\"}\n{bukan json}\n",
+		"graphml": "<graphml><graph edgedefault=\"directed\"></graph></graphml>",
+		"dot":     "digraph recipes {}",
+		"yaml":    "",
+	} {
+		if _, err := ImportRecipes(strings.NewReader(input), format); err == nil {
+			t.Errorf("%s: input %q seharusnya ditolak", format, input)
+		}
+	}
+}
+
+func TestExportDOT(t *testing.T) {
+	var buf bytes.Buffer
+	recipes := []Recipe{{Result: `Say "Hi"`, Ingredient1: "Air", Ingredient2: "Air"}}
+	if err := ExportRecipes(&buf, recipes, "dot", BaseElements); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, line := range []string{`"Air" [style=filled, fillcolor=lightblue];`, `"Say \"Hi\"";`, `"Air" -> r0;`, `r0 -> "Say \"Hi\"";`} {
+		if !strings.Contains(out, line) {
+			t.Errorf("output DOT tidak berisi %s:\n%s", line, out)
+		}
+	}
+}
+
+func TestImportDOT(t *testing.T) {
+	// Hasil ekspor yang diedit tangan: komentar, urutan pernyataan berbeda,
+	// rantai edge, dan nama dengan kutip
+	input := `digraph recipes {
+  rankdir=LR; // komentar
+  node [shape=box];
+  r1 -> "Say \"Hi\"";
+  r1 [shape=point];
+  "Air" -> r1; "Water" -> r1;
+  /* resep lain */
+  r0 [shape=point]
+  "Water" -> r0 -> Mud
+  Earth -> r0
+}`
+	recipes, err := ImportRecipes(strings.NewReader(input), "dot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Recipe{
+		{Result: `Say "Hi"`, Ingredient1: "Air", Ingredient2: "Water"},
+		{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"},
+	}
+	if !reflect.DeepEqual(recipes, want) {
+		t.Errorf("resep = %v, ingin %v", recipes, want)
+	}
+
+	for _, input := range []string{
+		"graph recipes { a -- b }",
+		"digraph { r0 [shape=point]; a -> r0; r0 -> b; }",
+		"digraph { r0 [shape=point]; a -> r0; b -> r0; r0 -> c;",
+	} {
+		if _, err := ImportRecipes(strings.NewReader(input), "dot"); err == nil {
+			t.Errorf("input %q seharusnya ditolak", input)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+// src/backend/dataset/stats.go
+package dataset
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// StatsTopN adalah jumlah maksimum entri untuk daftar "teratas" di statistik.
+const StatsTopN = 10
+
+// TierStats adalah jumlah elemen dan resep (menurut tier hasilnya) di satu tier.
+type TierStats struct {
+	Tier     int `json:"tier"`
+	Elements int `json:"elements"`
+	Recipes  int `json:"recipes"`
+}
+
+// DegreeBucket adalah satu baris histogram derajat: ada Elements elemen
+// dengan derajat Degree.
+type DegreeBucket struct {
+	Degree   int `json:"degree"`
+	Elements int `json:"elements"`
+}
+
+// ElementCount memasangkan elemen dengan sebuah hitungan (misalnya jumlah
+// resep yang memakainya).
+type ElementCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// DatasetStats adalah ringkasan statistik dan analitik graf satu dataset.
+type DatasetStats struct {
+	Elements     int `json:"elements"`
+	BaseElements int `json:"baseElements"`
+	Recipes      int `json:"recipes"`
+	MaxTier      int `json:"maxTier"`
+
+	Tiers               []TierStats `json:"tiers"`
+	UnreachableElements []string    `json:"unreachableElements"` // Tidak punya tier (tidak bisa dibuat)
+
+	// UsageDegrees: histogram jumlah resep yang memakai elemen (Graph).
+	// RecipeDegrees: histogram jumlah resep yang menghasilkan elemen (recipeMap).
+	UsageDegrees  []DegreeBucket `json:"usageDegrees"`
+	RecipeDegrees []DegreeBucket `json:"recipeDegrees"`
+
+	MostUsedIngredients  []ElementCount `json:"mostUsedIngredients"`
+	SingleRecipeElements []string       `json:"singleRecipeElements"`
+	SelfCombiningRecipes []Recipe       `json:"selfCombiningRecipes"`
+	TerminalElements     []string       `json:"terminalElements"` // Tidak pernah dipakai sebagai bahan
+
+	// LongestChains: untuk setiap elemen di tier tertinggi, rantai dari elemen
+	// dasar sampai elemen tersebut dengan tier naik tepat satu per langkah.
+	LongestChains [][]string `json:"longestChains"`
+}
+
+// Stats menghitung statistik dataset. Tier memakai ElementTiers,
+// sama dengan paket filter dan mode -compare.
+func (d *Dataset) Stats() DatasetStats {
+	stats := DatasetStats{
+		BaseElements:         len(d.BaseElements()),
+		UnreachableElements:  []string{},
+		SingleRecipeElements: []string{},
+		SelfCombiningRecipes: []Recipe{},
+		TerminalElements:     []string{},
+		LongestChains:        [][]string{},
+	}
+	if d == nil {
+		return stats
+	}
+
+	recipes := d.Recipes()
+	tiers, involved := ElementTiers(recipes, d.BaseElements())
+	names := make([]string, 0, len(involved))
+	for name := range involved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	stats.Elements = len(names)
+	stats.Recipes = len(recipes)
+
+	// ElementTiers memberi tier len(resep)+2 untuk elemen yang tidak bisa dibuat
+	unreachableTier := len(recipes) + 2
+	reachable := func(name string) bool {
+		return tiers[name] < unreachableTier
+	}
+
+	tierIndex := make(map[int]*TierStats)
+	tierOf := func(tier int) *TierStats {
+		ts, ok := tierIndex[tier]
+		if !ok {
+			ts = &TierStats{Tier: tier}
+			tierIndex[tier] = ts
+		}
+		return ts
+	}
+	for _, name := range names {
+		if !reachable(name) {
+			stats.UnreachableElements = append(stats.UnreachableElements, name)
+			continue
+		}
+		tierOf(tiers[name]).Elements++
+		if tiers[name] > stats.MaxTier {
+			stats.MaxTier = tiers[name]
+		}
+	}
+	for _, r := range recipes {
+		if reachable(r.Result) {
+			tierOf(tiers[r.Result]).Recipes++
+		}
+		if r.Ingredient1 == r.Ingredient2 {
+			stats.SelfCombiningRecipes = append(stats.SelfCombiningRecipes, r)
+		}
+	}
+	for tier := 0; tier <= stats.MaxTier; tier++ {
+		if ts, ok := tierIndex[tier]; ok {
+			stats.Tiers = append(stats.Tiers, *ts)
+		}
+	}
+
+	// Derajat: resep A + A tercatat dua kali di Graph()[A], jadi dihitung
+	// per resep unik
+	usage := make(map[string]int, len(names))
+	for _, name := range names {
+		seen := make(map[Recipe]bool)
+		for _, r := range d.graph[name] {
+			seen[r] = true
+		}
+		usage[name] = len(seen)
+	}
+	var mostUsed []ElementCount
+	for _, name := range names {
+		if usage[name] == 0 {
+			stats.TerminalElements = append(stats.TerminalElements, name)
+		} else {
+			mostUsed = append(mostUsed, ElementCount{Name: name, Count: usage[name]})
+		}
+		if len(d.recipeMap[name]) == 1 {
+			stats.SingleRecipeElements = append(stats.SingleRecipeElements, name)
+		}
+	}
+	sort.SliceStable(mostUsed, func(i, j int) bool {
+		return mostUsed[i].Count > mostUsed[j].Count
+	})
+	if len(mostUsed) > StatsTopN {
+		mostUsed = mostUsed[:StatsTopN]
+	}
+	stats.MostUsedIngredients = mostUsed
+	stats.UsageDegrees = degreeHistogram(names, func(name string) int { return usage[name] })
+	stats.RecipeDegrees = degreeHistogram(names, func(name string) int { return len(d.recipeMap[name]) })
+
+	if stats.MaxTier > 0 {
+		for _, name := range names {
+			if reachable(name) && tiers[name] == stats.MaxTier {
+				stats.LongestChains = append(stats.LongestChains, d.tierChain(name, tiers))
+			}
+		}
+	}
+	return stats
+}
+
+// degreeHistogram mengelompokkan elemen berdasarkan derajatnya, terurut derajat.
+func degreeHistogram(names []string, degree func(name string) int) []DegreeBucket {
+	counts := make(map[int]int)
+	for _, name := range names {
+		counts[degree(name)]++
+	}
+	buckets := make([]DegreeBucket, 0, len(counts))
+	for deg, count := range counts {
+		buckets = append(buckets, DegreeBucket{Degree: deg, Elements: count})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Degree < buckets[j].Degree
+	})
+	return buckets
+}
+
+// tierChain menelusuri mundur dari element lewat resep pertama yang
+// menghasilkan tier minimumnya, selalu ke bahan dengan tier lebih tinggi,
+// sampai elemen dasar. Hasilnya terurut dari elemen dasar ke element.
+func (d *Dataset) tierChain(element string, tiers map[string]int) []string {
+	chain := []string{element}
+	current := element
+	for tiers[current] > 0 {
+		next := ""
+		for _, r := range d.recipeMap[current] {
+			t1, t2 := tiers[r.Ingredient1], tiers[r.Ingredient2]
+			if 1+max(t1, t2) != tiers[current] {
+				continue
+			}
+			next = r.Ingredient1
+			if t2 > t1 {
+				next = r.Ingredient2
+			}
+			break
+		}
+		if next == "" {
+			break
+		}
+		chain = append(chain, next)
+		current = next
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
+
+// WriteStatsText menulis statistik dalam format teks yang mudah dibaca.
+func WriteStatsText(w io.Writer, stats DatasetStats) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Elemen        : %d (%d dasar, %d tidak bisa dibuat)\n", stats.Elements, stats.BaseElements, len(stats.UnreachableElements))
+	fmt.Fprintf(&b, "Resep         : %d\n", stats.Recipes)
+	fmt.Fprintf(&b, "Tier tertinggi: %d\n", stats.MaxTier)
+
+	b.WriteString("\nTier  Elemen  Resep\n")
+	for _, ts := range stats.Tiers {
+		fmt.Fprintf(&b, "%4d  %6d  %5d\n", ts.Tier, ts.Elements, ts.Recipes)
+	}
+
+	b.WriteString("\nDerajat pemakaian sebagai bahan (derajat: jumlah elemen)\n")
+	writeDegreeBuckets(&b, stats.UsageDegrees)
+	b.WriteString("\nJumlah resep per elemen (derajat: jumlah elemen)\n")
+	writeDegreeBuckets(&b, stats.RecipeDegrees)
+
+	fmt.Fprintf(&b, "\nBahan paling sering dipakai (top %d)\n", StatsTopN)
+	for i, ec := range stats.MostUsedIngredients {
+		fmt.Fprintf(&b, "  %2d. %s (%d resep)\n", i+1, ec.Name, ec.Count)
+	}
+
+	WriteNameList(&b, "Elemen dengan tepat satu resep", stats.SingleRecipeElements)
+	selfCombining := make([]string, len(stats.SelfCombiningRecipes))
+	for i, r := range stats.SelfCombiningRecipes {
+		selfCombining[i] = fmt.Sprintf("%s + %s = %s", r.Ingredient1, r.Ingredient2, r.Result)
+	}
+	WriteNameList(&b, "Resep yang menggabungkan elemen dengan dirinya sendiri", selfCombining)
+	WriteNameList(&b, "Elemen terminal (tidak pernah jadi bahan)", stats.TerminalElements)
+	if len(stats.UnreachableElements) > 0 {
+		WriteNameList(&b, "Elemen yang tidak bisa dibuat", stats.UnreachableElements)
+	}
+
+	fmt.Fprintf(&b, "\nRantai terpanjang (tier %d)\n", stats.MaxTier)
+	for _, chain := range stats.LongestChains {
+		fmt.Fprintf(&b, "  %s\n", strings.Join(chain, " -> "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeDegreeBuckets(b *strings.Builder, buckets []DegreeBucket) {
+	for _, bucket := range buckets {
+		fmt.Fprintf(b, "  %4d: %d\n", bucket.Degree, bucket.Elements)
+	}
+}
+
+// WriteNameList menulis daftar nama berjudul beserta jumlahnya, satu nama
+// per baris. Dipakai juga oleh ringkasan teks diff di package api.
+func WriteNameList(b *strings.Builder, title string, names []string) {
+	fmt.Fprintf(b, "\n%s (%d)\n", title, len(names))
+	for _, name := range names {
+		fmt.Fprintf(b, "  %s\n", name)
+	}
+}
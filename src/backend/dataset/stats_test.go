@@ -0,0 +1,66 @@
+// src/backend/dataset/stats_test.go
+package dataset
+
+import "testing"
+
+func TestStatsConsistent(t *testing.T) {
+	stats := testDataset.Stats()
+	names := make(map[string]bool)
+	for _, base := range BaseElements {
+		names[base] = true
+	}
+	for _, r := range testDataset.Recipes() {
+		names[r.Result], names[r.Ingredient1], names[r.Ingredient2] = true, true, true
+	}
+	if stats.Elements != len(names) || stats.Recipes != len(testDataset.Recipes()) {
+		t.Fatalf("elemen = %d, resep = %d tidak sesuai dataset", stats.Elements, stats.Recipes)
+	}
+
+	elements, recipes := len(stats.UnreachableElements), 0
+	for _, ts := range stats.Tiers {
+		elements += ts.Elements
+		recipes += ts.Recipes
+	}
+	if elements != stats.Elements || recipes != stats.Recipes {
+		t.Errorf("jumlah per tier = %d elemen/%d resep, ingin %d/%d", elements, recipes, stats.Elements, stats.Recipes)
+	}
+
+	for _, histogram := range [][]DegreeBucket{stats.UsageDegrees, stats.RecipeDegrees} {
+		total := 0
+		for _, bucket := range histogram {
+			total += bucket.Elements
+		}
+		if total != stats.Elements {
+			t.Errorf("histogram derajat berjumlah %d, ingin %d", total, stats.Elements)
+		}
+	}
+	if len(stats.UsageDegrees) == 0 || stats.UsageDegrees[0].Degree != 0 || stats.UsageDegrees[0].Elements != len(stats.TerminalElements) {
+		t.Errorf("derajat 0 tidak sama dengan jumlah elemen terminal (%d)", len(stats.TerminalElements))
+	}
+
+	for _, r := range stats.SelfCombiningRecipes {
+		if r.Ingredient1 != r.Ingredient2 {
+			t.Errorf("resep %+v bukan self-combining", r)
+		}
+	}
+	for _, name := range stats.SingleRecipeElements {
+		if len(testDataset.RecipeMap()[name]) != 1 {
+			t.Errorf("%s punya %d resep", name, len(testDataset.RecipeMap()[name]))
+		}
+	}
+
+	tiers, _ := ElementTiers(testDataset.Recipes(), BaseElements)
+	if len(stats.LongestChains) == 0 {
+		t.Fatal("tidak ada rantai terpanjang")
+	}
+	for _, chain := range stats.LongestChains {
+		if len(chain) != stats.MaxTier+1 {
+			t.Errorf("rantai %v panjangnya %d, ingin %d", chain, len(chain), stats.MaxTier+1)
+		}
+		for i, el := range chain {
+			if tiers[el] != i {
+				t.Errorf("rantai %v: %s bertier %d di posisi %d", chain, el, tiers[el], i)
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// src/backend/dataset/tiers.go
+package dataset
+
+// recipeWorklist mengindeks resep per bahan untuk propagasi worklist:
+// sebuah resep "siap" setelah semua bahan uniknya diproses.
+type recipeWorklist struct {
+	recipes      []Recipe
+	byIngredient map[string][]int
+	missing      []int // jumlah bahan unik resep yang belum diproses
+}
+
+func newRecipeWorklist(recipes []Recipe) *recipeWorklist {
+	w := &recipeWorklist{
+		recipes:      recipes,
+		byIngredient: make(map[string][]int),
+		missing:      make([]int, len(recipes)),
+	}
+	for i, r := range recipes {
+		w.byIngredient[r.Ingredient1] = append(w.byIngredient[r.Ingredient1], i)
+		w.missing[i] = 1
+		if r.Ingredient2 != r.Ingredient1 {
+			w.byIngredient[r.Ingredient2] = append(w.byIngredient[r.Ingredient2], i)
+			w.missing[i] = 2
+		}
+	}
+	return w
+}
+
+// process menandai element sudah diproses dan memanggil ready untuk setiap
+// resep yang semua bahannya kini sudah diproses. Setiap elemen cukup
+// diproses sekali, jadi total kerja linear terhadap jumlah resep.
+func (w *recipeWorklist) process(element string, ready func(r Recipe)) {
+	for _, i := range w.byIngredient[element] {
+		w.missing[i]--
+		if w.missing[i] == 0 {
+			ready(w.recipes[i])
+		}
+	}
+}
+
+// MakeableElements mengembalikan elemen yang bisa dibuat dari baseElements
+// dengan recipes. Dihitung dengan worklist dari elemen dasar; resep yang
+// bahannya bisa dibuat selalu menghasilkan elemen yang bisa dibuat, jadi satu
+// kali propagasi sudah mencapai titik tetap.
+func MakeableElements(recipes []Recipe, baseElements []string) map[string]bool {
+	makeableElements := make(map[string]bool)
+	var queue []string
+	for _, base := range baseElements {
+		if !makeableElements[base] {
+			makeableElements[base] = true
+			queue = append(queue, base)
+		}
+	}
+	worklist := newRecipeWorklist(recipes)
+	for len(queue) > 0 {
+		element := queue[0]
+		queue = queue[1:]
+		worklist.process(element, func(r Recipe) {
+			if !makeableElements[r.Result] {
+				makeableElements[r.Result] = true
+				queue = append(queue, r.Result)
+			}
+		})
+	}
+	return makeableElements
+}
+
+// ElementTiers menghitung tier setiap elemen: 0 untuk elemen dasar,
+// dan untuk elemen lain min(1 + max(tier bahan1, tier bahan2)) atas semua
+// resepnya. Dihitung seperti Dijkstra/Knuth: elemen diproses dengan urutan
+// tier naik lewat antrian FIFO, dan resep yang bahannya sudah lengkap
+// memberi hasilnya tier (tier elemen terakhir yang diproses) + 1, yang pasti
+// minimum karena tier yang keluar dari antrian tidak pernah turun. Elemen
+// yang tidak bisa dibuat mendapat tier len(resep)+2.
+func ElementTiers(recipesForTierCalc []Recipe, baseElements []string) (map[string]int, map[string]bool) {
+	elementTiers := make(map[string]int)
+	allInvolvedElements := make(map[string]bool)
+
+	var queue []string
+	for _, base := range baseElements {
+		if !allInvolvedElements[base] {
+			queue = append(queue, base)
+		}
+		elementTiers[base] = 0
+		allInvolvedElements[base] = true
+	}
+	for _, r := range recipesForTierCalc {
+		allInvolvedElements[r.Result] = true
+		allInvolvedElements[r.Ingredient1] = true
+		allInvolvedElements[r.Ingredient2] = true
+	}
+
+	if len(recipesForTierCalc) == 0 {
+		return elementTiers, allInvolvedElements
+	}
+
+	worklist := newRecipeWorklist(recipesForTierCalc)
+	for len(queue) > 0 {
+		element := queue[0]
+		queue = queue[1:]
+		nextTier := elementTiers[element] + 1
+		worklist.process(element, func(r Recipe) {
+			if _, hasTier := elementTiers[r.Result]; !hasTier {
+				elementTiers[r.Result] = nextTier
+				queue = append(queue, r.Result)
+			}
+		})
+	}
+
+	defaultHighTier := len(recipesForTierCalc) + 2
+	for el := range allInvolvedElements {
+		if _, hasTier := elementTiers[el]; !hasTier {
+			elementTiers[el] = defaultHighTier
+		}
+	}
+	return elementTiers, allInvolvedElements
+}
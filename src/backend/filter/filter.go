@@ -0,0 +1,181 @@
+// src/backend/filter/filter.go
+
+// Package filter membersihkan hasil scraping menjadi dataset resep: profil
+// filter (pipeline rule) dan perintah filter yang menulis
+// recipes_final_filtered.json.
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"tubes2stima/backend/dataset"
+)
+
+// Run menjalankan filter untuk data satu edisi game di baseDir.
+func Run(baseDir string, baseElements []string) {
+	rawRecipeFile := filepath.Join(baseDir, ScrapedRecipeFile)
+	filteredRecipeFile := filepath.Join(baseDir, dataset.FilteredRecipeFile)
+
+	fmt.Println("Memulai skrip filter resep lanjutan...")
+
+	rawBytes, err := os.ReadFile(rawRecipeFile)
+	if err != nil {
+		fmt.Printf("Error membaca file resep mentah '%s': %v\n", rawRecipeFile, err)
+		return
+	}
+	var initialRecipes []dataset.Recipe
+	err = json.Unmarshal(rawBytes, &initialRecipes)
+	if err != nil {
+		fmt.Printf("Error unmarshal JSON resep mentah: %v\n", err)
+		return
+	}
+	fmt.Printf("Berhasil memuat %d resep mentah.\n", len(initialRecipes))
+
+	// Kumpulkan semua elemen unik dari data mentah
+	initialElementsSet := make(map[string]bool)
+	for _, base := range baseElements {
+		initialElementsSet[base] = true
+	}
+	for _, recipe := range initialRecipes {
+		initialElementsSet[recipe.Result] = true
+		initialElementsSet[recipe.Ingredient1] = true
+		initialElementsSet[recipe.Ingredient2] = true
+	}
+	fmt.Printf("Jumlah elemen unik awal (termasuk dasar): %d\n", len(initialElementsSet))
+
+
+	// Profil "default" (atau versi yang ditimpa di filter_profiles.json):
+	// ketercapaian dan validitas tier, diulang sampai stabil
+	config, err := LoadConfig(filepath.Join(baseDir, ConfigFile), baseElements)
+	if err != nil {
+		fmt.Printf("Error memuat profil filter: %v\n", err)
+		return
+	}
+	profile := config.Profile(DefaultProfile)
+	fmt.Printf("\n--- Menjalankan profil filter '%s' ---\n", profile.Name)
+	result, err := profile.Run(initialRecipes, baseElements)
+	if err != nil {
+		fmt.Printf("Error menjalankan profil filter '%s': %v\n", profile.Name, err)
+		return
+	}
+	for _, removal := range result.Removals {
+		fmt.Printf("Rule %s: %d resep dihapus.\n", removal.Rule, removal.Removed)
+	}
+	fmt.Printf("Filter konvergen setelah %d putaran. Sisa: %d resep.\n", result.Rounds, len(result.Recipes))
+	allRemovedRecipesTracker := result.Reasons
+	finalValidRecipes := result.Recipes
+
+	if len(allRemovedRecipesTracker) > 0 {
+		fmt.Printf("\n--- Daftar Resep yang Dihapus (%d total dari semua tahap) ---\n", len(allRemovedRecipesTracker))
+		var sortedRemovedIDs []string
+		for id := range allRemovedRecipesTracker {
+			sortedRemovedIDs = append(sortedRemovedIDs, id)
+		}
+		sort.Strings(sortedRemovedIDs)
+		for _, id := range sortedRemovedIDs {
+			fmt.Printf("  %s (Alasan: %s)\n", id, allRemovedRecipesTracker[id])
+		}
+	} else {
+		fmt.Println("\nTidak ada resep yang dihapus selama proses filter.")
+	}
+
+	finalValidElementsSet := make(map[string]bool)
+	for _, base := range baseElements {
+		finalValidElementsSet[base] = true
+	}
+	for _, recipe := range finalValidRecipes {
+		finalValidElementsSet[recipe.Result] = true
+		finalValidElementsSet[recipe.Ingredient1] = true
+		finalValidElementsSet[recipe.Ingredient2] = true
+	}
+	fmt.Printf("\nJumlah elemen unik yang valid setelah semua filter: %d\n", len(finalValidElementsSet))
+
+	// --- TAMBAHAN: Identifikasi dan cetak elemen yang dihilangkan ---
+	var removedElementsList []string
+	for initialEl := range initialElementsSet {
+		if !finalValidElementsSet[initialEl] {
+			removedElementsList = append(removedElementsList, initialEl)
+		}
+	}
+	sort.Strings(removedElementsList) // Urutkan untuk output yang konsisten
+
+	if len(removedElementsList) > 0 {
+		fmt.Printf("\n--- Daftar Elemen yang Dihilangkan (%d total) ---\n", len(removedElementsList))
+		for i, el := range removedElementsList {
+			fmt.Printf("  %d. %s\n", i+1, el)
+		}
+	} else {
+		fmt.Println("\nTidak ada elemen yang dihilangkan (semua elemen awal masih valid atau merupakan bagian dari resep valid).")
+	}
+	// --- AKHIR TAMBAHAN ---
+
+
+	filteredBytes, err := json.MarshalIndent(finalValidRecipes, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshal JSON resep terfilter akhir: %v\n", err)
+		return
+	}
+	err = os.WriteFile(filteredRecipeFile, filteredBytes, 0644)
+	if err != nil {
+		fmt.Printf("Error menulis JSON resep terfilter akhir ke file '%s': %v\n", filteredRecipeFile, err)
+		return
+	}
+
+	fmt.Printf("\nProses filter keseluruhan selesai. %d resep valid disimpan ke '%s'.\n", len(finalValidRecipes), filteredRecipeFile)
+}
+
+// filterUnmakeablePaths membuang resep yang salah satu bahannya tidak bisa
+// dibuat dari elemen dasar (lihat dataset.MakeableElements). Resep yang
+// dibuang dikembalikan sekali per dataset.RecipeID, dengan urutan kemunculan.
+func filterUnmakeablePaths(recipesToFilter []dataset.Recipe, baseElements []string) ([]dataset.Recipe, []dataset.Recipe) {
+	makeableElements := dataset.MakeableElements(recipesToFilter, baseElements)
+
+	var validRecipes []dataset.Recipe
+	var removedInThisCall []dataset.Recipe
+	validIDs := make(map[string]bool)
+	for _, recipe := range recipesToFilter {
+		if makeableElements[recipe.Ingredient1] && makeableElements[recipe.Ingredient2] {
+			validRecipes = append(validRecipes, recipe)
+			validIDs[dataset.RecipeID(recipe)] = true
+		}
+	}
+	removedIDs := make(map[string]bool)
+	for _, recipe := range recipesToFilter {
+		id := dataset.RecipeID(recipe)
+		if !validIDs[id] && !removedIDs[id] {
+			removedIDs[id] = true
+			removedInThisCall = append(removedInThisCall, recipe)
+		}
+	}
+	return validRecipes, removedInThisCall
+}
+
+// filterByTierLogic (sama seperti versi sebelumnya)
+func filterByTierLogic(recipes []dataset.Recipe, tiers map[string]int) ([]dataset.Recipe, []dataset.Recipe) {
+	var validRecipes []dataset.Recipe
+	var removedRecipes []dataset.Recipe
+	
+	maxKnownTier := 0
+    for _, t := range tiers { if t > maxKnownTier { maxKnownTier = t } }
+    defaultTierForUnknown := maxKnownTier + 2
+
+	for _, recipe := range recipes {
+		tierResult, okR := tiers[recipe.Result]
+		if !okR { tierResult = defaultTierForUnknown }
+		tierIng1, okI1 := tiers[recipe.Ingredient1]
+		if !okI1 { tierIng1 = defaultTierForUnknown }
+		tierIng2, okI2 := tiers[recipe.Ingredient2]
+		if !okI2 { tierIng2 = defaultTierForUnknown }
+
+		if tierIng1 > tierResult || tierIng2 > tierResult {
+			removedRecipes = append(removedRecipes, recipe)
+		} else {
+			validRecipes = append(validRecipes, recipe)
+		}
+	}
+	return validRecipes, removedRecipes
+}
@@ -0,0 +1,116 @@
+// src/backend/filter/filter_test.go
+package filter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+// loadFilteredDataset memuat recipes_final_filtered.json bawaan.
+func loadFilteredDataset(t *testing.T) *dataset.Dataset {
+	t.Helper()
+	d, _, err := dataset.LoadFile(filepath.Join("..", "data", dataset.FilteredRecipeFile), dataset.BaseElements)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func loadScrapedRecipes(t *testing.T) []dataset.Recipe {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("..", "data", ScrapedRecipeFile))
+	if err != nil {
+		t.Skipf("data mentah tidak tersedia: %v", err)
+	}
+	var recipes []dataset.Recipe
+	if err := json.Unmarshal(raw, &recipes); err != nil {
+		t.Fatal(err)
+	}
+	return recipes
+}
+
+// naiveTiers adalah definisi tier secara langsung: relaksasi berulang atas
+// semua resep sampai tidak ada perubahan.
+func naiveTiers(recipes []dataset.Recipe) map[string]int {
+	tiers := make(map[string]int)
+	for _, base := range dataset.BaseElements {
+		tiers[base] = 0
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, r := range recipes {
+			t1, ok1 := tiers[r.Ingredient1]
+			t2, ok2 := tiers[r.Ingredient2]
+			if !ok1 || !ok2 || slices.Contains(dataset.BaseElements, r.Result) {
+				continue
+			}
+			if current, ok := tiers[r.Result]; !ok || 1+max(t1, t2) < current {
+				tiers[r.Result] = 1 + max(t1, t2)
+				changed = true
+			}
+		}
+	}
+	return tiers
+}
+
+func TestElementTiersMatchFixpoint(t *testing.T) {
+	filtered := loadFilteredDataset(t)
+	for name, recipes := range map[string][]dataset.Recipe{
+		"mentah":      loadScrapedRecipes(t),
+		"terfilter":   filtered.Recipes(),
+		"tanpa Metal": filtered.Filter(func(r dataset.Recipe) bool { return r.Result != "Metal" }).Recipes(),
+	} {
+		tiers, involved := dataset.ElementTiers(recipes, dataset.BaseElements)
+		want := naiveTiers(recipes)
+		for el := range involved {
+			wantTier, ok := want[el]
+			if !ok {
+				wantTier = len(recipes) + 2
+			}
+			if tiers[el] != wantTier {
+				t.Errorf("%s: tier %s = %d, ingin %d", name, el, tiers[el], wantTier)
+			}
+		}
+	}
+}
+
+func TestFilterUnmakeablePaths(t *testing.T) {
+	recipes := loadScrapedRecipes(t)
+	kept, removed := filterUnmakeablePaths(recipes, dataset.BaseElements)
+	tiers := naiveTiers(recipes)
+
+	keptIDs := make(map[string]bool)
+	for _, r := range kept {
+		keptIDs[dataset.RecipeID(r)] = true
+		_, ok1 := tiers[r.Ingredient1]
+		_, ok2 := tiers[r.Ingredient2]
+		if !ok1 || !ok2 {
+			t.Errorf("resep %s dipertahankan, bahan tidak bisa dibuat", dataset.RecipeID(r))
+		}
+	}
+	removedIDs := make(map[string]bool)
+	for _, r := range removed {
+		id := dataset.RecipeID(r)
+		if keptIDs[id] || removedIDs[id] {
+			t.Errorf("resep %s dibuang dua kali atau juga dipertahankan", id)
+		}
+		removedIDs[id] = true
+	}
+	for _, r := range recipes {
+		if !keptIDs[dataset.RecipeID(r)] && !removedIDs[dataset.RecipeID(r)] {
+			t.Errorf("resep %s hilang dari kedua hasil", dataset.RecipeID(r))
+		}
+	}
+
+	// Sudah titik tetap: filter ulang tidak membuang apa pun
+	again, removedAgain := filterUnmakeablePaths(kept, dataset.BaseElements)
+	if !reflect.DeepEqual(again, kept) || len(removedAgain) != 0 {
+		t.Errorf("filter ulang membuang %d resep", len(removedAgain))
+	}
+}
@@ -0,0 +1,294 @@
+// src/backend/filter/pipeline.go
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"tubes2stima/backend/dataset"
+)
+
+// ConfigFile adalah nama file profil filter (opsional) di direktori data.
+const ConfigFile = "filter_profiles.json"
+
+// ScrapedRecipeFile adalah hasil scraping mentah, sumber default profil filter.
+const ScrapedRecipeFile = "recipes_scraped.json"
+
+// DefaultProfile adalah profil yang dipakai Run untuk menghasilkan
+// recipes_final_filtered.json.
+const DefaultProfile = "default"
+
+// RuleConfig adalah satu langkah pipeline filter di file konfigurasi.
+type RuleConfig struct {
+	Rule     string   `json:"rule"`
+	Elements []string `json:"elements,omitempty"` // Untuk drop_elements
+}
+
+// Profile adalah pipeline filter bernama: daftar rule yang dijalankan
+// berurutan terhadap resep dari Source.
+type Profile struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Source      string       `json:"source,omitempty"` // "scraped" (default) atau "filtered"
+	Rules       []RuleConfig `json:"rules"`
+	// UntilStable: ulangi seluruh rule sampai satu putaran tidak membuang resep
+	UntilStable bool `json:"untilStable,omitempty"`
+}
+
+// Config adalah isi file filter_profiles.json.
+type Config struct {
+	Profiles []Profile `json:"profiles"`
+}
+
+// RuleRemoval adalah jumlah resep yang dibuang satu rule (total semua putaran).
+type RuleRemoval struct {
+	Rule    string `json:"rule"`
+	Removed int    `json:"removed"`
+}
+
+// Result adalah hasil menjalankan satu profil.
+type Result struct {
+	Profile  string
+	Recipes  []dataset.Recipe
+	Removals []RuleRemoval     // Sejajar dengan Rules profil
+	Reasons  map[string]string // dataset.RecipeID -> alasan rule pertama yang membuangnya
+	Rounds   int
+}
+
+// filterRule adalah satu langkah pipeline yang sudah dikonfigurasi. apply
+// mengembalikan resep yang dipertahankan (urutan asli) dan alasan untuk
+// setiap resep yang dibuang.
+type filterRule struct {
+	name  string
+	apply func(recipes []dataset.Recipe) (kept []dataset.Recipe, removed []dataset.Recipe, reason func(r dataset.Recipe) string)
+}
+
+// ruleContext adalah data edisi game yang dipakai rule saat dibangun dan
+// dijalankan.
+type ruleContext struct {
+	baseElements []string
+}
+
+// ruleBuilders mendaftar semua rule yang bisa dipakai di profil.
+var ruleBuilders = map[string]func(cfg RuleConfig, ctx ruleContext) (filterRule, error){
+	// reachable: buang resep yang bahannya tidak bisa dibuat dari elemen dasar
+	"reachable": func(cfg RuleConfig, ctx ruleContext) (filterRule, error) {
+		return filterRule{name: cfg.Rule, apply: func(recipes []dataset.Recipe) ([]dataset.Recipe, []dataset.Recipe, func(dataset.Recipe) string) {
+			kept, removed := filterUnmakeablePaths(recipes, ctx.baseElements)
+			return kept, removed, func(dataset.Recipe) string { return "Tidak tercapai dari elemen dasar" }
+		}}, nil
+	},
+	// tier_validity: buang resep yang tier bahannya lebih tinggi dari tier hasil
+	"tier_validity": func(cfg RuleConfig, ctx ruleContext) (filterRule, error) {
+		return filterRule{name: cfg.Rule, apply: func(recipes []dataset.Recipe) ([]dataset.Recipe, []dataset.Recipe, func(dataset.Recipe) string) {
+			tiers, _ := dataset.ElementTiers(recipes, ctx.baseElements)
+			kept, removed := filterByTierLogic(recipes, tiers)
+			return kept, removed, func(r dataset.Recipe) string {
+				return fmt.Sprintf("Tier tidak valid (H:%d, B1:%d, B2:%d)", tiers[r.Result], tiers[r.Ingredient1], tiers[r.Ingredient2])
+			}
+		}}, nil
+	},
+	// drop_self_combinations: buang resep A + A
+	"drop_self_combinations": func(cfg RuleConfig, ctx ruleContext) (filterRule, error) {
+		return keepRecipesRule(cfg.Rule, "Menggabungkan elemen dengan dirinya sendiri", func(r dataset.Recipe) bool {
+			return r.Ingredient1 != r.Ingredient2
+		}), nil
+	},
+	// drop_elements: buang resep yang memakai atau menghasilkan elemen di Elements
+	"drop_elements": func(cfg RuleConfig, ctx ruleContext) (filterRule, error) {
+		if len(cfg.Elements) == 0 {
+			return filterRule{}, errors.New("rule 'drop_elements' membutuhkan daftar 'elements'")
+		}
+		dropped := make(map[string]bool, len(cfg.Elements))
+		for _, el := range cfg.Elements {
+			if slices.Contains(ctx.baseElements, el) {
+				return filterRule{}, fmt.Errorf("rule 'drop_elements' tidak boleh membuang elemen dasar '%s'", el)
+			}
+			dropped[el] = true
+		}
+		return keepRecipesRule(cfg.Rule, "Memakai elemen yang dibuang profil", func(r dataset.Recipe) bool {
+			return !dropped[r.Result] && !dropped[r.Ingredient1] && !dropped[r.Ingredient2]
+		}), nil
+	},
+	// dedupe: buang resep yang sama menurut dataset.RecipeID (kemunculan pertama dipertahankan)
+	"dedupe": func(cfg RuleConfig, ctx ruleContext) (filterRule, error) {
+		return filterRule{name: cfg.Rule, apply: func(recipes []dataset.Recipe) ([]dataset.Recipe, []dataset.Recipe, func(dataset.Recipe) string) {
+			seen := make(map[string]bool, len(recipes))
+			var kept, removed []dataset.Recipe
+			for _, r := range recipes {
+				if id := dataset.RecipeID(r); !seen[id] {
+					seen[id] = true
+					kept = append(kept, r)
+				} else {
+					removed = append(removed, r)
+				}
+			}
+			return kept, removed, func(dataset.Recipe) string { return "Duplikat" }
+		}}, nil
+	},
+}
+
+// keepRecipesRule membuat rule sederhana yang mempertahankan resep dengan keep(r) true.
+func keepRecipesRule(name, reason string, keep func(r dataset.Recipe) bool) filterRule {
+	return filterRule{name: name, apply: func(recipes []dataset.Recipe) ([]dataset.Recipe, []dataset.Recipe, func(dataset.Recipe) string) {
+		var kept, removed []dataset.Recipe
+		for _, r := range recipes {
+			if keep(r) {
+				kept = append(kept, r)
+			} else {
+				removed = append(removed, r)
+			}
+		}
+		return kept, removed, func(dataset.Recipe) string { return reason }
+	}}
+}
+
+// builtinProfiles dipakai jika file konfigurasi tidak ada, dan bisa
+// ditimpa profil bernama sama di file. Profil default sama persis dengan
+// tahapan Run sebelumnya: ketercapaian lalu validitas tier, diulang
+// sampai stabil.
+func builtinProfiles() []Profile {
+	return []Profile{
+		{
+			Name:        DefaultProfile,
+			Description: "Resep yang tercapai dari elemen dasar dengan tier valid (recipes_final_filtered.json)",
+			Rules:       []RuleConfig{{Rule: "reachable"}, {Rule: "tier_validity"}},
+			UntilStable: true,
+		},
+		{
+			Name:        "raw",
+			Description: "Hasil scraping apa adanya",
+			Rules:       []RuleConfig{},
+		},
+	}
+}
+
+// LoadConfig membaca profil filter dari filePath dan menggabungkannya
+// dengan profil bawaan. Rule divalidasi terhadap elemen dasar baseElements.
+// File yang tidak ada tidak dianggap error.
+func LoadConfig(filePath string, baseElements []string) (Config, error) {
+	config := Config{Profiles: builtinProfiles()}
+	bytes, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("gagal membaca file %s: %w", filePath, err)
+	}
+	var fileConfig Config
+	if err := json.Unmarshal(bytes, &fileConfig); err != nil {
+		return Config{}, fmt.Errorf("gagal unmarshal JSON profil filter dari %s: %w", filePath, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, profile := range fileConfig.Profiles {
+		if profile.Name == "" {
+			return Config{}, fmt.Errorf("profil filter di %s tidak punya nama", filePath)
+		}
+		if seen[profile.Name] {
+			return Config{}, fmt.Errorf("profil filter '%s' didefinisikan dua kali di %s", profile.Name, filePath)
+		}
+		seen[profile.Name] = true
+		if _, err := profile.compile(ruleContext{baseElements: baseElements}); err != nil {
+			return Config{}, fmt.Errorf("profil filter '%s' di %s tidak valid: %w", profile.Name, filePath, err)
+		}
+		if existing := config.Profile(profile.Name); existing != nil {
+			*existing = profile
+		} else {
+			config.Profiles = append(config.Profiles, profile)
+		}
+	}
+	return config, nil
+}
+
+// Profile mengembalikan profil bernama name, atau nil jika tidak ada.
+func (c *Config) Profile(name string) *Profile {
+	for i := range c.Profiles {
+		if c.Profiles[i].Name == name {
+			return &c.Profiles[i]
+		}
+	}
+	return nil
+}
+
+// compile memvalidasi profil dan membangun rule-nya.
+func (p Profile) compile(ctx ruleContext) ([]filterRule, error) {
+	if p.Source != "" && p.Source != "scraped" && p.Source != "filtered" {
+		return nil, fmt.Errorf("source '%s' tidak dikenal (gunakan 'scraped' atau 'filtered')", p.Source)
+	}
+	rules := make([]filterRule, 0, len(p.Rules))
+	for _, cfg := range p.Rules {
+		build, ok := ruleBuilders[cfg.Rule]
+		if !ok {
+			return nil, fmt.Errorf("rule '%s' tidak dikenal (tersedia: %s)", cfg.Rule, strings.Join(ruleNames(), ", "))
+		}
+		rule, err := build(cfg, ctx)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func ruleNames() []string {
+	names := make([]string, 0, len(ruleBuilders))
+	for name := range ruleBuilders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run menjalankan semua rule profil terhadap recipes dengan elemen dasar
+// baseElements. Dengan UntilStable, putaran diulang sampai tidak ada resep
+// yang dibuang; karena setiap putaran hanya bisa membuang resep, perulangan
+// pasti berhenti.
+func (p Profile) Run(recipes []dataset.Recipe, baseElements []string) (Result, error) {
+	rules, err := p.compile(ruleContext{baseElements: baseElements})
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{
+		Profile:  p.Name,
+		Recipes:  recipes,
+		Removals: make([]RuleRemoval, len(rules)),
+		Reasons:  make(map[string]string),
+	}
+	for i, rule := range rules {
+		result.Removals[i].Rule = rule.name
+	}
+
+	for {
+		result.Rounds++
+		removedThisRound := 0
+		for i, rule := range rules {
+			kept, removed, reason := rule.apply(result.Recipes)
+			result.Recipes = kept
+			result.Removals[i].Removed += len(removed)
+			removedThisRound += len(removed)
+			for _, r := range removed {
+				id := dataset.RecipeID(r)
+				if _, exists := result.Reasons[id]; exists {
+					continue
+				}
+				result.Reasons[id] = reason(r)
+				if result.Rounds > 1 {
+					result.Reasons[id] += fmt.Sprintf(" (putaran %d)", result.Rounds)
+				}
+			}
+		}
+		if !p.UntilStable || removedThisRound == 0 {
+			break
+		}
+	}
+	if result.Recipes == nil {
+		result.Recipes = []dataset.Recipe{}
+	}
+	return result, nil
+}
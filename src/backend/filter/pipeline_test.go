@@ -0,0 +1,111 @@
+// src/backend/filter/pipeline_test.go
+package filter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+func TestDefaultProfileReproducesFilteredFile(t *testing.T) {
+	config, err := LoadConfig(filepath.Join("..", "data", ConfigFile), dataset.BaseElements)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := config.Profile(DefaultProfile).Run(loadScrapedRecipes(t), dataset.BaseElements)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result.Recipes, filteredRecipesInFileOrder(t)) {
+		t.Errorf("profil default menghasilkan %d resep, berbeda dengan recipes_final_filtered.json", len(result.Recipes))
+	}
+	removed := 0
+	for _, removal := range result.Removals {
+		removed += removal.Removed
+	}
+	if removed != len(result.Reasons) || result.Rounds < 2 {
+		t.Errorf("%d resep dibuang, %d alasan, %d putaran", removed, len(result.Reasons), result.Rounds)
+	}
+}
+
+// filteredRecipesInFileOrder membaca recipes_final_filtered.json apa adanya
+// (Dataset.Recipes mengurutkan per hasil).
+func filteredRecipesInFileOrder(t *testing.T) []dataset.Recipe {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("..", "data", dataset.FilteredRecipeFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recipes []dataset.Recipe
+	if err := json.Unmarshal(raw, &recipes); err != nil {
+		t.Fatal(err)
+	}
+	return recipes
+}
+
+func TestFilterProfileRules(t *testing.T) {
+	recipes := []dataset.Recipe{
+		{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"},
+		{Result: "Mud", Ingredient1: "Earth", Ingredient2: "Water"},
+		{Result: "Energy", Ingredient1: "Fire", Ingredient2: "Fire"},
+		{Result: "Golem", Ingredient1: "Mud", Ingredient2: "Cyclops"},
+	}
+	profile := Profile{
+		Name: "uji",
+		Rules: []RuleConfig{
+			{Rule: "dedupe"},
+			{Rule: "drop_self_combinations"},
+			{Rule: "drop_elements", Elements: []string{"Cyclops"}},
+		},
+	}
+	result, err := profile.Run(recipes, dataset.BaseElements)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Recipes) != 1 || result.Recipes[0] != recipes[0] {
+		t.Errorf("resep tersisa = %v", result.Recipes)
+	}
+	want := []RuleRemoval{{"dedupe", 1}, {"drop_self_combinations", 1}, {"drop_elements", 1}}
+	if !reflect.DeepEqual(result.Removals, want) {
+		t.Errorf("removals = %v, ingin %v", result.Removals, want)
+	}
+
+	for _, bad := range []Profile{
+		{Name: "x", Rules: []RuleConfig{{Rule: "tidak-ada"}}},
+		{Name: "x", Rules: []RuleConfig{{Rule: "drop_elements"}}},
+		{Name: "x", Rules: []RuleConfig{{Rule: "drop_elements", Elements: []string{"Fire"}}}},
+		{Name: "x", Source: "wiki"},
+	} {
+		if _, err := bad.Run(recipes, dataset.BaseElements); err == nil {
+			t.Errorf("profil %+v seharusnya ditolak", bad)
+		}
+	}
+}
+
+func TestFilterProfileUsesGivenBaseElements(t *testing.T) {
+	recipes := []dataset.Recipe{
+		{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"},
+		{Result: "Steam", Ingredient1: "Water", Ingredient2: "Fire"},
+		{Result: "Fire", Ingredient1: "Lava", Ingredient2: "Air"},
+	}
+	profile := Profile{Name: "uji", Rules: []RuleConfig{{Rule: "reachable"}}, UntilStable: true}
+
+	// Tanpa Fire dan Lava di elemen dasar, Steam dan Fire tidak tercapai
+	result, err := profile.Run(recipes, []string{"Water", "Earth", "Air"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Recipes) != 1 || result.Recipes[0].Result != "Mud" {
+		t.Errorf("resep tersisa = %v", result.Recipes)
+	}
+
+	// Fire boleh dibuang drop_elements jika bukan elemen dasar edisi
+	dropFire := Profile{Name: "uji", Rules: []RuleConfig{{Rule: "drop_elements", Elements: []string{"Fire"}}}}
+	if _, err := dropFire.Run(recipes, []string{"Water", "Earth", "Air"}); err != nil {
+		t.Errorf("drop_elements Fire ditolak: %v", err)
+	}
+}
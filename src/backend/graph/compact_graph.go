@@ -0,0 +1,290 @@
+// src/backend/graph/compact_graph.go
+
+// Package graph berisi representasi graf resep yang ringkas (CompactGraph)
+// dan analisis graf di atasnya: ancestors/descendants dan elemen wajib.
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+
+	"tubes2stima/backend/dataset"
+)
+
+// NoElement menandai "tidak ada elemen / tidak ada resep" pada array berbasis ID.
+const NoElement = ^uint32(0)
+
+// ErrNotInitialized dikembalikan analisis graf jika dataset belum dibangun (nil).
+var ErrNotInitialized = errors.New("compact graph not initialized")
+
+// CompactRecipe adalah resep dalam bentuk ID. Urutan bahan dipertahankan
+// seperti data aslinya supaya konversi balik ke Recipe tidak mengubah output.
+type CompactRecipe struct {
+	Result uint32
+	Ing1   uint32
+	Ing2   uint32
+}
+
+// Other mengembalikan bahan pasangan dari el di dalam resep r.
+func (r CompactRecipe) Other(el uint32) uint32 {
+	if r.Ing1 == el {
+		return r.Ing2
+	}
+	return r.Ing1
+}
+
+// CompactGraph adalah representasi graf resep dengan elemen ter-intern menjadi
+// ID uint32 yang padat. ID diberikan berdasarkan urutan alfabetis nama, sehingga
+// membandingkan ID sama dengan membandingkan nama (penting agar urutan
+// tie-break algoritma tetap sama dengan versi berbasis string).
+//
+// Resep disimpan sekali di recipes, lalu diindeks dalam format CSR:
+//   - byResult[byResultOff[e]:byResultOff[e+1]] = resep yang menghasilkan e
+//     (urutan sama dengan recipeMap[e])
+//   - byIng[byIngOff[e]:byIngOff[e+1]] = resep yang memakai e sebagai bahan,
+//     diurutkan berdasarkan (bahan pasangan, hasil)
+type CompactGraph struct {
+	names   []string
+	ids     map[string]uint32
+	base    Bitset
+	baseIDs []uint32
+
+	recipes     []CompactRecipe
+	byResultOff []uint32
+	byResult    []uint32
+	byIngOff    []uint32
+	byIng       []uint32
+}
+
+// New membangun CompactGraph dari map resep (format Dataset.RecipeMap) dan
+// elemen dasarnya (Dataset.BaseElements).
+func New(inputRecipeMap map[string][]dataset.Recipe, baseElements []string) *CompactGraph {
+	nameSet := make(map[string]bool)
+	for _, base := range baseElements {
+		nameSet[base] = true
+	}
+	results := make([]string, 0, len(inputRecipeMap))
+	for result, recipes := range inputRecipeMap {
+		results = append(results, result)
+		for _, r := range recipes {
+			nameSet[r.Result] = true
+			nameSet[r.Ingredient1] = true
+			nameSet[r.Ingredient2] = true
+		}
+	}
+	sort.Strings(results)
+
+	g := &CompactGraph{
+		names: make([]string, 0, len(nameSet)),
+		ids:   make(map[string]uint32, len(nameSet)),
+	}
+	for name := range nameSet {
+		g.names = append(g.names, name)
+	}
+	sort.Strings(g.names)
+	for i, name := range g.names {
+		g.ids[name] = uint32(i)
+	}
+	n := len(g.names)
+
+	g.base = NewBitset(n)
+	for _, base := range baseElements {
+		g.base.Set(g.ids[base])
+	}
+	for id := 0; id < n; id++ {
+		if g.base.Has(uint32(id)) {
+			g.baseIDs = append(g.baseIDs, uint32(id))
+		}
+	}
+
+	// Resep dikumpulkan per hasil (urut nama hasil, lalu urutan asli per hasil)
+	for _, result := range results {
+		for _, r := range inputRecipeMap[result] {
+			g.recipes = append(g.recipes, CompactRecipe{
+				Result: g.ids[r.Result],
+				Ing1:   g.ids[r.Ingredient1],
+				Ing2:   g.ids[r.Ingredient2],
+			})
+		}
+	}
+
+	// CSR berdasarkan hasil
+	resultCount := make([]uint32, n+1)
+	for _, r := range g.recipes {
+		resultCount[r.Result+1]++
+	}
+	for i := 1; i <= n; i++ {
+		resultCount[i] += resultCount[i-1]
+	}
+	g.byResultOff = resultCount
+	g.byResult = make([]uint32, len(g.recipes))
+	fill := make([]uint32, n)
+	copy(fill, g.byResultOff[:n])
+	for i, r := range g.recipes {
+		g.byResult[fill[r.Result]] = uint32(i)
+		fill[r.Result]++
+	}
+
+	// CSR berdasarkan bahan (resep dengan dua bahan sama hanya dicatat sekali)
+	ingCount := make([]uint32, n+1)
+	for _, r := range g.recipes {
+		ingCount[r.Ing1+1]++
+		if r.Ing2 != r.Ing1 {
+			ingCount[r.Ing2+1]++
+		}
+	}
+	for i := 1; i <= n; i++ {
+		ingCount[i] += ingCount[i-1]
+	}
+	g.byIngOff = ingCount
+	g.byIng = make([]uint32, ingCount[n])
+	copy(fill, g.byIngOff[:n])
+	for i, r := range g.recipes {
+		g.byIng[fill[r.Ing1]] = uint32(i)
+		fill[r.Ing1]++
+		if r.Ing2 != r.Ing1 {
+			g.byIng[fill[r.Ing2]] = uint32(i)
+			fill[r.Ing2]++
+		}
+	}
+	for el := 0; el < n; el++ {
+		list := g.byIng[g.byIngOff[el]:g.byIngOff[el+1]]
+		sort.SliceStable(list, func(i, j int) bool {
+			ri, rj := g.recipes[list[i]], g.recipes[list[j]]
+			oi, oj := ri.Other(uint32(el)), rj.Other(uint32(el))
+			if oi != oj {
+				return oi < oj
+			}
+			return ri.Result < rj.Result
+		})
+	}
+
+	return g
+}
+
+// compactKey adalah key graf kompak di cache Dataset.
+type compactKey struct{}
+
+// Of mengembalikan graf kompak untuk dataset d (nil jika d nil). Graf
+// dibangun sekali per dataset lalu disimpan di cache dataset.
+func Of(d *dataset.Dataset) *CompactGraph {
+	if d == nil {
+		return nil
+	}
+	return d.Cached(compactKey{}, func() any { return New(d.RecipeMap(), d.BaseElements()) }).(*CompactGraph)
+}
+
+// NumElements mengembalikan jumlah elemen yang ter-intern.
+func (g *CompactGraph) NumElements() int {
+	return len(g.names)
+}
+
+// ID mengembalikan ID elemen untuk nama tertentu.
+func (g *CompactGraph) ID(name string) (uint32, bool) {
+	id, ok := g.ids[name]
+	return id, ok
+}
+
+// Name mengembalikan nama elemen untuk ID tertentu.
+func (g *CompactGraph) Name(id uint32) string {
+	return g.names[id]
+}
+
+// IsBase memeriksa apakah ID merupakan elemen dasar.
+func (g *CompactGraph) IsBase(id uint32) bool {
+	return g.base.Has(id)
+}
+
+// BaseIDs mengembalikan ID elemen dasar secara terurut (sama dengan urutan
+// nama). Slice tidak boleh diubah pemanggil.
+func (g *CompactGraph) BaseIDs() []uint32 {
+	return g.baseIDs
+}
+
+// NumRecipes mengembalikan jumlah resep.
+func (g *CompactGraph) NumRecipes() int {
+	return len(g.recipes)
+}
+
+// Recipe mengembalikan resep kompak dengan indeks ri.
+func (g *CompactGraph) Recipe(ri uint32) CompactRecipe {
+	return g.recipes[ri]
+}
+
+// BaseSet mengembalikan salinan himpunan elemen dasar.
+func (g *CompactGraph) BaseSet() Bitset {
+	return g.base.Clone()
+}
+
+// RecipesFor mengembalikan indeks resep yang menghasilkan el.
+func (g *CompactGraph) RecipesFor(el uint32) []uint32 {
+	return g.byResult[g.byResultOff[el]:g.byResultOff[el+1]]
+}
+
+// RecipesUsing mengembalikan indeks resep yang memakai el sebagai bahan.
+func (g *CompactGraph) RecipesUsing(el uint32) []uint32 {
+	return g.byIng[g.byIngOff[el]:g.byIngOff[el+1]]
+}
+
+// ToRecipe mengubah resep kompak kembali menjadi dataset.Recipe.
+func (g *CompactGraph) ToRecipe(ri uint32) dataset.Recipe {
+	r := g.recipes[ri]
+	return dataset.Recipe{Result: g.names[r.Result], Ingredient1: g.names[r.Ing1], Ingredient2: g.names[r.Ing2]}
+}
+
+// ToPath mengubah daftar indeks resep menjadi jalur []dataset.Recipe.
+func (g *CompactGraph) ToPath(recipeIdx []uint32) []dataset.Recipe {
+	path := make([]dataset.Recipe, 0, len(recipeIdx))
+	for _, ri := range recipeIdx {
+		path = append(path, g.ToRecipe(ri))
+	}
+	return path
+}
+
+func (g *CompactGraph) String() string {
+	return fmt.Sprintf("CompactGraph{elemen: %d, resep: %d}", len(g.names), len(g.recipes))
+}
+
+// --- Bitset ---
+
+// Bitset adalah himpunan ID elemen berbasis bit, pengganti map[string]bool
+// untuk visited/discovered/available di algoritma versi kompak.
+type Bitset []uint64
+
+// NewBitset membuat Bitset kosong untuk ID 0..n-1.
+func NewBitset(n int) Bitset {
+	return make(Bitset, (n+63)/64)
+}
+
+// Has memeriksa apakah i ada di himpunan.
+func (b Bitset) Has(i uint32) bool {
+	return b[i>>6]&(1<<(i&63)) != 0
+}
+
+// Set menambahkan i ke himpunan.
+func (b Bitset) Set(i uint32) {
+	b[i>>6] |= 1 << (i & 63)
+}
+
+// Clear menghapus i dari himpunan.
+func (b Bitset) Clear(i uint32) {
+	b[i>>6] &^= 1 << (i & 63)
+}
+
+// Clone mengembalikan salinan himpunan.
+func (b Bitset) Clone() Bitset {
+	c := make(Bitset, len(b))
+	copy(c, b)
+	return c
+}
+
+// Count mengembalikan jumlah anggota himpunan.
+func (b Bitset) Count() int {
+	total := 0
+	for _, w := range b {
+		total += bits.OnesCount64(w)
+	}
+	return total
+}
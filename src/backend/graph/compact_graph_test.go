@@ -0,0 +1,53 @@
+// src/backend/graph/compact_graph_test.go
+package graph
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+// testDataset dimuat sekali dari data bawaan la2.
+var testDataset *dataset.Dataset
+
+func TestMain(m *testing.M) {
+	var err error
+	testDataset, _, err = dataset.LoadFile(filepath.Join("..", "data", dataset.FilteredRecipeFile), dataset.BaseElements)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gagal memuat data untuk test: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+func TestCompactGraphMirrorsRecipeMap(t *testing.T) {
+	g := Of(testDataset)
+	total := 0
+	for result, recipes := range testDataset.RecipeMap() {
+		id, ok := g.ID(result)
+		if !ok {
+			t.Fatalf("elemen %q tidak ter-intern", result)
+		}
+		got := g.RecipesFor(id)
+		if len(got) != len(recipes) {
+			t.Fatalf("%s: %d resep kompak, %d resep asli", result, len(got), len(recipes))
+		}
+		for i, ri := range got {
+			if g.ToRecipe(ri) != recipes[i] {
+				t.Fatalf("%s: resep ke-%d berbeda: %v vs %v", result, i, g.ToRecipe(ri), recipes[i])
+			}
+		}
+		total += len(recipes)
+	}
+	if total != g.NumRecipes() {
+		t.Fatalf("jumlah resep kompak %d, seharusnya %d", g.NumRecipes(), total)
+	}
+	for i := 1; i < g.NumElements(); i++ {
+		if g.Name(uint32(i-1)) >= g.Name(uint32(i)) {
+			t.Fatalf("ID tidak terurut alfabetis di %d", i)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// src/backend/graph/mandatory.go
+package graph
+
+import (
+	"fmt"
+
+	"tubes2stima/backend/dataset"
+)
+
+// mandatorySets menghitung, untuk setiap elemen X, himpunan elemen yang
+// muncul di SEMUA pohon resep X (termasuk X sendiri), sebagai titik tetap
+// terbesar dari
+//
+//	M(dasar) = {dasar}
+//	M(X)     = {X} ∪ ⋂ untuk setiap resep X = A + B: (M(A) ∪ M(B))
+//
+// Mirip dominator pada graf biasa, tetapi simpul AND (resep) memakai gabungan
+// dan simpul OR (elemen) memakai irisan. Mulai dari "semua elemen" lalu
+// dikecilkan dengan worklist sampai stabil. Hasilnya aman: setiap pohon resep
+// valid pasti memuat M(X) (induksi pada tinggi pohon). Elemen yang tidak bisa
+// dibuat tetap berisi semua elemen.
+func (g *CompactGraph) mandatorySets() []Bitset {
+	n := len(g.names)
+	sets := make([]Bitset, n)
+	full := NewBitset(n)
+	for id := 0; id < n; id++ {
+		full.Set(uint32(id))
+	}
+
+	inQueue := NewBitset(n)
+	var worklist []uint32
+	for id := 0; id < n; id++ {
+		el := uint32(id)
+		if g.base.Has(el) {
+			sets[el] = NewBitset(n)
+			sets[el].Set(el)
+			continue
+		}
+		sets[el] = full.Clone()
+		worklist = append(worklist, el)
+		inQueue.Set(el)
+	}
+
+	scratch := NewBitset(n)
+	for len(worklist) > 0 {
+		el := worklist[0]
+		worklist = worklist[1:]
+		inQueue.Clear(el)
+
+		// scratch = irisan (M(A) ∪ M(B)) untuk semua resep el
+		copy(scratch, full)
+		for _, ri := range g.RecipesFor(el) {
+			r := g.recipes[ri]
+			a, b := sets[r.Ing1], sets[r.Ing2]
+			for w := range scratch {
+				scratch[w] &= a[w] | b[w]
+			}
+		}
+		scratch.Set(el)
+
+		changed := false
+		for w := range scratch {
+			if scratch[w] != sets[el][w] {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			continue
+		}
+		copy(sets[el], scratch)
+		for _, ri := range g.RecipesUsing(el) {
+			result := g.recipes[ri].Result
+			if !inQueue.Has(result) {
+				inQueue.Set(result)
+				worklist = append(worklist, result)
+			}
+		}
+	}
+	return sets
+}
+
+// mandatoryKey adalah key hasil mandatorySets di cache Dataset.
+type mandatoryKey struct{}
+
+// MandatoryElements mengembalikan elemen (selain element sendiri, termasuk
+// elemen dasar) yang pasti dipakai di setiap pohon resep element, terurut
+// nama (urutan ID). Hasil analisis seluruh graf dihitung sekali per dataset.
+func MandatoryElements(d *dataset.Dataset, element string) ([]string, error) {
+	g := Of(d)
+	if g == nil {
+		return nil, ErrNotInitialized
+	}
+	id, ok := g.ids[element]
+	if !ok {
+		return nil, fmt.Errorf("elemen '%s' tidak ditemukan", element)
+	}
+	sets := d.Cached(mandatoryKey{}, func() any { return g.mandatorySets() }).([]Bitset)
+
+	set := sets[id]
+	if !g.base.Has(id) && set.Count() == len(g.names) {
+		return nil, fmt.Errorf("elemen '%s' tidak bisa dibuat", element)
+	}
+	var required []string
+	for other := 0; other < len(g.names); other++ {
+		if uint32(other) != id && set.Has(uint32(other)) {
+			required = append(required, g.names[other])
+		}
+	}
+	return required, nil
+}
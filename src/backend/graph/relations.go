@@ -0,0 +1,139 @@
+// src/backend/graph/relations.go
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"tubes2stima/backend/dataset"
+)
+
+// RelatedElement adalah satu elemen hasil analisis ancestors/descendants
+// beserta jarak minimumnya (dalam langkah resep) dari elemen asal.
+type RelatedElement struct {
+	Name  string `json:"name"`
+	Depth int    `json:"depth"`
+}
+
+// makeableWithout mengembalikan elemen yang bisa dibuat dari elemen dasar
+// tanpa pernah memakai atau membuat excluded.
+func (g *CompactGraph) makeableWithout(excluded uint32) Bitset {
+	n := len(g.names)
+	makeable := g.base.Clone()
+	missing := make([]uint8, len(g.recipes)) // bahan resep yang belum bisa dibuat
+	var worklist []uint32
+	for id := 0; id < n; id++ {
+		if g.base.Has(uint32(id)) {
+			worklist = append(worklist, uint32(id))
+		}
+	}
+	for i, r := range g.recipes {
+		missing[i] = 2
+		if r.Ing1 == r.Ing2 {
+			missing[i] = 1
+		}
+	}
+	for len(worklist) > 0 {
+		el := worklist[len(worklist)-1]
+		worklist = worklist[:len(worklist)-1]
+		for _, ri := range g.RecipesUsing(el) {
+			r := g.recipes[ri]
+			missing[ri]--
+			if missing[ri] > 0 || r.Result == excluded || r.Ing1 == excluded || r.Ing2 == excluded || makeable.Has(r.Result) {
+				continue
+			}
+			makeable.Set(r.Result)
+			worklist = append(worklist, r.Result)
+		}
+	}
+	return makeable
+}
+
+// Ancestors mengembalikan elemen yang bisa muncul di pohon resep element,
+// dengan kedalaman minimum (1 = bahan langsung). Penelusuran mundur lewat
+// Dataset.RecipeMap hanya memakai resep yang kedua bahannya bisa dibuat tanpa
+// element, karena pohon resep tidak boleh memuat target di bawah akarnya.
+// Untuk siklus yang lebih panjang hasilnya bisa sedikit berlebih.
+// maxDepth <= 0 berarti tanpa batas.
+func Ancestors(d *dataset.Dataset, element string, maxDepth int) ([]RelatedElement, error) {
+	g := Of(d)
+	if g == nil {
+		return nil, ErrNotInitialized
+	}
+	start, ok := g.ids[element]
+	if !ok {
+		return nil, fmt.Errorf("elemen '%s' tidak ditemukan", element)
+	}
+	makeable := g.makeableWithout(start)
+	return g.relatedBFS(start, maxDepth, func(el uint32, visit func(uint32)) {
+		for _, ri := range g.RecipesFor(el) {
+			r := g.recipes[ri]
+			if makeable.Has(r.Ing1) && makeable.Has(r.Ing2) {
+				visit(r.Ing1)
+				visit(r.Ing2)
+			}
+		}
+	}), nil
+}
+
+// Descendants mengembalikan elemen yang (secara transitif) bisa dibuat
+// dengan bantuan element lewat Dataset.Graph, dengan kedalaman minimum
+// (1 = hasil resep yang memakai element langsung). maxDepth <= 0 berarti
+// tanpa batas.
+func Descendants(d *dataset.Dataset, element string, maxDepth int) ([]RelatedElement, error) {
+	g := Of(d)
+	if g == nil {
+		return nil, ErrNotInitialized
+	}
+	start, ok := g.ids[element]
+	if !ok {
+		return nil, fmt.Errorf("elemen '%s' tidak ditemukan", element)
+	}
+	return g.relatedBFS(start, maxDepth, func(el uint32, visit func(uint32)) {
+		for _, ri := range g.RecipesUsing(el) {
+			visit(g.recipes[ri].Result)
+		}
+	}), nil
+}
+
+// relatedBFS menjalankan BFS dari start dengan fungsi tetangga neighbors dan
+// mengembalikan elemen yang dicapai (tanpa start), terurut kedalaman lalu nama.
+func (g *CompactGraph) relatedBFS(start uint32, maxDepth int, neighbors func(el uint32, visit func(uint32))) []RelatedElement {
+	seen := NewBitset(len(g.names))
+	seen.Set(start)
+	frontier := []uint32{start}
+	var related []RelatedElement
+	for level := 1; len(frontier) > 0 && (maxDepth <= 0 || level <= maxDepth); level++ {
+		var next []uint32
+		for _, el := range frontier {
+			neighbors(el, func(other uint32) {
+				if seen.Has(other) {
+					return
+				}
+				seen.Set(other)
+				next = append(next, other)
+				related = append(related, RelatedElement{Name: g.names[other], Depth: level})
+			})
+		}
+		frontier = next
+	}
+	sort.Slice(related, func(i, j int) bool {
+		if related[i].Depth != related[j].Depth {
+			return related[i].Depth < related[j].Depth
+		}
+		return related[i].Name < related[j].Name
+	})
+	return related
+}
+
+// CountsByDepth mengembalikan jumlah elemen per kedalaman (indeks 0 = kedalaman 1).
+func CountsByDepth(related []RelatedElement) []int {
+	var counts []int
+	for _, el := range related {
+		for len(counts) < el.Depth {
+			counts = append(counts, 0)
+		}
+		counts[el.Depth-1]++
+	}
+	return counts
+}
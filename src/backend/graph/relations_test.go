@@ -0,0 +1,24 @@
+// src/backend/graph/relations_test.go
+package graph
+
+import "testing"
+
+func TestRelationDepthLimit(t *testing.T) {
+	all, _ := Descendants(testDataset, "Fire", 0)
+	limited, _ := Descendants(testDataset, "Fire", 2)
+	if len(limited) == 0 || len(limited) >= len(all) {
+		t.Fatalf("descendants Fire: %d dengan depth 2, %d tanpa batas", len(limited), len(all))
+	}
+	for _, el := range limited {
+		if el.Depth > 2 {
+			t.Errorf("%s di kedalaman %d melebihi batas", el.Name, el.Depth)
+		}
+	}
+	total := 0
+	for _, c := range CountsByDepth(all) {
+		total += c
+	}
+	if total != len(all) {
+		t.Errorf("CountsByDepth berjumlah %d, ingin %d", total, len(all))
+	}
+}
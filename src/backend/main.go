@@ -2,43 +2,353 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"net/http" // Import net/http
+	"os"
+	"path/filepath"
+	"strings"
+
+	"tubes2stima/backend/api"
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/filter"
+	"tubes2stima/backend/scrape"
+	"tubes2stima/backend/search"
 )
 
+// strictDataValidation: jika true (flag -strict), pemuatan dan impor data
+// gagal bila validasi data menemukan masalah tingkat error
+var strictDataValidation bool
+
 func main() {
+	// Subcommand (serve, scrape, filter, search, stats) memakai flag sendiri,
+	// lihat cli.go. Tanpa subcommand, flag lama tetap berlaku.
+	if runSubcommand(os.Args[1:]) {
+		return
+	}
+	flag.Usage = printUsage
 	scrapeOnly := flag.Bool("scrapeonly", false, "Run scraping and filtering then exit")
+	compareTargets := flag.String("compare", "", "Bandingkan semua algoritma untuk daftar target (dipisah koma, atau 'tiers') lalu keluar")
+	compareFormat := flag.String("compare-format", "markdown", "Format output -compare: 'markdown' atau 'csv'")
+	compareMax := flag.Int("compare-max", 5, "Nilai max untuk algoritma mode multiple pada -compare")
+	showStats := flag.Bool("stats", false, "Cetak statistik dataset dan analitik graf lalu keluar")
+	statsFormat := flag.String("stats-format", "text", "Format output -stats: 'text' atau 'json'")
+	diffOld := flag.String("diff", "", "Bandingkan dua snapshot resep: -diff lama.json baru.json, lalu keluar")
+	diffFormat := flag.String("diff-format", "text", "Format output -diff: 'text' atau 'json'")
+	strict := flag.Bool("strict", false, "Gagal memuat data jika validasi menemukan resep duplikat, rusak, atau tidak bisa dibuat")
+	gameName := flag.String("game", dataset.DefaultGame, "Edisi game untuk scraping/filter saat start (misalnya 'la2', 'la1', atau 'all'), -import, -export, -diff, -compare, -stats, dan -guide")
+	exportFormat := flag.String("export", "", "Ekspor resep terfilter ke format 'json', 'csv', 'ndjson', 'graphml', atau 'dot' lalu keluar")
+	exportOut := flag.String("export-out", "", "File tujuan -export (kosong = stdout)")
+	importFile := flag.String("import", "", "Impor resep dari file (json, csv, ndjson, graphml, dot) sebagai data mentah, jalankan filter, lalu keluar")
+	importFormat := flag.String("import-format", "", "Format file -import (kosong = ditebak dari ekstensi)")
+	importForce := flag.Bool("force", false, "Izinkan -import menimpa recipes_scraped.json dan recipes_final_filtered.json yang sudah ada")
+	guideTarget := flag.String("guide", "", "Cetak panduan langkah demi langkah untuk membuat elemen lalu keluar")
+	guideFormat := flag.String("guide-format", "text", "Format output -guide: 'text' atau 'markdown'")
 	flag.Parse() 
+	strictDataValidation = *strict
+
+	if *compareTargets != "" {
+		runCompareMode(*gameName, *compareTargets, *compareFormat, *compareMax)
+		return
+	}
+	if *showStats {
+		runStatsMode(*gameName, *statsFormat)
+		return
+	}
+	if *guideTarget != "" {
+		runGuideMode(*gameName, *guideTarget, *guideFormat)
+		return
+	}
+	if *exportFormat != "" {
+		runExportMode(*gameName, *exportFormat, *exportOut)
+		return
+	}
+	if *importFile != "" {
+		runImportMode(*gameName, *importFile, *importFormat, *importForce)
+		return
+	}
+	if *diffOld != "" {
+		// flag berhenti di argumen non-flag pertama (file baru), jadi sisa flag
+		// setelahnya (misalnya -diff-format) di-parse ulang
+		newFile := flag.Arg(0)
+		if newFile != "" {
+			flag.CommandLine.Parse(flag.Args()[1:])
+		}
+		if newFile == "" || flag.NArg() != 0 {
+			log.Fatalf("FATAL: -diff membutuhkan dua file: -diff lama.json baru.json")
+		}
+		runDiffMode(*gameName, *diffOld, newFile, *diffFormat)
+		return
+	}
 
-	RunScraping();
-	runFilter();
+	scrapeAndFilter(*gameName, true)
 	if *scrapeOnly {
 		log.Println("Scraping dan filtering selesai (mode scrapeonly). Aplikasi akan keluar.")
 		return // Keluar setelah scraping dan filter jika flag aktif
 	}
+	runServer("data", "8080")
+}
+
+// scrapeAndFilter menjalankan scraper (dan filter jika filter true) untuk
+// edisi game pada nilai flag -game.
+func scrapeAndFilter(gameName string, runFilter bool) {
+	editions, err := scrapeEditions(gameName)
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	for _, edition := range editions {
+		editionDir := filepath.Join("data", edition.DataDir)
+		scrape.Run(edition, editionDir)
+		if runFilter {
+			filter.Run(editionDir, edition.BaseElements)
+		}
+	}
+}
+
+// filterEditions menjalankan filter untuk data lokal edisi game pada nilai
+// flag -game.
+func filterEditions(gameName string) {
+	editions, err := scrapeEditions(gameName)
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	for _, edition := range editions {
+		filter.Run(filepath.Join("data", edition.DataDir), edition.BaseElements)
+	}
+}
+
+// runServer memuat data dari dataDirPath lalu menjalankan server HTTP.
+func runServer(dataDirPath string, port string) {
 	log.Println("=== MEMULAI SERVER BACKEND ===")
-	dataDirPath := "data"
-	err := InitData(dataDirPath) // Dari data.go
+	err := api.Init(dataDirPath, strictDataValidation) // Dari api/games.go
 	if err != nil {
 		log.Fatalf("FATAL: Gagal memuat data awal aplikasi dari '%s': %v", dataDirPath, err)
 	}
 	fmt.Println("Data awal berhasil dimuat.")
-	BuildGraph(GetRecipeMap()) // Dari graph.go
-	fmt.Println("Struktur graf siap digunakan.")
+	api.SetImageCacheDir(filepath.Join(dataDirPath, "image_cache")) // Cache gambar elemen (api/image_cache.go)
 
 	// --- Setup Rute API ---
-	http.HandleFunc("/api/search", searchHandler) // Daftarkan handler dari handlers.go
-	http.HandleFunc("/api/image", imageHandler)
-	// Tambahkan handler lain jika ada nanti
+	api.RegisterRoutes(http.DefaultServeMux) // Daftarkan handler dari api/handlers.go
 
 	// --- Jalankan Server ---
-	port := "8080" // Port yang akan digunakan server
 	log.Printf("Server backend berjalan di http://localhost:%s\n", port)
 	log.Printf("Server frontend berjalan di http://localhost:3000\n")
 	err = http.ListenAndServe(":"+port, nil) // Jalankan server
 	if err != nil {
 		log.Fatalf("FATAL: Gagal menjalankan server: %v", err)
 	}
-}
\ No newline at end of file
+}
+
+// scrapeEditions mengembalikan edisi game untuk nilai flag -game ("all" =
+// semua edisi).
+func scrapeEditions(name string) ([]dataset.GameEdition, error) {
+	if name == "all" {
+		return dataset.Editions, nil
+	}
+	edition, ok := dataset.FindEdition(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", dataset.ErrUnknownGame, name)
+	}
+	return []dataset.GameEdition{edition}, nil
+}
+
+// gameDataDir mengembalikan edisi dan direktori data lokal untuk nilai flag -game.
+func gameDataDir(name string) (dataset.GameEdition, string) {
+	edition, ok := dataset.FindEdition(name)
+	if !ok {
+		log.Fatalf("FATAL: %v: '%s'", dataset.ErrUnknownGame, name)
+	}
+	return edition, filepath.Join("data", edition.DataDir)
+}
+
+// runExportMode menulis resep terfilter edisi game ke file atau stdout.
+func runExportMode(gameName, format, outFile string) {
+	edition, dataDir := gameDataDir(gameName)
+	restore := silenceStdout()
+	recipes, err := dataset.LoadRecipes(filepath.Join(dataDir, dataset.FilteredRecipeFile))
+	restore()
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+
+	out := os.Stdout
+	if outFile != "" {
+		if out, err = os.Create(outFile); err != nil {
+			log.Fatalf("FATAL: %v", err)
+		}
+		defer out.Close()
+	}
+	if err := dataset.ExportRecipes(out, recipes, format, edition.BaseElements); err != nil {
+		log.Fatalf("FATAL: Gagal mengekspor resep: %v", err)
+	}
+	if outFile != "" {
+		log.Printf("%d resep diekspor ke %s (%s).\n", len(recipes), outFile, format)
+	}
+}
+
+// runImportMode membaca file resep, memvalidasinya seperti api.Init, lalu
+// menyimpannya sebagai recipes_scraped.json edisi game dan menjalankan filter
+// yang sama dengan hasil scraping. Data edisi yang sudah ada hanya ditimpa
+// jika force diaktifkan.
+func runImportMode(gameName, file, format string, force bool) {
+	edition, dataDir := gameDataDir(gameName)
+	if !force {
+		for _, name := range []string{filter.ScrapedRecipeFile, dataset.FilteredRecipeFile} {
+			if _, err := os.Stat(filepath.Join(dataDir, name)); err == nil {
+				log.Fatalf("FATAL: '%s' sudah ada dan akan ditimpa; gunakan -force untuk menimpanya", filepath.Join(dataDir, name))
+			}
+		}
+	}
+	recipes, err := dataset.ImportRecipesFile(file, format)
+	if err != nil {
+		log.Fatalf("FATAL: Gagal mengimpor resep: %v", err)
+	}
+	recipes, _, report := dataset.Validate(recipes, nil, edition.BaseElements)
+	for _, problem := range report.Errors {
+		log.Printf("Peringatan data: %s\n", problem)
+	}
+	if strictDataValidation && len(report.Errors) > 0 {
+		log.Fatalf("FATAL: validasi data gagal (-strict), tidak ada file yang ditulis")
+	}
+
+	if err := os.MkdirAll(dataDir, os.ModePerm); err != nil {
+		log.Fatalf("FATAL: Gagal membuat direktori '%s': %v", dataDir, err)
+	}
+	rawBytes, err := json.MarshalIndent(recipes, "", "  ")
+	if err != nil {
+		log.Fatalf("FATAL: Gagal marshal resep: %v", err)
+	}
+	rawFile := filepath.Join(dataDir, filter.ScrapedRecipeFile)
+	if err := os.WriteFile(rawFile, rawBytes, 0644); err != nil {
+		log.Fatalf("FATAL: Gagal menulis '%s': %v", rawFile, err)
+	}
+	log.Printf("%d resep (%d dibaca) disimpan ke %s.\n", len(recipes), report.RecipesLoaded, rawFile)
+	filter.Run(dataDir, edition.BaseElements)
+}
+
+// runGuideMode memuat data lokal (tanpa scraping), mencari jalur terpendek
+// (BFS) ke target, lalu mencetak panduannya ke stdout (lihat api/guide.go).
+func runGuideMode(gameName, target, format string) {
+	if format == "md" {
+		format = "markdown"
+	}
+	if format != "text" && format != "markdown" {
+		log.Fatalf("FATAL: Format -guide-format '%s' tidak dikenal (gunakan 'text' atau 'markdown')", format)
+	}
+	g := loadLocalGame(gameName)
+	element := g.ResolveElementName(strings.TrimSpace(target))
+	if !g.HasElement(element) {
+		log.Fatalf("FATAL: Elemen '%s' tidak ditemukan", target)
+	}
+	// Log pencarian juga ditulis ke stdout, jadi dibuang
+	restore := silenceStdout()
+	paths, _, err := search.Run(g.Dataset(), g.Costs(), "bfs", "shortest", element, 1, nil)
+	restore()
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	if len(paths) == 0 && !g.Dataset().IsBase(element) {
+		log.Fatalf("FATAL: Jalur untuk '%s' tidak ditemukan", element)
+	}
+	if err := api.WriteGuides(os.Stdout, api.BuildGuides(element, paths), format == "markdown"); err != nil {
+		log.Fatalf("FATAL: Gagal menulis panduan: %v", err)
+	}
+}
+
+// loadLocalGame memuat data lokal (tanpa scraping) untuk mode CLI dan
+// mengembalikan data edisi gameName. Log pemuatan ditulis ke stdout, jadi
+// dibuang agar output tetap bersih.
+func loadLocalGame(gameName string) *api.GameData {
+	restore := silenceStdout()
+	err := api.Init("data", strictDataValidation)
+	var g *api.GameData
+	if err == nil {
+		g, err = api.GetGame(gameName)
+	}
+	restore()
+	if err != nil {
+		log.Fatalf("FATAL: Gagal memuat data: %v", err)
+	}
+	return g
+}
+
+// runStatsMode memuat data lokal (tanpa scraping) lalu mencetak statistik
+// dataset edisi game ke stdout.
+func runStatsMode(gameName, format string) {
+	g := loadLocalGame(gameName)
+	stats := g.Dataset().Stats()
+	var err error
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(stats)
+	case "text":
+		err = dataset.WriteStatsText(os.Stdout, stats)
+	default:
+		log.Fatalf("FATAL: Format -stats-format '%s' tidak dikenal (gunakan 'text' atau 'json')", format)
+	}
+	if err != nil {
+		log.Fatalf("FATAL: Gagal menulis statistik: %v", err)
+	}
+}
+
+// runDiffMode membandingkan dua file []dataset.Recipe (dengan elemen dasar
+// edisi game) lalu mencetak perbedaannya ke stdout.
+func runDiffMode(gameName, oldFile, newFile string, format string) {
+	edition, _ := gameDataDir(gameName)
+	restore := silenceStdout()
+	oldRecipes, err := dataset.LoadRecipes(oldFile)
+	var newRecipes []dataset.Recipe
+	if err == nil {
+		newRecipes, err = dataset.LoadRecipes(newFile)
+	}
+	restore()
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+
+	restore = silenceStdout()
+	diff := api.DiffRecipes(oldRecipes, newRecipes, edition.BaseElements)
+	restore()
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(diff)
+	case "text":
+		err = api.WriteDiffText(os.Stdout, diff)
+	default:
+		log.Fatalf("FATAL: Format -diff-format '%s' tidak dikenal (gunakan 'text' atau 'json')", format)
+	}
+	if err != nil {
+		log.Fatalf("FATAL: Gagal menulis diff: %v", err)
+	}
+}
+
+// runCompareMode memuat data lokal edisi game (tanpa scraping) lalu mencetak
+// tabel perbandingan algoritma ke stdout.
+func runCompareMode(gameName, targetList string, format string, maxRecipes int) {
+	g := loadLocalGame(gameName)
+
+	targets, err := parseCompareTargets(g, targetList)
+	if err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	log.Printf("Membandingkan %d algoritma untuk %d target...\n", len(comparedAlgorithms(g)), len(targets))
+	rows := RunComparison(g, targets, maxRecipes)
+
+	switch format {
+	case "csv":
+		err = WriteComparisonCSV(os.Stdout, rows)
+	case "markdown", "md":
+		err = WriteComparisonMarkdown(os.Stdout, rows)
+	default:
+		log.Fatalf("FATAL: Format -compare-format '%s' tidak dikenal (gunakan 'markdown' atau 'csv')", format)
+	}
+	if err != nil {
+		log.Fatalf("FATAL: Gagal menulis hasil perbandingan: %v", err)
+	}
+}
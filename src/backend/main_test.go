@@ -0,0 +1,31 @@
+// src/backend/main_test.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"tubes2stima/backend/api"
+)
+
+func TestMain(m *testing.M) {
+	restore := silenceStdout()
+	err := api.Init("data", false)
+	restore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gagal memuat data untuk test: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// defaultGame mengembalikan data game default yang dimuat TestMain.
+func defaultGame(t *testing.T) *api.GameData {
+	t.Helper()
+	g, err := api.GetGame("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
@@ -1,4 +1,8 @@
-package main
+// src/backend/scrape/scrape.go
+
+// Package scrape mengambil data resep dan gambar elemen dari wiki sesuai
+// profil scraper edisi game (dataset.GameEdition).
+package scrape
 
 import (
 	"encoding/json"
@@ -10,6 +14,8 @@ import (
 	"strings"
 	// Hanya perlu goquery dan library standar ini
 	"github.com/PuerkitoBio/goquery"
+
+	"tubes2stima/backend/dataset"
 )
 
 // --- Struct Definitions ---
@@ -29,9 +35,7 @@ import (
 
 // --- Konfigurasi ---
 
-// !!! GANTI URL INI DENGAN URL WEBSITE YANG ANDA SCRAPE !!!
-// Contoh: "https://hints.littlealchemy2.com/all"
-const targetURL = "https://little-alchemy.fandom.com/wiki/Elements_(Little_Alchemy_2)#Tier_15_elements"
+// URL target setiap edisi ada di dataset.Editions (GameEdition.Scrape.URL).
 // Dihapus sesuai permintaan, tapi diperlukan jika ada URL relatif pada gambar/link lain
 // const baseURL = "URL_DASAR_WEBSITE_TARGET_ANDA"
 
@@ -54,41 +58,14 @@ func getValidImageURL(imgTag *goquery.Selection) (string, bool) {
 }
 
 
-// --- Fungsi Utama ---
-func RunScraping() {
-	// Validasi URL Target
-	if targetURL == "URL_WEBSITE_TARGET_ANDA_DI_SINI" {
-		log.Fatal("Error: Anda belum mengganti placeholder targetURL di dalam kode!")
-	}
-
-	// --- MODIFIKASI: Tentukan direktori data dan buat jika belum ada ---
-	dataDir := "data" // Nama subdirektori
-	if err := os.MkdirAll(dataDir, os.ModePerm); err != nil {
-		log.Fatalf("Error membuat direktori '%s': %v", dataDir, err)
-	}
-	fmt.Printf("Memastikan direktori '%s' ada.\n", dataDir)
-	// -------------------------------------------------------------------
-
-	fmt.Println("Memulai proses scraping dari:", targetURL)
-
-	// 1. HTTP GET Request
-	res, err := http.Get(targetURL)
-	if err != nil { log.Fatalf("Error GET request: %v", err) }
-	defer res.Body.Close()
-	if res.StatusCode != 200 { log.Fatalf("Error status code: %d", res.StatusCode) }
-
-	// 2. Load HTML
-	doc, err := goquery.NewDocumentFromReader(res.Body)
-	if err != nil { log.Fatalf("Error membaca HTML: %v", err) }
-	fmt.Println("Berhasil memuat dokumen HTML.")
-
-	// 3. Proses Scraping
-	var allRecipes []Recipe               // Slice untuk data resep
-	var elementImages []ElementImage     // Slice BARU untuk data gambar
+// parseTables mengambil resep dan URL gambar elemen dari setiap tabel doc
+// yang cocok dengan tableSelector. Dipisah dari Run agar tata letak tabel
+// bisa diuji dengan fixture HTML (lihat scrape_test.go).
+func parseTables(doc *goquery.Document, tableSelector string) ([]dataset.Recipe, []dataset.ElementImage) {
+	var allRecipes []dataset.Recipe               // Slice untuk data resep
+	var elementImages []dataset.ElementImage     // Slice BARU untuk data gambar
 	processedElements := make(map[string]bool) // Set untuk melacak elemen yg gambarnya sudah diproses
 
-	// Selector Tabel Utama
-	tableSelector := "table.list-table.col-list.icon-hover" // !! VERIFIKASI !!
 	fmt.Printf("Mencari tabel dengan selector: '%s'\n", tableSelector)
 
 	doc.Find(tableSelector).Each(func(index int, table *goquery.Selection) {
@@ -116,7 +93,7 @@ func RunScraping() {
 					}
 				})
 				if imgURL != "" {
-					elementImages = append(elementImages, ElementImage{Name: resultName, ImageURL: imgURL})
+					elementImages = append(elementImages, dataset.ElementImage{Name: resultName, ImageURL: imgURL})
 					processedElements[resultName] = true // Tandai sudah diproses
 					fmt.Printf("    -> URL Gambar Hasil ditemukan: %s\n", imgURL)
 				} else {
@@ -162,7 +139,7 @@ func RunScraping() {
 
 					// Buat resep teks
 					fmt.Printf("    -> Resep ke-%d: %s + %s\n", j+1, bahan1, bahan2)
-					recipe := Recipe{ Result: resultName, Ingredient1: bahan1, Ingredient2: bahan2 }
+					recipe := dataset.Recipe{ Result: resultName, Ingredient1: bahan1, Ingredient2: bahan2 }
 					allRecipes = append(allRecipes, recipe)
 
 					// Cocokkan & Simpan URL Gambar Bahan jika belum diproses
@@ -171,12 +148,12 @@ func RunScraping() {
 					if len(ingredientImageURLs) == 2 { imgURL2 = ingredientImageURLs[1] }
 
 					if _, processed := processedElements[bahan1]; !processed && imgURL1 != "" {
-						elementImages = append(elementImages, ElementImage{Name: bahan1, ImageURL: imgURL1})
+						elementImages = append(elementImages, dataset.ElementImage{Name: bahan1, ImageURL: imgURL1})
 						processedElements[bahan1] = true
 						fmt.Printf("      -> URL Gambar Bahan 1 ditemukan: %s\n", imgURL1)
 					}
 					if _, processed := processedElements[bahan2]; !processed && imgURL2 != "" {
-						elementImages = append(elementImages, ElementImage{Name: bahan2, ImageURL: imgURL2})
+						elementImages = append(elementImages, dataset.ElementImage{Name: bahan2, ImageURL: imgURL2})
 						processedElements[bahan2] = true
 						fmt.Printf("      -> URL Gambar Bahan 2 ditemukan: %s\n", imgURL2)
 					}
@@ -189,6 +166,42 @@ func RunScraping() {
 		}) // Akhir loop tr
 	}) // Akhir loop table
 
+	return allRecipes, elementImages
+}
+
+// --- Fungsi Utama ---
+// Run menjalankan scraping dengan profil scraper edisi game dan menulis
+// hasilnya ke dataDir (lihat dataset.Editions).
+func Run(edition dataset.GameEdition, dataDir string) {
+	targetURL := edition.Scrape.URL
+	// Validasi URL Target
+	if targetURL == "URL_WEBSITE_TARGET_ANDA_DI_SINI" {
+		log.Fatal("Error: Anda belum mengganti placeholder targetURL di dalam kode!")
+	}
+
+	// --- MODIFIKASI: Buat direktori data jika belum ada ---
+	if err := os.MkdirAll(dataDir, os.ModePerm); err != nil {
+		log.Fatalf("Error membuat direktori '%s': %v", dataDir, err)
+	}
+	fmt.Printf("Memastikan direktori '%s' ada.\n", dataDir)
+	// -------------------------------------------------------------------
+
+	fmt.Printf("Memulai proses scraping %s dari: %s\n", edition.Title, targetURL)
+
+	// 1. HTTP GET Request
+	res, err := http.Get(targetURL)
+	if err != nil { log.Fatalf("Error GET request: %v", err) }
+	defer res.Body.Close()
+	if res.StatusCode != 200 { log.Fatalf("Error status code: %d", res.StatusCode) }
+
+	// 2. Load HTML
+	doc, err := goquery.NewDocumentFromReader(res.Body)
+	if err != nil { log.Fatalf("Error membaca HTML: %v", err) }
+	fmt.Println("Berhasil memuat dokumen HTML.")
+
+	// 3. Proses Scraping
+	allRecipes, elementImages := parseTables(doc, edition.Scrape.TableSelector)
+
 	fmt.Printf("\nTotal resep tekstual yang berhasil di-scrape: %d\n", len(allRecipes))
 	fmt.Printf("Total pemetaan gambar elemen unik yang ditemukan: %d\n", len(elementImages))
 
@@ -202,7 +215,9 @@ func RunScraping() {
 		if err != nil { log.Fatalf("Error menulis JSON Resep ke file '%s': %v", recipeFileName, err) }
 		fmt.Printf("Sukses! Data resep tekstual telah disimpan ke %s\n", recipeFileName)
 	} else {
-		fmt.Println("Tidak ada resep tekstual yang di-scrape untuk disimpan.")
+		// Tabel tidak cocok dengan selector profil (misalnya tata letak wiki
+		// berubah): gagal dengan exit code != 0 agar tidak lolos diam-diam
+		log.Fatalf("Error: tidak ada resep yang di-scrape dari %s dengan selector '%s'", targetURL, edition.Scrape.TableSelector)
 	}
 
 	// 5. Marshal & Tulis JSON untuk Gambar Elemen
@@ -1,13 +1,15 @@
-package main
+package search
 
 import (
 	"container/list"
 	"errors"
 	"fmt"
-	"sort" // Diperlukan untuk generatePathIdentifier dan sorting
-	// Diperlukan untuk generatePathIdentifier
+	"sort" // Diperlukan untuk PathIdentifier dan sorting
+	// Diperlukan untuk PathIdentifier
 	"sync"
 	"sync/atomic" // Digunakan untuk nodesVisitedCount di multiple
+
+	"tubes2stima/backend/dataset"
 )
 
 // --- Definisi yang Duplikat Dihapus ---
@@ -15,48 +17,37 @@ import (
 
 // --- Implementasi Bidirectional Search (BDS) ---
 
-// reconstructSingleSegmentPath: Membangun jalur dari parent maps setelah pertemuan.
-// (Fungsi ini tetap sama seperti versi sebelumnya)
-func reconstructSingleSegmentPath(parentMap map[string]Recipe, startNode string, stopCondition func(string) bool) []Recipe {
-	pathList := list.New()
-	processed := make(map[string]bool)
-	curr := startNode
-	for curr != "" && !stopCondition(curr) {
-		recipe, exists := parentMap[curr]
-		if !exists { break }
-		recipeKey := getUniqueRecipeKey(recipe)
-		if processed[recipeKey] { break }
-		pathList.PushFront(recipe)
-		processed[recipeKey] = true
-		_, p1Exists := parentMap[recipe.Ingredient1]
-		_, p2Exists := parentMap[recipe.Ingredient2]
-		chosenParent := ""
-		if p1Exists && p2Exists {
-			if recipe.Ingredient1 < recipe.Ingredient2 { chosenParent = recipe.Ingredient1 } else { chosenParent = recipe.Ingredient2 }
-		} else if p1Exists { chosenParent = recipe.Ingredient1
-		} else if p2Exists { chosenParent = recipe.Ingredient2
-		} else {
-			if isBaseElement(recipe.Ingredient1) && stopCondition(recipe.Ingredient1) { chosenParent = recipe.Ingredient1
-			} else if isBaseElement(recipe.Ingredient2) && stopCondition(recipe.Ingredient2) { chosenParent = recipe.Ingredient2
-			} else if isBaseElement(recipe.Ingredient1) && !stopCondition(recipe.Ingredient1) { chosenParent = recipe.Ingredient1
-			} else if isBaseElement(recipe.Ingredient2) && !stopCondition(recipe.Ingredient2) { chosenParent = recipe.Ingredient2
-			} else { chosenParent = "" }
+// reconstructForwardTree: Mengumpulkan seluruh resep yang dipakai pencarian maju
+// untuk menghasilkan node (kedua bahan ditelusuri rekursif sampai elemen dasar),
+// dalam urutan bahan-dulu. Parent maju selalu ditetapkan setelah kedua bahannya
+// dikunjungi, jadi penelusuran ini tidak bisa berputar.
+func reconstructForwardTree(d *dataset.Dataset, parentMap map[string]dataset.Recipe, node string) []dataset.Recipe {
+	var recipes []dataset.Recipe
+	visited := make(map[string]bool)
+	var collect func(el string)
+	collect = func(el string) {
+		if visited[el] || d.IsBase(el) {
+			return
 		}
-		curr = chosenParent
-	}
-	finalPath := make([]Recipe, 0, pathList.Len())
-	for e := pathList.Front(); e != nil; e = e.Next() {
-		finalPath = append(finalPath, e.Value.(Recipe))
+		visited[el] = true
+		recipe, exists := parentMap[el]
+		if !exists {
+			return
+		}
+		collect(recipe.Ingredient1)
+		collect(recipe.Ingredient2)
+		recipes = append(recipes, recipe)
 	}
-	return finalPath
+	collect(node)
+	return recipes
 }
 
 // buildSortedPathFromRecipes: Mengurutkan sekumpulan resep berdasarkan dependensi.
 // Mirip dengan logika buildRecipePath di BFS.
-func buildSortedPathFromRecipes(recipes map[string]Recipe, targetElement string) []Recipe {
+func buildSortedPathFromRecipes(d *dataset.Dataset, recipes map[string]dataset.Recipe, targetElement string) []dataset.Recipe {
 	fmt.Println("  Mengurutkan resep gabungan berdasarkan dependensi...")
 	if len(recipes) == 0 {
-		return []Recipe{}
+		return []dataset.Recipe{}
 	}
 
 	// 1. Identifikasi semua elemen yang terlibat (bahan dan hasil)
@@ -69,27 +60,27 @@ func buildSortedPathFromRecipes(recipes map[string]Recipe, targetElement string)
 
 	// 2. Inisialisasi elemen yang tersedia (awalnya hanya base elements)
 	available := make(map[string]bool)
-	for _, base := range baseElements {
+	for _, base := range d.BaseElements() {
 		if elementsInvolved[base] { // Hanya tambahkan base element jika relevan dengan path ini
 			available[base] = true
 		}
 	}
 
 	// 3. Buat map resep yang belum digunakan
-	remainingRecipes := make(map[string]Recipe)
+	remainingRecipes := make(map[string]dataset.Recipe)
 	for k, v := range recipes {
 		remainingRecipes[k] = v
 	}
 
 	// 4. Iteratif membangun jalur terurut
-	sortedPath := make([]Recipe, 0, len(recipes))
+	sortedPath := make([]dataset.Recipe, 0, len(recipes))
 	iterations := 0 // Safety break
 	maxIterations := len(recipes) * 2 + 10 // Batas iterasi untuk mencegah infinite loop
 
 	for !available[targetElement] && iterations < maxIterations {
 		addedRecipeInIteration := false
 		// Cari resep yang bisa dibuat (kedua bahan tersedia) dari resep yang tersisa
-		candidates := make([]Recipe, 0)
+		candidates := make([]dataset.Recipe, 0)
 		candidateKeys := make([]string, 0) // Simpan key untuk menghapus dari remainingRecipes
 
 		for key, recipe := range remainingRecipes {
@@ -144,28 +135,27 @@ func buildSortedPathFromRecipes(recipes map[string]Recipe, targetElement string)
 }
 
 
-// FindPathBDS: Mencari jalur menggunakan hybrid BDS + BFS.
-func FindPathBDS(targetElement string) ([]Recipe, int, error) {
+func FindPathBDS(d *dataset.Dataset, targetElement string) ([]dataset.Recipe, int, error) {
 	fmt.Printf("Hybrid BDS+BFS: Mencari jalur ke: %s\n", targetElement)
-	recipeMap := GetRecipeMap()
-	alchemyGraph := GetAlchemyGraph()
+	recipeMap := d.RecipeMap()
+	alchemyGraph := d.Graph()
 	if recipeMap == nil || alchemyGraph == nil {
 		return nil, 0, errors.New("data resep/graf belum diinisialisasi")
 	}
-	if isBaseElement(targetElement) {
-		return []Recipe{}, 0, nil
+	if d.IsBase(targetElement) {
+		return []dataset.Recipe{}, 0, nil
 	}
 
 	nodesVisitedCount := 0
 	queueForward := list.New()
 	visitedForward := make(map[string]int)
-	parentForward := make(map[string]Recipe)
+	parentForward := make(map[string]dataset.Recipe)
 	queueBackward := list.New()
 	visitedBackward := make(map[string]int)
-	parentBackward := make(map[string]Recipe)
+	parentBackward := make(map[string]dataset.Recipe)
 
 	// Inisialisasi
-	for _, base := range baseElements {
+	for _, base := range d.BaseElements() {
 		if visitedForward[base] == 0 {
 			queueForward.PushBack(base)
 			visitedForward[base] = 1
@@ -283,22 +273,21 @@ func FindPathBDS(targetElement string) ([]Recipe, int, error) {
 	ing1 := finalRecipe.Ingredient1
 	ing2 := finalRecipe.Ingredient2
 
-	combinedRecipes := make(map[string]Recipe) // Pindahkan deklarasi ke sini
+	combinedRecipes := make(map[string]dataset.Recipe) // Pindahkan deklarasi ke sini
 
 	if meetingNode == ing1 || meetingNode == ing2 {
 		// Kasus 1: Meeting node adalah salah satu bahan final
 		var ingredientToSearchBFS string
-		var pathForMeetingNodeSegment []Recipe
+		var pathForMeetingNodeSegment []dataset.Recipe
 
 		if meetingNode == ing1 { ingredientToSearchBFS = ing2 } else { ingredientToSearchBFS = ing1 }
 
 		fmt.Printf("  Merekonstruksi jalur FWD untuk meeting node '%s'...\n", meetingNode)
-		stopAtBase := func(node string) bool { return isBaseElement(node) }
-		pathForMeetingNodeSegment = reconstructSingleSegmentPath(parentForward, meetingNode, stopAtBase)
+		pathForMeetingNodeSegment = reconstructForwardTree(d, parentForward, meetingNode)
 		fmt.Printf("  Jalur FWD untuk '%s' ditemukan (panjang: %d)\n", meetingNode, len(pathForMeetingNodeSegment))
 
 		fmt.Printf("  Mencari jalur BFS untuk bahan '%s'\n", ingredientToSearchBFS)
-		pathOtherIngredient, bfsNodes, errBFS := FindPathBFS(ingredientToSearchBFS)
+		pathOtherIngredient, bfsNodes, errBFS := FindPathBFS(d, ingredientToSearchBFS)
 		if errBFS != nil {
 			fmt.Printf("  ERROR: Gagal mencari jalur BFS untuk '%s': %v\n", ingredientToSearchBFS, errBFS)
 			return nil, nodesVisitedCount + bfsNodes, fmt.Errorf("gagal mencari jalur BFS untuk bahan '%s': %v", ingredientToSearchBFS, errBFS)
@@ -315,7 +304,7 @@ func FindPathBDS(targetElement string) ([]Recipe, int, error) {
 		fmt.Printf("  PERINGATAN: Meeting node '%s' bukan bahan langsung. Mencari BFS untuk KEDUA bahan '%s' dan '%s'.\n", meetingNode, ing1, ing2)
 
 		fmt.Printf("  Mencari jalur BFS untuk bahan 1: '%s'\n", ing1)
-		pathIng1, bfsNodes1, err1 := FindPathBFS(ing1)
+		pathIng1, bfsNodes1, err1 := FindPathBFS(d, ing1)
 		if err1 != nil {
 			fmt.Printf("  ERROR: Gagal mencari jalur BFS untuk '%s': %v\n", ing1, err1)
 			return nil, nodesVisitedCount + bfsNodes1, fmt.Errorf("gagal mencari jalur BFS untuk bahan '%s': %v", ing1, err1)
@@ -326,7 +315,7 @@ func FindPathBDS(targetElement string) ([]Recipe, int, error) {
 
 
 		fmt.Printf("  Mencari jalur BFS untuk bahan 2: '%s'\n", ing2)
-		pathIng2, bfsNodes2, err2 := FindPathBFS(ing2)
+		pathIng2, bfsNodes2, err2 := FindPathBFS(d, ing2)
 		if err2 != nil {
 			fmt.Printf("  ERROR: Gagal mencari jalur BFS untuk '%s': %v\n", ing2, err2)
 			return nil, nodesVisitedCount + bfsNodes2, fmt.Errorf("gagal mencari jalur BFS untuk bahan '%s': %v", ing2, err2)
@@ -337,8 +326,7 @@ func FindPathBDS(targetElement string) ([]Recipe, int, error) {
 
 		// Kita juga perlu jalur dari meeting node ke base dalam kasus ini
 		fmt.Printf("  Merekonstruksi jalur FWD untuk meeting node '%s' (kasus 2)...\n", meetingNode)
-		stopAtBase := func(node string) bool { return isBaseElement(node) }
-		pathMeetingToBase := reconstructSingleSegmentPath(parentForward, meetingNode, stopAtBase)
+		pathMeetingToBase := reconstructForwardTree(d, parentForward, meetingNode)
 		fmt.Printf("  Jalur FWD untuk '%s' ditemukan (panjang: %d)\n", meetingNode, len(pathMeetingToBase))
 		for _, r := range pathMeetingToBase { combinedRecipes[getUniqueRecipeKey(r)] = r }
 	}
@@ -347,14 +335,14 @@ func FindPathBDS(targetElement string) ([]Recipe, int, error) {
 	combinedRecipes[getUniqueRecipeKey(finalRecipe)] = finalRecipe
 
 	// --- Urutkan Resep Gabungan ---
-	finalPathSorted := buildSortedPathFromRecipes(combinedRecipes, targetElement)
-
-	// Validasi akhir (opsional)
-	if len(finalPathSorted) == 0 && !isBaseElement(targetElement) {
-		fmt.Printf("  PERINGATAN AKHIR: Jalur terurut kosong untuk target non-dasar '%s'.\n", targetElement)
-		// Mungkin ada masalah dalam pengurutan atau resep yang hilang
-	} else if len(finalPathSorted) > 0 && finalPathSorted[len(finalPathSorted)-1].Result != targetElement {
-		fmt.Printf("  PERINGATAN AKHIR: Jalur terurut TIDAK menghasilkan target '%s'. Resep terakhir: %v\n", targetElement, finalPathSorted[len(finalPathSorted)-1].Result)
+	finalPathSorted := buildSortedPathFromRecipes(d, combinedRecipes, targetElement)
+
+	// Resep gabungan bisa berisi dua resep untuk elemen yang sama atau segmen
+	// meeting node yang tidak dipakai; rapikan menjadi satu pohon lalu verifikasi.
+	finalPathSorted = normalizePath(finalPathSorted, targetElement)
+	if err := ValidatePath(d, finalPathSorted, targetElement); err != nil {
+		fmt.Printf("  ERROR AKHIR: %v\n", err)
+		return nil, nodesVisitedCount, err
 	}
 
 	fmt.Printf("Hybrid BDS+BFS: Penggabungan dan pengurutan selesai. Total resep unik terurut: %d\n", len(finalPathSorted))
@@ -362,20 +350,24 @@ func FindPathBDS(targetElement string) ([]Recipe, int, error) {
 }
 
 
-// FindMultiplePathsBDS: Mencari beberapa jalur unik menggunakan konkurensi.
-// (Fungsi ini tetap sama, hanya memanggil FindPathBDS yang sudah diubah)
-func FindMultiplePathsBDS(targetElement string, maxRecipes int) ([][]Recipe, int, error) {
+func FindMultiplePathsBDS(d *dataset.Dataset, targetElement string, maxRecipes int) ([][]dataset.Recipe, int, error) {
+	return findMultiplePathsBDS(d, targetElement, maxRecipes, multiSearchOptions{})
+}
+
+// findMultiplePathsBDS: pada mode deterministik goroutine dijalankan berurutan
+// sehingga jumlah pencarian (dan total node) tidak bergantung penjadwalan.
+func findMultiplePathsBDS(d *dataset.Dataset, targetElement string, maxRecipes int, opts multiSearchOptions) ([][]dataset.Recipe, int, error) {
 	fmt.Printf("BDS Multiple (Hybrid): Mencari %d jalur ke: %s (Multithreaded)\n", maxRecipes, targetElement)
 	// fmt.Println("CATATAN: Implementasi BDS Multiple saat ini cenderung menemukan jalur terpendek yang sama.")
 
 	if maxRecipes <= 0 {
 		return nil, 0, errors.New("jumlah resep minimal harus 1")
 	}
-	if isBaseElement(targetElement) {
-		return [][]Recipe{{}}, 0, nil
+	if d.IsBase(targetElement) {
+		return [][]dataset.Recipe{{}}, 0, nil
 	}
 
-	var allFoundPaths [][]Recipe
+	var allFoundPaths [][]dataset.Recipe
 	addedPathIdentifiers := make(map[string]bool)
 	var mu sync.Mutex
 	var wg sync.WaitGroup
@@ -397,12 +389,11 @@ func FindMultiplePathsBDS(targetElement string, maxRecipes int) ([][]Recipe, int
 
 	for i := 0; i < numGoroutines; i++ {
 		if foundCount.Load() >= int32(maxRecipes) { break }
-		wg.Add(1)
-		go func(goroutineIndex int) {
+		search := func(goroutineIndex int) {
 			defer wg.Done()
 			// Setiap goroutine sekarang menjalankan FindPathBDS (Hybrid)
 			// Path yang dikembalikan sudah diurutkan oleh buildSortedPathFromRecipes
-			path, nodesVisited, err := FindPathBDS(targetElement)
+			path, nodesVisited, err := FindPathBDS(d, targetElement)
 			nodesVisitedTotal.Add(int32(nodesVisited))
 			mu.Lock()
 			defer mu.Unlock()
@@ -413,11 +404,11 @@ func FindMultiplePathsBDS(targetElement string, maxRecipes int) ([][]Recipe, int
 			if err == nil && path != nil {
 				// Path sudah diurutkan, bisa langsung generate ID
 				if len(path) > 0 {
-					pathID := generatePathIdentifier(path) // ID dari path terurut
+					pathID := PathIdentifier(path) // ID dari path terurut
 					if !addedPathIdentifiers[pathID] {
 						if currentFound := foundCount.Load(); currentFound < int32(maxRecipes) {
 							// Salin path sebelum menambahkannya
-							pathToAppend := make([]Recipe, len(path))
+							pathToAppend := make([]dataset.Recipe, len(path))
 							copy(pathToAppend, path)
 							allFoundPaths = append(allFoundPaths, pathToAppend)
 							addedPathIdentifiers[pathID] = true
@@ -428,17 +419,24 @@ func FindMultiplePathsBDS(targetElement string, maxRecipes int) ([][]Recipe, int
 					}
 				}
 			}
-		}(i)
+		}
+
+		wg.Add(1)
+		if opts.deterministic {
+			search(i)
+		} else {
+			go search(i)
+		}
 	}
 
 	wg.Wait()
 	mu.Lock()
-	finalPathsToReturn := make([][]Recipe, len(allFoundPaths))
+	finalPathsToReturn := make([][]dataset.Recipe, len(allFoundPaths))
 	copy(finalPathsToReturn, allFoundPaths)
 	currentFoundCount := len(finalPathsToReturn)
 	mu.Unlock()
 
-	if currentFoundCount == 0 && !isBaseElement(targetElement) {
+	if currentFoundCount == 0 && !d.IsBase(targetElement) {
 		return nil, int(nodesVisitedTotal.Load()), fmt.Errorf("tidak ada jalur Hybrid BDS+BFS (multiple) yang valid ditemukan untuk '%s'", targetElement)
 	}
 
@@ -0,0 +1,49 @@
+// src/backend/search/bench_test.go
+package search
+
+import (
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+// Benchmark untuk setiap fungsi FindPath*/FindMultiplePaths* pada dataset
+// asli. Versi kompak ada di compact_search_test.go.
+
+const benchMaxRecipes = 5
+
+func benchmarkSingle(b *testing.B, find func(*dataset.Dataset, string) ([]dataset.Recipe, int, error)) {
+	for _, bt := range benchTargets {
+		b.Run(benchName(bt.Tier, bt.Element), func(b *testing.B) {
+			defer silenceStdout()()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				testDataset.ResetCache()
+				b.StartTimer()
+				find(testDataset, bt.Element)
+			}
+		})
+	}
+}
+
+func benchmarkMultiple(b *testing.B, find func(*dataset.Dataset, string, int) ([][]dataset.Recipe, int, error)) {
+	for _, bt := range benchTargets {
+		b.Run(benchName(bt.Tier, bt.Element), func(b *testing.B) {
+			defer silenceStdout()()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				testDataset.ResetCache()
+				b.StartTimer()
+				find(testDataset, bt.Element, benchMaxRecipes)
+			}
+		})
+	}
+}
+
+func BenchmarkFindPathBFS(b *testing.B)          { benchmarkSingle(b, FindPathBFS) }
+func BenchmarkFindPathDFS(b *testing.B)          { benchmarkSingle(b, FindPathDFS) }
+func BenchmarkFindPathBDS(b *testing.B)          { benchmarkSingle(b, FindPathBDS) }
+func BenchmarkFindPathIDDFS(b *testing.B)        { benchmarkSingle(b, FindPathIDDFS) }
+func BenchmarkFindMultiplePathsBFS(b *testing.B) { benchmarkMultiple(b, FindMultiplePathsBFS) }
+func BenchmarkFindMultiplePathsDFS(b *testing.B) { benchmarkMultiple(b, FindMultiplePathsDFS) }
+func BenchmarkFindMultiplePathsBDS(b *testing.B) { benchmarkMultiple(b, FindMultiplePathsBDS) }
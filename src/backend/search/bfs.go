@@ -1,5 +1,5 @@
-// src/backend/bfs.go
-package main
+// src/backend/search/bfs.go
+package search
 
 import (
 	"container/list"
@@ -10,55 +10,64 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
-)
 
-var baseElements = []string{"Air", "Earth", "Fire", "Water"}
+	"tubes2stima/backend/dataset"
+)
 
-var baseElementMap = map[string]bool{
-	"Air":   true,
-	"Earth": true,
-	"Fire":  true,
-	"Water": true,
+// bfsPathCache menyimpan jalur hasil FindPathBFS per target. Disimpan di
+// dataset lewat Cached sehingga setiap dataset (dan view-nya) punya cache
+// sendiri; Reset dipanggil oleh Dataset.ResetCache.
+type bfsPathCache struct {
+	mu    sync.RWMutex
+	paths map[string][]dataset.Recipe
 }
 
-func isBaseElement(name string) bool {
-	return baseElementMap[name]
+type bfsCacheKey struct{}
+
+func pathCacheOf(d *dataset.Dataset) *bfsPathCache {
+	return d.Cached(bfsCacheKey{}, func() any {
+		return &bfsPathCache{paths: make(map[string][]dataset.Recipe)}
+	}).(*bfsPathCache)
 }
 
-var (
-	bfsPathCacheMutex sync.RWMutex
-)
+// Reset mengosongkan cache jalur.
+func (c *bfsPathCache) Reset() {
+	c.mu.Lock()
+	c.paths = make(map[string][]dataset.Recipe)
+	c.mu.Unlock()
+}
 
-func FindPathBFS(targetElement string) ([]Recipe, int, error) {
+func FindPathBFS(d *dataset.Dataset, targetElement string) ([]dataset.Recipe, int, error) {
 	fmt.Printf("Finding BFS shortest path to: %s\n", targetElement)
-	graph := GetAlchemyGraph()
+	graph := d.Graph()
 	if graph == nil {
-		return nil, 0, errors.New("alchemy graph not initialized")
+		return nil, 0, dataset.ErrNotInitialized
 	}
 
-	bfsPathCacheMutex.RLock()
-	if path, exists := bfsPathCache[targetElement]; exists {
-		bfsPathCacheMutex.RUnlock()
+	cache := pathCacheOf(d)
+	cache.mu.RLock()
+	if path, exists := cache.paths[targetElement]; exists {
+		cache.mu.RUnlock()
 		fmt.Printf("BFS Cache: Path to '%s' found in cache.\n", targetElement)
 		return path, 0, nil
 	}
-	bfsPathCacheMutex.RUnlock()
+	cache.mu.RUnlock()
 
-	if isBaseElement(targetElement) {
-		return []Recipe{}, 0, nil
+	if d.IsBase(targetElement) {
+		return []dataset.Recipe{}, 0, nil
 	}
 
 	queue := list.New()
 	visited := make(map[string]bool, 1000)
 	elementVisited := make(map[string]bool, 1000)
-	recipeParent := make(map[string]Recipe)
+	recipeParent := make(map[string]dataset.Recipe)
 	discovered := make(map[string]bool, 1000)
 	nodesVisitedCount := 0
 
 	depth := make(map[string]int)
 
-	sortedBaseElements := make([]string, len(baseElements))
-	copy(sortedBaseElements, baseElements)
+	sortedBaseElements := make([]string, len(d.BaseElements()))
+	copy(sortedBaseElements, d.BaseElements())
 	sort.Strings(sortedBaseElements)
 
 	for _, base := range sortedBaseElements {
@@ -92,7 +101,7 @@ func FindPathBFS(targetElement string) ([]Recipe, int, error) {
 				continue
 			}
 			visited[pairKey] = true
-			recipes := getRecipes(currentElement, otherElement)
+			recipes := getRecipes(d, currentElement, otherElement)
 
 			for _, recipe := range recipes {
 				result := recipe.Result
@@ -103,10 +112,10 @@ func FindPathBFS(targetElement string) ([]Recipe, int, error) {
 					depth[result] = currentDepth + 1
 					if result == targetElement {
 						fmt.Printf("Target '%s' found!\n", targetElement)
-						path := buildRecipePath(recipeParent, targetElement, depth)
-						bfsPathCacheMutex.Lock()
-						bfsPathCache[targetElement] = path
-						bfsPathCacheMutex.Unlock()
+						path := buildRecipePath(d, recipeParent, targetElement, depth)
+						cache.mu.Lock()
+						cache.paths[targetElement] = path
+						cache.mu.Unlock()
 
 						return path, nodesVisitedCount, nil
 					}
@@ -131,7 +140,7 @@ func getPairKey(a, b string) string {
 	return a + ":" + b
 }
 
-func buildRecipePath(recipeParent map[string]Recipe, target string, depth map[string]int) []Recipe {
+func buildRecipePath(d *dataset.Dataset, recipeParent map[string]dataset.Recipe, target string, depth map[string]int) []dataset.Recipe {
 	dependencies := make(map[string][]string)
 	elementsNeeded := make(map[string]bool)
 
@@ -142,7 +151,7 @@ func buildRecipePath(recipeParent map[string]Recipe, target string, depth map[st
 	for queue.Len() > 0 {
 		current := queue.Remove(queue.Front()).(string)
 
-		if isBaseElement(current) {
+		if d.IsBase(current) {
 			continue
 		}
 
@@ -159,18 +168,18 @@ func buildRecipePath(recipeParent map[string]Recipe, target string, depth map[st
 		sort.Strings(ingredients)
 
 		for _, ingredient := range ingredients {
-			if !elementsNeeded[ingredient] && !isBaseElement(ingredient) {
+			if !elementsNeeded[ingredient] && !d.IsBase(ingredient) {
 				elementsNeeded[ingredient] = true
 				queue.PushBack(ingredient)
 			}
 		}
 	}
 
-	var result []Recipe
+	var result []dataset.Recipe
 	available := make(map[string]bool)
 
-	sortedBaseElements := make([]string, len(baseElements))
-	copy(sortedBaseElements, baseElements)
+	sortedBaseElements := make([]string, len(d.BaseElements()))
+	copy(sortedBaseElements, d.BaseElements())
 	sort.Strings(sortedBaseElements)
 	for _, base := range sortedBaseElements {
 		available[base] = true
@@ -226,9 +235,9 @@ func buildRecipePath(recipeParent map[string]Recipe, target string, depth map[st
 	return result
 }
 
-func getRecipes(a, b string) []Recipe {
-	graph := GetAlchemyGraph()
-	var result []Recipe
+func getRecipes(d *dataset.Dataset, a, b string) []dataset.Recipe {
+	graph := d.Graph()
+	var result []dataset.Recipe
 
 	aRecipes := graph[a]
 	bRecipes := graph[b]
@@ -252,7 +261,9 @@ func getRecipes(a, b string) []Recipe {
 	return result
 }
 
-func generatePathIdentifier(path []Recipe) string {
+// PathIdentifier adalah kunci jalur yang tidak bergantung urutan langkah,
+// dipakai untuk mendeteksi jalur duplikat.
+func PathIdentifier(path []dataset.Recipe) string {
 	if len(path) == 0 {
 		return ""
 	}
@@ -276,7 +287,7 @@ func generatePathIdentifier(path []Recipe) string {
 	return strings.Join(parts, "|")
 }
 
-func getUniqueRecipeKey(recipe Recipe) string {
+func getUniqueRecipeKey(recipe dataset.Recipe) string {
 	ing1, ing2 := recipe.Ingredient1, recipe.Ingredient2
 	if ing1 > ing2 {
 		ing1, ing2 = ing2, ing1
@@ -284,21 +295,36 @@ func getUniqueRecipeKey(recipe Recipe) string {
 	return fmt.Sprintf("%s+%s=>%s", ing1, ing2, recipe.Result)
 }
 
-func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int, error) {
-	fmt.Printf("Finding %d different BFS paths to: %s (Multithreaded)\n", maxRecipes, targetElement)
+func FindMultiplePathsBFS(d *dataset.Dataset, targetElement string, maxRecipes int) ([][]dataset.Recipe, int, error) {
+	return findMultiplePathsBFS(d, targetElement, maxRecipes, multiSearchOptions{})
+}
+
+// FindMultiplePathsBFSSeeded adalah versi deterministik FindMultiplePathsBFS:
+// worker dijalankan berurutan dengan pembagian strategi yang ditentukan seed,
+// sehingga seed yang sama selalu menghasilkan jalur dan urutan yang sama.
+func FindMultiplePathsBFSSeeded(d *dataset.Dataset, targetElement string, maxRecipes int, seed int64) ([][]dataset.Recipe, int, error) {
+	return findMultiplePathsBFS(d, targetElement, maxRecipes, multiSearchOptions{deterministic: true, seed: seed})
+}
 
-	graph := GetAlchemyGraph()
+func findMultiplePathsBFS(d *dataset.Dataset, targetElement string, maxRecipes int, opts multiSearchOptions) ([][]dataset.Recipe, int, error) {
+	if opts.deterministic {
+		fmt.Printf("Finding %d different BFS paths to: %s (Deterministic, seed %d)\n", maxRecipes, targetElement, opts.seed)
+	} else {
+		fmt.Printf("Finding %d different BFS paths to: %s (Multithreaded)\n", maxRecipes, targetElement)
+	}
+
+	graph := d.Graph()
 	if graph == nil {
-		return nil, 0, errors.New("alchemy graph not initialized")
+		return nil, 0, dataset.ErrNotInitialized
 	}
 	if maxRecipes <= 0 {
 		return nil, 0, errors.New("minimum number of recipes must be 1")
 	}
-	if isBaseElement(targetElement) {
-		return [][]Recipe{}, 0, nil
+	if d.IsBase(targetElement) {
+		return [][]dataset.Recipe{}, 0, nil
 	}
 
-	uniqueRecipeCombos, allCombinations := getAllUniqueRecipeCombinations(targetElement)
+	uniqueRecipeCombos, allCombinations := getAllUniqueRecipeCombinations(d, targetElement)
 	if uniqueRecipeCombos == 0 {
 		return nil, 0, fmt.Errorf("element '%s' not found in recipe database", targetElement)
 	}
@@ -316,33 +342,33 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 	}
 
 	if maxRecipes == 1 {
-		firstPath, visitCount, err := FindPathBFS(targetElement)
+		firstPath, visitCount, err := FindPathBFS(d, targetElement)
 		if err != nil {
 			return nil, visitCount, err
 		}
-		return [][]Recipe{firstPath}, visitCount, nil
+		return [][]dataset.Recipe{firstPath}, visitCount, nil
 	}
 
 	foundTargetCombinations := make(map[string]bool)
-	remainingCombinations := make(map[string]Recipe, uniqueRecipeCombos)
+	remainingCombinations := make(map[string]dataset.Recipe, uniqueRecipeCombos)
 	for comboKey, recipe := range allCombinations {
 		remainingCombinations[comboKey] = recipe
 	}
 
-	var allFoundPaths [][]Recipe
+	var allFoundPaths [][]dataset.Recipe
 	addedPathIdentifiers := make(map[string]bool)
 	var mu sync.Mutex
 	nodesVisitedCount := atomic.Int32{}
 
 	var wg sync.WaitGroup
-	pathChan := make(chan []Recipe, maxRecipes)
+	pathChan := make(chan []dataset.Recipe, maxRecipes)
 	done := atomic.Bool{}
 
-	firstPath, _, firstErr := FindPathBFS(targetElement)
+	firstPath, _, firstErr := FindPathBFS(d, targetElement)
 	if firstErr == nil && len(firstPath) > 0 {
-		pathID := generatePathIdentifier(firstPath)
+		pathID := PathIdentifier(firstPath)
 
-		var targetRecipe Recipe
+		var targetRecipe dataset.Recipe
 		for _, r := range firstPath {
 			if r.Result == targetElement {
 				targetRecipe = r
@@ -379,11 +405,13 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 		numWorkersPerCombo := 3
 
 		mu.Lock()
-		combinationsToSearch := make([]Recipe, 0, len(remainingCombinations))
+		combinationsToSearch := make([]dataset.Recipe, 0, len(remainingCombinations))
 		for _, recipe := range remainingCombinations {
 			combinationsToSearch = append(combinationsToSearch, recipe)
 		}
 		mu.Unlock()
+		opts.orderCombinations(combinationsToSearch)
+		strategyOffset := opts.strategyOffset()
 
 		for comboIdx, targetRecipe := range combinationsToSearch {
 			for w := 0; w < numWorkersPerCombo; w++ {
@@ -391,8 +419,7 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 					break
 				}
 
-				wg.Add(1)
-				go func(workerID int, comboIdx int, targetComboRecipe Recipe) {
+				comboWorker := func(workerID int, comboIdx int, targetComboRecipe dataset.Recipe) {
 					defer wg.Done()
 
 					comboKey := getUniqueRecipeKey(targetComboRecipe)
@@ -405,14 +432,14 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 						return
 					}
 
-					strategyVariant := (workerID + comboIdx) % 5
+					strategyVariant := (workerID + comboIdx + strategyOffset) % 5
 
 					fmt.Printf("Worker %d searching for combo %d: %s + %s => %s (strategy: %d)\n",
 						workerID, comboIdx,
 						targetComboRecipe.Ingredient1, targetComboRecipe.Ingredient2,
 						targetComboRecipe.Result, strategyVariant)
 
-					currentPath := findPathForSpecificCombination(
+					currentPath := findPathForSpecificCombination(d,
 						targetElement,
 						targetComboRecipe,
 						strategyVariant,
@@ -421,7 +448,7 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 					)
 
 					if len(currentPath) > 0 {
-						var foundTargetRecipe Recipe
+						var foundTargetRecipe dataset.Recipe
 						for _, r := range currentPath {
 							if r.Result == targetElement {
 								foundTargetRecipe = r
@@ -436,14 +463,14 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 							return
 						}
 
-						pathID := generatePathIdentifier(currentPath)
+						pathID := PathIdentifier(currentPath)
 
 						mu.Lock()
 						isNewCombo := !foundTargetCombinations[pathComboKey]
 						isNewPath := !addedPathIdentifiers[pathID]
 
 						if isNewCombo && isNewPath && len(allFoundPaths) < maxRecipes {
-							pathCopy := make([]Recipe, len(currentPath))
+							pathCopy := make([]dataset.Recipe, len(currentPath))
 							copy(pathCopy, currentPath)
 
 							allFoundPaths = append(allFoundPaths, pathCopy)
@@ -467,35 +494,45 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 						}
 						mu.Unlock()
 					}
-				}(w, comboIdx, targetRecipe)
+				}
+
+				wg.Add(1)
+				if opts.deterministic {
+					comboWorker(w, comboIdx, targetRecipe)
+				} else {
+					go comboWorker(w, comboIdx, targetRecipe)
+				}
 			}
 		}
 
 		if !shouldStop() {
 			additionalWorkers := runtime.NumCPU() * 2
+			if opts.deterministic {
+				// Jumlah worker tidak boleh bergantung pada mesin
+				additionalWorkers = deterministicBFSWorkers
+			}
 
 			for w := 0; w < additionalWorkers; w++ {
-				wg.Add(1)
-				go func(workerID int) {
+				explorer := func(workerID int) {
 					defer wg.Done()
 
-					strategyVariant := workerID % 5
+					strategyVariant := (workerID + strategyOffset) % 5
 					queue := list.New()
 					localVisited := make(map[string]bool)
-					parent := make(map[string]Recipe)
+					parent := make(map[string]dataset.Recipe)
 					discovered := make(map[string]bool)
 
-					startOffset := (workerID * 17) % len(baseElements)
-					for i := 0; i < len(baseElements); i++ {
-						idx := (startOffset + i) % len(baseElements)
-						base := baseElements[idx]
+					startOffset := (workerID * 17) % len(d.BaseElements())
+					for i := 0; i < len(d.BaseElements()); i++ {
+						idx := (startOffset + i) % len(d.BaseElements())
+						base := d.BaseElements()[idx]
 						queue.PushBack(base)
 						localVisited[base] = true
 						discovered[base] = true
 					}
 
 					depthMap := make(map[string]int)
-					for _, base := range baseElements {
+					for _, base := range d.BaseElements() {
 						depthMap[base] = 0
 					}
 
@@ -507,11 +544,7 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 						if nodesVisitedCount.Load()%1000 == 0 {
 							mu.Lock()
 							if len(remainingCombinations) > 0 && len(remainingCombinations) <= 3 {
-								var targetCombo Recipe
-								for _, recipe := range remainingCombinations {
-									targetCombo = recipe
-									break
-								}
+								targetCombo := firstRemainingCombination(remainingCombinations)
 								mu.Unlock()
 
 								ing1 := targetCombo.Ingredient1
@@ -543,7 +576,7 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 							}
 							localVisited[pairKey] = true
 
-							recipes := getRecipes(currentElement, otherElement)
+							recipes := getRecipes(d, currentElement, otherElement)
 
 							for _, recipe := range recipes {
 								if shouldStop() {
@@ -590,9 +623,9 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 										continue
 									}
 
-									currentPath := buildDiversePath(parent, targetElement, workerID)
+									currentPath := buildDiversePath(d, parent, targetElement, workerID)
 									if len(currentPath) > 0 {
-										var pathTargetRecipe Recipe
+										var pathTargetRecipe dataset.Recipe
 										for _, r := range currentPath {
 											if r.Result == targetElement {
 												pathTargetRecipe = r
@@ -601,14 +634,14 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 										}
 
 										pathComboKey := getUniqueRecipeKey(pathTargetRecipe)
-										pathID := generatePathIdentifier(currentPath)
+										pathID := PathIdentifier(currentPath)
 
 										mu.Lock()
 										isNewCombo := !foundTargetCombinations[pathComboKey]
 										isNewPath := !addedPathIdentifiers[pathID]
 
 										if isNewCombo && isNewPath && len(allFoundPaths) < maxRecipes {
-											pathCopy := make([]Recipe, len(currentPath))
+											pathCopy := make([]dataset.Recipe, len(currentPath))
 											copy(pathCopy, currentPath)
 
 											allFoundPaths = append(allFoundPaths, pathCopy)
@@ -635,7 +668,14 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 							}
 						}
 					}
-				}(w)
+				}
+
+				wg.Add(1)
+				if opts.deterministic {
+					explorer(w)
+				} else {
+					go explorer(w)
+				}
 			}
 		}
 	}
@@ -650,7 +690,7 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 	}
 
 	mu.Lock()
-	result := make([][]Recipe, len(allFoundPaths))
+	result := make([][]dataset.Recipe, len(allFoundPaths))
 	copy(result, allFoundPaths)
 
 	missingCount := len(remainingCombinations)
@@ -666,7 +706,11 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 	foundCombinations := len(foundTargetCombinations)
 	mu.Unlock()
 
-	if foundCount == 0 && !isBaseElement(targetElement) {
+	if opts.deterministic {
+		sortPathsCanonical(result)
+	}
+
+	if foundCount == 0 && !d.IsBase(targetElement) {
 		fmt.Printf("BFS Multiple: No paths found for '%s'.\n", targetElement)
 		return nil, int(nodesVisitedCount.Load()), fmt.Errorf("path to element '%s' not found", targetElement)
 	}
@@ -676,14 +720,28 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 	return result, int(nodesVisitedCount.Load()), nil
 }
 
-func getAllUniqueRecipeCombinations(element string) (int, map[string]Recipe) {
-	uniqueCombos := make(map[string]Recipe)
+// firstRemainingCombination memilih kombinasi dengan key terkecil agar pilihan
+// tidak bergantung pada urutan iterasi map.
+func firstRemainingCombination(remaining map[string]dataset.Recipe) dataset.Recipe {
+	bestKey := ""
+	var best dataset.Recipe
+	for comboKey, recipe := range remaining {
+		if bestKey == "" || comboKey < bestKey {
+			bestKey = comboKey
+			best = recipe
+		}
+	}
+	return best
+}
 
-	if isBaseElement(element) {
+func getAllUniqueRecipeCombinations(d *dataset.Dataset, element string) (int, map[string]dataset.Recipe) {
+	uniqueCombos := make(map[string]dataset.Recipe)
+
+	if d.IsBase(element) {
 		return 0, uniqueCombos
 	}
 
-	graph := GetAlchemyGraph()
+	graph := d.Graph()
 	if graph == nil {
 		return 0, uniqueCombos
 	}
@@ -700,19 +758,19 @@ func getAllUniqueRecipeCombinations(element string) (int, map[string]Recipe) {
 	return len(uniqueCombos), uniqueCombos
 }
 
-func findPathForSpecificCombination(targetElement string, targetRecipe Recipe,
-	strategyVariant int, nodesVisitedCount *atomic.Int32, shouldStop func() bool) []Recipe {
+func findPathForSpecificCombination(d *dataset.Dataset, targetElement string, targetRecipe dataset.Recipe,
+	strategyVariant int, nodesVisitedCount *atomic.Int32, shouldStop func() bool) []dataset.Recipe {
 
 	ing1 := targetRecipe.Ingredient1
 	ing2 := targetRecipe.Ingredient2
 
 	queue := list.New()
 	localVisited := make(map[string]bool)
-	parent := make(map[string]Recipe)
+	parent := make(map[string]dataset.Recipe)
 	discovered := make(map[string]bool)
 	depthMap := make(map[string]int)
 
-	for _, base := range baseElements {
+	for _, base := range d.BaseElements() {
 		queue.PushBack(base)
 		localVisited[base] = true
 		discovered[base] = true
@@ -730,7 +788,7 @@ func findPathForSpecificCombination(targetElement string, targetRecipe Recipe,
 				depthMap[targetElement] = max(depthMap[ing1], depthMap[ing2]) + 1
 				discovered[targetElement] = true
 
-				return buildDiversePath(parent, targetElement, strategyVariant)
+				return buildDiversePath(d, parent, targetElement, strategyVariant)
 			}
 		}
 
@@ -749,11 +807,11 @@ func findPathForSpecificCombination(targetElement string, targetRecipe Recipe,
 			}
 			localVisited[pairKey] = true
 
-			recipes := getRecipes(currentElement, otherElement)
+			recipes := getRecipes(d, currentElement, otherElement)
 
 			for _, recipe := range recipes {
 				if shouldStop() {
-					return []Recipe{}
+					return []dataset.Recipe{}
 				}
 
 				result := recipe.Result
@@ -786,7 +844,7 @@ func findPathForSpecificCombination(targetElement string, targetRecipe Recipe,
 		}
 	}
 
-	return []Recipe{}
+	return []dataset.Recipe{}
 }
 
 func sortElements(elements []string, strategyVariant int, depthMap map[string]int, seed int) {
@@ -832,7 +890,7 @@ func sortElements(elements []string, strategyVariant int, depthMap map[string]in
 	}
 }
 
-func buildDiversePath(parent map[string]Recipe, target string, workerID int) []Recipe {
+func buildDiversePath(d *dataset.Dataset, parent map[string]dataset.Recipe, target string, workerID int) []dataset.Recipe {
 	elementsNeeded := make(map[string]bool)
 	queue := list.New()
 	queue.PushBack(target)
@@ -843,17 +901,17 @@ func buildDiversePath(parent map[string]Recipe, target string, workerID int) []R
 	for queue.Len() > 0 {
 		current := queue.Remove(queue.Front()).(string)
 
-		if isBaseElement(current) {
+		if d.IsBase(current) {
 			continue
 		}
 
 		recipe, exists := parent[current]
 		if !exists {
-			return []Recipe{}
+			return []dataset.Recipe{}
 		}
 
 		for _, ingredient := range []string{recipe.Ingredient1, recipe.Ingredient2} {
-			if processed[ingredient] || isBaseElement(ingredient) {
+			if processed[ingredient] || d.IsBase(ingredient) {
 				continue
 			}
 
@@ -863,15 +921,15 @@ func buildDiversePath(parent map[string]Recipe, target string, workerID int) []R
 		}
 	}
 
-	var result []Recipe
+	var result []dataset.Recipe
 	available := make(map[string]bool)
 
-	for _, base := range baseElements {
+	for _, base := range d.BaseElements() {
 		available[base] = true
 	}
 
 	for !available[target] {
-		candidates := make([]Recipe, 0)
+		candidates := make([]dataset.Recipe, 0)
 
 		for element := range elementsNeeded {
 			if available[element] {
@@ -889,7 +947,7 @@ func buildDiversePath(parent map[string]Recipe, target string, workerID int) []R
 		}
 
 		if len(candidates) == 0 {
-			return []Recipe{}
+			return []dataset.Recipe{}
 		}
 
 		strategyVariant := workerID % 3
@@ -917,9 +975,3 @@ func buildDiversePath(parent map[string]Recipe, target string, workerID int) []R
 
 	return result
 }
-
-func ResetCaches() {
-	bfsPathCacheMutex.Lock()
-	bfsPathCache = make(map[string][]Recipe)
-	bfsPathCacheMutex.Unlock()
-}
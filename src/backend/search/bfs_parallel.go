@@ -0,0 +1,135 @@
+// src/backend/search/bfs_parallel.go
+package search
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/graph"
+)
+
+// parallelBFSMinChunk: jumlah minimum elemen frontier per goroutine, supaya
+// frontier kecil tidak dibayar dengan overhead goroutine.
+const parallelBFSMinChunk = 32
+
+// FindPathBFSParallel menjalankan BFS paralel pada graf kompak dataset d
+// dengan workers worker (workers <= 0 berarti runtime.NumCPU()).
+func FindPathBFSParallel(d *dataset.Dataset, targetElement string, workers int) ([]dataset.Recipe, int, error) {
+	g, err := compactOf(d)
+	if err != nil {
+		return nil, 0, err
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return g.FindPathBFSParallel(targetElement, workers)
+}
+
+// FindPathBFSParallel adalah BFS level-synchronous: seluruh frontier (satu
+// level antrian) diekspansi bersamaan oleh beberapa worker, lalu hasilnya
+// digabung berurutan. Jalur dan nodesVisited identik dengan FindPathBFS.
+//
+// Pada FindPathBFS, elemen yang di-dequeue hanya dipasangkan dengan elemen
+// yang sudah ditemukan sebelum dequeue tersebut (discoveredAt < snapshot),
+// dan hasil resep diambil oleh pasangan pertama dalam urutan antrian. Setiap
+// pasangan yang diproses menemukan semua hasil resepnya, jadi pairVisited
+// hanya optimasi dan tidak dibutuhkan di sini. Karena itu ekspansi dibagi
+// dua fase per level:
+//  1. Paralel: setiap worker menelusuri byIng elemen frontier bagiannya
+//     (indeks bahan yang sudah terurut) dan hanya menyimpan resep yang
+//     hasilnya belum ditemukan saat level dimulai. Dataset hanya dibaca.
+//  2. Berurutan: kandidat setiap elemen frontier diproses dengan urutan
+//     antrian, memeriksa ulang syarat pasangan dan hasil dengan state terkini.
+//     Fase ini hanya menyentuh resep yang mungkin menemukan elemen baru.
+func (g compactGraph) FindPathBFSParallel(targetElement string, workers int) ([]dataset.Recipe, int, error) {
+	target, ok := g.ID(targetElement)
+	if !ok {
+		target = graph.NoElement
+	}
+	path, nodesVisited, found := g.findPathBFSParallel(target, workers)
+	if !found {
+		return nil, nodesVisited, fmt.Errorf("path to element '%s' not found", targetElement)
+	}
+	return g.ToPath(path), nodesVisited, nil
+}
+
+func (g compactGraph) findPathBFSParallel(target uint32, workers int) ([]uint32, int, bool) {
+	if target != graph.NoElement && g.IsBase(target) {
+		return []uint32{}, 0, true
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	n := g.NumElements()
+	discovered := graph.NewBitset(n)
+	discoveredAt := make([]int32, n)
+	parent := make([]uint32, n)
+	depth := make([]int32, n)
+	for i := range parent {
+		parent[i] = graph.NoElement
+	}
+
+	var frontier []uint32
+	for id := 0; id < n; id++ {
+		if g.IsBase(uint32(id)) {
+			discovered.Set(uint32(id))
+			frontier = append(frontier, uint32(id))
+		}
+	}
+
+	nodesVisitedCount := 0
+	for len(frontier) > 0 {
+		// Fase 1: kumpulkan kandidat resep per elemen frontier secara paralel
+		candidates := make([][]uint32, len(frontier))
+		chunk := (len(frontier) + workers - 1) / workers
+		if chunk < parallelBFSMinChunk {
+			chunk = parallelBFSMinChunk
+		}
+		var wg sync.WaitGroup
+		for start := 0; start < len(frontier); start += chunk {
+			end := start + chunk
+			if end > len(frontier) {
+				end = len(frontier)
+			}
+			wg.Add(1)
+			go func(start, end int) {
+				defer wg.Done()
+				for i := start; i < end; i++ {
+					for _, ri := range g.RecipesUsing(frontier[i]) {
+						if !discovered.Has(g.Recipe(ri).Result) {
+							candidates[i] = append(candidates[i], ri)
+						}
+					}
+				}
+			}(start, end)
+		}
+		wg.Wait()
+
+		// Fase 2: gabungkan dengan urutan antrian
+		var next []uint32
+		for i, current := range frontier {
+			nodesVisitedCount++
+			snapshot := int32(nodesVisitedCount)
+			for _, ri := range candidates[i] {
+				r := g.Recipe(ri)
+				other := r.Other(current)
+				if !discovered.Has(other) || discoveredAt[other] >= snapshot || discovered.Has(r.Result) {
+					continue
+				}
+				discovered.Set(r.Result)
+				discoveredAt[r.Result] = snapshot
+				parent[r.Result] = ri
+				depth[r.Result] = depth[current] + 1
+				if r.Result == target {
+					return g.buildRecipePath(parent, target, depth), nodesVisitedCount, true
+				}
+				next = append(next, r.Result)
+			}
+		}
+		frontier = next
+	}
+	return nil, nodesVisitedCount, false
+}
@@ -0,0 +1,36 @@
+// src/backend/search/bfs_parallel_test.go
+package search
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestParallelBFSMatchesCompactBFS(t *testing.T) {
+	g, _ := compactOf(testDataset)
+	targets := append(sortedElementNames(), "Unobtainium")
+	for _, workers := range []int{1, 3, runtime.NumCPU()} {
+		for _, target := range targets {
+			want, wantNodes, wantErr := g.FindPathBFS(target)
+			got, gotNodes, gotErr := g.FindPathBFSParallel(target, workers)
+			if (wantErr == nil) != (gotErr == nil) {
+				t.Errorf("%s/%d worker: error berbeda: %v vs %v", target, workers, wantErr, gotErr)
+				continue
+			}
+			if !reflect.DeepEqual(want, got) || wantNodes != gotNodes {
+				t.Errorf("%s/%d worker: hasil berbeda\n  sekuensial: %d node %v\n  paralel   : %d node %v", target, workers, wantNodes, want, gotNodes, got)
+			}
+		}
+	}
+}
+
+func BenchmarkFindPathBFSParallel(b *testing.B) {
+	for _, bt := range benchTargets {
+		b.Run(benchName(bt.Tier, bt.Element), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FindPathBFSParallel(testDataset, bt.Element, 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,619 @@
+// src/backend/search/compact_search.go
+package search
+
+import (
+	"fmt"
+	"sort"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/graph"
+)
+
+// File ini berisi port BFS/DFS/BDS (bfs.go, dfs.go, bds.go) ke CompactGraph.
+// Logika pemilihan resep dan tie-break dibuat sama dengan versi string;
+// perbedaannya hanya di representasi (ID + graph.Bitset + CSR) dan tidak ada log
+// per-node, sehingga jauh lebih cepat untuk dataset penuh.
+
+// compactGraph menempelkan algoritma pencarian versi kompak ke
+// graph.CompactGraph; accessor graf tersedia lewat embedding.
+type compactGraph struct {
+	*graph.CompactGraph
+}
+
+// compactOf mengembalikan graf kompak milik dataset d.
+func compactOf(d *dataset.Dataset) (compactGraph, error) {
+	g := graph.Of(d)
+	if g == nil {
+		return compactGraph{}, graph.ErrNotInitialized
+	}
+	return compactGraph{g}, nil
+}
+
+// FindPathBFSCompact menjalankan BFS shortest pada graf kompak dataset d.
+func FindPathBFSCompact(d *dataset.Dataset, targetElement string) ([]dataset.Recipe, int, error) {
+	g, err := compactOf(d)
+	if err != nil {
+		return nil, 0, err
+	}
+	return g.FindPathBFS(targetElement)
+}
+
+// FindPathDFSCompact menjalankan DFS single pada graf kompak dataset d.
+func FindPathDFSCompact(d *dataset.Dataset, targetElement string) ([]dataset.Recipe, int, error) {
+	g, err := compactOf(d)
+	if err != nil {
+		return nil, 0, err
+	}
+	return g.FindPathDFS(targetElement)
+}
+
+// FindPathBDSCompact menjalankan hybrid BDS+BFS pada graf kompak dataset d.
+func FindPathBDSCompact(d *dataset.Dataset, targetElement string) ([]dataset.Recipe, int, error) {
+	g, err := compactOf(d)
+	if err != nil {
+		return nil, 0, err
+	}
+	return g.FindPathBDS(targetElement)
+}
+
+// --- BFS ---
+
+// FindPathBFS adalah port FindPathBFS ke graf kompak. Hasil jalur dan jumlah
+// node yang dikunjungi identik dengan versi string.
+//
+// Versi string memasangkan elemen yang di-dequeue dengan SELURUH elemen yang
+// sudah ditemukan (diurutkan ulang setiap dequeue). Di sini cukup menelusuri
+// resep yang memakai elemen tersebut (byIng, sudah terurut berdasarkan bahan
+// pasangan lalu hasil) dan menyaring pasangan yang sudah ditemukan sebelum
+// dequeue dimulai, sehingga urutan pemrosesan pasangan tetap sama.
+func (g compactGraph) FindPathBFS(targetElement string) ([]dataset.Recipe, int, error) {
+	// Elemen yang tidak ada di graf tetap ditelusuri sampai habis (graph.NoElement
+	// tidak akan pernah ditemukan), sama seperti versi string.
+	target, ok := g.ID(targetElement)
+	if !ok {
+		target = graph.NoElement
+	}
+	path, nodesVisited, found := g.findPathBFS(target)
+	if !found {
+		return nil, nodesVisited, fmt.Errorf("path to element '%s' not found", targetElement)
+	}
+	return g.ToPath(path), nodesVisited, nil
+}
+
+func (g compactGraph) findPathBFS(target uint32) ([]uint32, int, bool) {
+	if target != graph.NoElement && g.IsBase(target) {
+		return []uint32{}, 0, true
+	}
+
+	n := g.NumElements()
+	discovered := graph.NewBitset(n)
+	discoveredAt := make([]int32, n) // nodesVisitedCount saat elemen ditemukan
+	parent := make([]uint32, n)
+	depth := make([]int32, n)
+	pairVisited := graph.NewBitset(n * n)
+	queue := make([]uint32, 0, n)
+	for i := range parent {
+		parent[i] = graph.NoElement
+	}
+
+	// ID terurut alfabetis, jadi iterasi ID = iterasi base yang sudah di-sort
+	for id := 0; id < n; id++ {
+		if g.IsBase(uint32(id)) {
+			discovered.Set(uint32(id))
+			queue = append(queue, uint32(id))
+		}
+	}
+
+	nodesVisitedCount := 0
+	for head := 0; head < len(queue); head++ {
+		current := queue[head]
+		nodesVisitedCount++
+		snapshot := int32(nodesVisitedCount)
+
+		lastOther := graph.NoElement
+		pairActive := false
+		for _, ri := range g.RecipesUsing(current) {
+			r := g.Recipe(ri)
+			other := r.Other(current)
+			if other != lastOther {
+				lastOther = other
+				pairActive = false
+				if !discovered.Has(other) || discoveredAt[other] >= snapshot {
+					continue
+				}
+				pk := pairIndex(current, other, n)
+				if pairVisited.Has(pk) {
+					continue
+				}
+				pairVisited.Set(pk)
+				pairActive = true
+			}
+			if !pairActive || discovered.Has(r.Result) {
+				continue
+			}
+
+			discovered.Set(r.Result)
+			discoveredAt[r.Result] = snapshot
+			parent[r.Result] = ri
+			depth[r.Result] = depth[current] + 1
+			if r.Result == target {
+				return g.buildRecipePath(parent, target, depth), nodesVisitedCount, true
+			}
+			queue = append(queue, r.Result)
+		}
+	}
+	return nil, nodesVisitedCount, false
+}
+
+func pairIndex(a, b uint32, n int) uint32 {
+	if a > b {
+		a, b = b, a
+	}
+	return a*uint32(n) + b
+}
+
+// buildRecipePath adalah port buildRecipePath (bfs.go): mengumpulkan elemen
+// yang dibutuhkan target lalu mengurutkannya secara topologis dengan tie-break
+// kedalaman, jumlah pemakai, lalu nama.
+func (g compactGraph) buildRecipePath(parent []uint32, target uint32, depth []int32) []uint32 {
+	n := g.NumElements()
+	dependencies := make([]int32, n)
+	elementsNeeded := graph.NewBitset(n)
+	neededList := []uint32{target}
+	elementsNeeded.Set(target)
+
+	for head := 0; head < len(neededList); head++ {
+		current := neededList[head]
+		if g.IsBase(current) || parent[current] == graph.NoElement {
+			continue
+		}
+		r := g.Recipe(parent[current])
+		dependencies[r.Ing1]++
+		dependencies[r.Ing2]++
+		for _, ingredient := range []uint32{r.Ing1, r.Ing2} {
+			if !elementsNeeded.Has(ingredient) && !g.IsBase(ingredient) {
+				elementsNeeded.Set(ingredient)
+				neededList = append(neededList, ingredient)
+			}
+		}
+	}
+
+	result := make([]uint32, 0, len(neededList))
+	available := g.BaseSet()
+	remainingElements := len(neededList)
+	for remainingElements > 0 {
+		best := graph.NoElement
+		for _, el := range neededList {
+			if available.Has(el) || parent[el] == graph.NoElement {
+				continue
+			}
+			r := g.Recipe(parent[el])
+			if !available.Has(r.Ing1) || !available.Has(r.Ing2) {
+				continue
+			}
+			if best == graph.NoElement {
+				best = el
+				continue
+			}
+			if depth[el] != depth[best] {
+				if depth[el] < depth[best] {
+					best = el
+				}
+				continue
+			}
+			if dependencies[el] != dependencies[best] {
+				if dependencies[el] > dependencies[best] {
+					best = el
+				}
+				continue
+			}
+			if el < best {
+				best = el
+			}
+		}
+		if best == graph.NoElement {
+			break
+		}
+
+		result = append(result, parent[best])
+		available.Set(best)
+		remainingElements--
+		if available.Has(target) {
+			break
+		}
+	}
+	return result
+}
+
+// --- DFS ---
+
+// FindPathDFS adalah port FindPathDFS (dfs.go) ke graf kompak. Penanda
+// visited tidak lagi disalin di setiap rekursi: elemen ditandai di graph.Bitset
+// on-stack saat masuk dan dilepas saat keluar.
+func (g compactGraph) FindPathDFS(targetElement string) ([]dataset.Recipe, int, error) {
+	target, ok := g.ID(targetElement)
+	if !ok {
+		return nil, 0, fmt.Errorf("tidak ada jalur valid untuk membuat %s", targetElement)
+	}
+	if g.IsBase(target) {
+		return []dataset.Recipe{}, 0, nil
+	}
+
+	n := g.NumElements()
+	nodesVisitedCount := 0
+	onStack := graph.NewBitset(n)
+	pathCache := make([][]uint32, n)
+	cached := graph.NewBitset(n)
+
+	var buildOrderedPath func(el uint32, available graph.Bitset) ([]uint32, bool)
+	buildOrderedPath = func(el uint32, available graph.Bitset) ([]uint32, bool) {
+		nodesVisitedCount++
+		if g.IsBase(el) || available.Has(el) {
+			return []uint32{}, true
+		}
+
+		if cached.Has(el) {
+			if g.pathUsable(pathCache[el], available) {
+				return append([]uint32(nil), pathCache[el]...), true
+			}
+		}
+
+		if onStack.Has(el) {
+			return nil, false
+		}
+		onStack.Set(el)
+		defer onStack.Clear(el)
+
+		candidates := g.RecipesFor(el)
+		if len(candidates) == 0 {
+			return nil, false
+		}
+		recipes := make([]uint32, len(candidates))
+		copy(recipes, candidates)
+		sort.SliceStable(recipes, func(i, j int) bool {
+			iCanMake := g.recipeReady(recipes[i], available)
+			jCanMake := g.recipeReady(recipes[j], available)
+			if iCanMake != jCanMake {
+				return iCanMake
+			}
+			return g.baseIngredientCount(recipes[i]) > g.baseIngredientCount(recipes[j])
+		})
+
+		var bestPath []uint32
+		found := false
+		for _, ri := range recipes {
+			r := g.Recipe(ri)
+			elementsAvailable := available.Clone()
+
+			var path1, path2 []uint32
+			if !g.IsBase(r.Ing1) && !elementsAvailable.Has(r.Ing1) {
+				p, ok := buildOrderedPath(r.Ing1, elementsAvailable)
+				if !ok {
+					continue
+				}
+				path1 = p
+				g.markResults(path1, elementsAvailable)
+			}
+			if !g.IsBase(r.Ing2) && !elementsAvailable.Has(r.Ing2) {
+				p, ok := buildOrderedPath(r.Ing2, elementsAvailable)
+				if !ok {
+					continue
+				}
+				path2 = p
+				g.markResults(path2, elementsAvailable)
+			}
+			if !g.recipeReady(ri, elementsAvailable) {
+				continue
+			}
+
+			completePath := make([]uint32, 0, len(path1)+len(path2)+1)
+			completePath = append(completePath, path1...)
+			completePath = append(completePath, path2...)
+			completePath = append(completePath, ri)
+			if !found || len(completePath) < len(bestPath) {
+				bestPath = completePath
+				found = true
+			}
+		}
+
+		if found {
+			pathCache[el] = append([]uint32(nil), bestPath...)
+			cached.Set(el)
+		}
+		return bestPath, found
+	}
+
+	optimalPath, found := buildOrderedPath(target, g.BaseSet())
+	if !found {
+		return nil, nodesVisitedCount, fmt.Errorf("tidak ada jalur valid untuk membuat %s", targetElement)
+	}
+
+	// Hilangkan duplikat dan elemen yang dibuat ulang (setara FindPathDFS)
+	return normalizePath(g.ToPath(optimalPath), targetElement), nodesVisitedCount, nil
+}
+
+// recipeReady memeriksa apakah kedua bahan resep sudah tersedia.
+func (g compactGraph) recipeReady(ri uint32, available graph.Bitset) bool {
+	r := g.Recipe(ri)
+	return (g.IsBase(r.Ing1) || available.Has(r.Ing1)) && (g.IsBase(r.Ing2) || available.Has(r.Ing2))
+}
+
+func (g compactGraph) baseIngredientCount(ri uint32) int {
+	r := g.Recipe(ri)
+	count := 0
+	if g.IsBase(r.Ing1) {
+		count++
+	}
+	if g.IsBase(r.Ing2) {
+		count++
+	}
+	return count
+}
+
+// pathUsable memeriksa apakah jalur (dari cache) bisa dijalankan berurutan
+// dengan elemen yang tersedia saat ini.
+func (g compactGraph) pathUsable(path []uint32, available graph.Bitset) bool {
+	clonedAvailable := available.Clone()
+	for _, ri := range path {
+		if !g.recipeReady(ri, clonedAvailable) {
+			return false
+		}
+		clonedAvailable.Set(g.Recipe(ri).Result)
+	}
+	return true
+}
+
+func (g compactGraph) markResults(path []uint32, available graph.Bitset) {
+	for _, ri := range path {
+		available.Set(g.Recipe(ri).Result)
+	}
+}
+
+// --- BDS ---
+
+// recipeKey adalah padanan getUniqueRecipeKey untuk resep kompak.
+type recipeKey struct {
+	result, lo, hi uint32
+}
+
+func (g compactGraph) keyOf(ri uint32) recipeKey {
+	r := g.Recipe(ri)
+	lo, hi := r.Ing1, r.Ing2
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return recipeKey{r.Result, lo, hi}
+}
+
+// FindPathBDS adalah port FindPathBDS (bds.go) ke graf kompak: pencarian
+// dua arah dengan level disimpan di array, lalu bahan yang belum tercakup
+// dilengkapi dengan BFS kompak.
+func (g compactGraph) FindPathBDS(targetElement string) ([]dataset.Recipe, int, error) {
+	target, ok := g.ID(targetElement)
+	if !ok {
+		return nil, 0, fmt.Errorf("jalur (BDS meeting) ke '%s' tidak ditemukan", targetElement)
+	}
+	if g.IsBase(target) {
+		return []dataset.Recipe{}, 0, nil
+	}
+
+	n := g.NumElements()
+	nodesVisitedCount := 0
+	visitedForward := make([]int32, n)
+	parentForward := make([]uint32, n)
+	visitedBackward := make([]int32, n)
+	parentBackward := make([]uint32, n)
+	for i := 0; i < n; i++ {
+		parentForward[i] = graph.NoElement
+		parentBackward[i] = graph.NoElement
+	}
+
+	queueForward := make([]uint32, 0, n)
+	for _, id := range g.BaseIDs() {
+		if visitedForward[id] == 0 {
+			queueForward = append(queueForward, id)
+			visitedForward[id] = 1
+		}
+	}
+	queueBackward := []uint32{target}
+	visitedBackward[target] = 1
+
+	currentLevelForward := int32(1)
+	currentLevelBackward := int32(1)
+	meetingNode := graph.NoElement
+
+	for len(queueForward) > 0 && len(queueBackward) > 0 && meetingNode == graph.NoElement {
+		// Langkah maju
+		lenF := len(queueForward)
+		for i := 0; i < lenF && meetingNode == graph.NoElement; i++ {
+			currF := queueForward[0]
+			queueForward = queueForward[1:]
+			nodesVisitedCount++
+			if visitedBackward[currF] > 0 {
+				meetingNode = currF
+			}
+			for _, ri := range g.RecipesUsing(currF) {
+				r := g.Recipe(ri)
+				otherIng := r.Other(currF)
+				if visitedForward[otherIng] > 0 && visitedForward[otherIng] <= currentLevelForward {
+					if visitedForward[r.Result] == 0 {
+						visitedForward[r.Result] = currentLevelForward + 1
+						parentForward[r.Result] = ri
+						queueForward = append(queueForward, r.Result)
+						if visitedBackward[r.Result] > 0 && meetingNode == graph.NoElement {
+							meetingNode = r.Result
+						}
+					}
+				}
+			}
+		}
+		if meetingNode != graph.NoElement {
+			break
+		}
+		currentLevelForward++
+
+		// Langkah mundur
+		lenB := len(queueBackward)
+		for i := 0; i < lenB && meetingNode == graph.NoElement; i++ {
+			currB := queueBackward[0]
+			queueBackward = queueBackward[1:]
+			nodesVisitedCount++
+			if visitedForward[currB] > 0 {
+				meetingNode = currB
+			}
+			recipesMakingCurrB := g.RecipesFor(currB)
+			if parentBackward[currB] == graph.NoElement && len(recipesMakingCurrB) > 0 {
+				parentBackward[currB] = recipesMakingCurrB[0]
+			}
+			for _, ri := range recipesMakingCurrB {
+				r := g.Recipe(ri)
+				for _, ing := range []uint32{r.Ing1, r.Ing2} {
+					if visitedBackward[ing] == 0 {
+						visitedBackward[ing] = currentLevelBackward + 1
+						queueBackward = append(queueBackward, ing)
+						if visitedForward[ing] > 0 && meetingNode == graph.NoElement {
+							meetingNode = ing
+						}
+					}
+				}
+			}
+		}
+		if meetingNode != graph.NoElement {
+			break
+		}
+		currentLevelBackward++
+	}
+
+	if meetingNode == graph.NoElement {
+		return nil, nodesVisitedCount, fmt.Errorf("jalur (BDS meeting) ke '%s' tidak ditemukan", targetElement)
+	}
+
+	finalRecipe := parentBackward[target]
+	if finalRecipe == graph.NoElement {
+		recipesForTarget := g.RecipesFor(target)
+		if len(recipesForTarget) == 0 {
+			return nil, nodesVisitedCount, fmt.Errorf("resep final untuk '%s' tidak ditemukan", targetElement)
+		}
+		finalRecipe = recipesForTarget[0]
+	}
+	ing1, ing2 := g.Recipe(finalRecipe).Ing1, g.Recipe(finalRecipe).Ing2
+
+	combinedRecipes := make(map[recipeKey]uint32)
+	addAll := func(path []uint32) {
+		for _, ri := range path {
+			combinedRecipes[g.keyOf(ri)] = ri
+		}
+	}
+	searchIngredient := func(ing uint32) error {
+		path, bfsNodes, found := g.findPathBFS(ing)
+		nodesVisitedCount += bfsNodes
+		if !found {
+			return fmt.Errorf("gagal mencari jalur BFS untuk bahan '%s': path to element '%s' not found", g.Name(ing), g.Name(ing))
+		}
+		addAll(path)
+		return nil
+	}
+
+	if meetingNode == ing1 || meetingNode == ing2 {
+		ingredientToSearchBFS := ing1
+		if meetingNode == ing1 {
+			ingredientToSearchBFS = ing2
+		}
+		addAll(g.reconstructForwardTree(parentForward, meetingNode))
+		if err := searchIngredient(ingredientToSearchBFS); err != nil {
+			return nil, nodesVisitedCount, err
+		}
+	} else {
+		if err := searchIngredient(ing1); err != nil {
+			return nil, nodesVisitedCount, err
+		}
+		if err := searchIngredient(ing2); err != nil {
+			return nil, nodesVisitedCount, err
+		}
+		addAll(g.reconstructForwardTree(parentForward, meetingNode))
+	}
+	combinedRecipes[g.keyOf(finalRecipe)] = finalRecipe
+
+	path := normalizePath(g.ToPath(g.buildSortedPathFromRecipes(combinedRecipes, target)), targetElement)
+	if len(path) == 0 {
+		return nil, nodesVisitedCount, fmt.Errorf("jalur terurut tidak menghasilkan target '%s'", targetElement)
+	}
+	return path, nodesVisitedCount, nil
+}
+
+// reconstructForwardTree adalah port fungsi bernama sama di bds.go.
+func (g compactGraph) reconstructForwardTree(parentMap []uint32, node uint32) []uint32 {
+	var recipes []uint32
+	visited := graph.NewBitset(g.NumElements())
+	var collect func(el uint32)
+	collect = func(el uint32) {
+		if visited.Has(el) || g.IsBase(el) {
+			return
+		}
+		visited.Set(el)
+		ri := parentMap[el]
+		if ri == graph.NoElement {
+			return
+		}
+		collect(g.Recipe(ri).Ing1)
+		collect(g.Recipe(ri).Ing2)
+		recipes = append(recipes, ri)
+	}
+	collect(node)
+	return recipes
+}
+
+// buildSortedPathFromRecipes adalah port fungsi bernama sama di bds.go:
+// setiap putaran menambahkan semua resep yang bahannya sudah tersedia.
+func (g compactGraph) buildSortedPathFromRecipes(recipes map[recipeKey]uint32, target uint32) []uint32 {
+	if len(recipes) == 0 {
+		return []uint32{}
+	}
+
+	remaining := make([]uint32, 0, len(recipes))
+	involved := make(map[uint32]bool)
+	for _, ri := range recipes {
+		remaining = append(remaining, ri)
+		r := g.Recipe(ri)
+		involved[r.Ing1] = true
+		involved[r.Ing2] = true
+		involved[r.Result] = true
+	}
+	sort.Slice(remaining, func(i, j int) bool {
+		ri, rj := g.Recipe(remaining[i]), g.Recipe(remaining[j])
+		if ri.Result != rj.Result {
+			return ri.Result < rj.Result
+		}
+		return remaining[i] < remaining[j]
+	})
+
+	available := graph.NewBitset(g.NumElements())
+	for id := range involved {
+		if g.IsBase(id) {
+			available.Set(id)
+		}
+	}
+
+	sortedPath := make([]uint32, 0, len(recipes))
+	maxIterations := len(recipes)*2 + 10
+	for iterations := 0; !available.Has(target) && iterations < maxIterations; iterations++ {
+		var candidates, rest []uint32
+		for _, ri := range remaining {
+			r := g.Recipe(ri)
+			if available.Has(r.Ing1) && available.Has(r.Ing2) {
+				candidates = append(candidates, ri)
+			} else {
+				rest = append(rest, ri)
+			}
+		}
+		if len(candidates) == 0 {
+			return sortedPath
+		}
+		for _, ri := range candidates {
+			sortedPath = append(sortedPath, ri)
+			available.Set(g.Recipe(ri).Result)
+		}
+		remaining = rest
+	}
+	return sortedPath
+}
@@ -0,0 +1,150 @@
+// src/backend/search/compact_search_test.go
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/graph"
+)
+
+// BFS kompak harus menghasilkan jalur dan jumlah node yang sama persis dengan
+// FindPathBFS. Versi string lambat, jadi diuji pada sampel lintas tier.
+func TestCompactBFSMatchesBFS(t *testing.T) {
+	targets := []string{"Time"} // elemen tanpa resep
+	for _, bt := range benchTargets {
+		targets = append(targets, bt.Element)
+	}
+	for i, name := range sortedElementNames() {
+		if i%15 == 0 {
+			targets = append(targets, name)
+		}
+	}
+
+	defer silenceStdout()()
+	for _, target := range targets {
+		testDataset.ResetCache()
+		want, wantNodes, wantErr := FindPathBFS(testDataset, target)
+		got, gotNodes, gotErr := FindPathBFSCompact(testDataset, target)
+		if (wantErr == nil) != (gotErr == nil) {
+			t.Errorf("%s: error berbeda: %v vs %v", target, wantErr, gotErr)
+			continue
+		}
+		if !reflect.DeepEqual(want, got) || wantNodes != gotNodes {
+			t.Errorf("%s: hasil berbeda\n  string : %d node %v\n  kompak : %d node %v", target, wantNodes, want, gotNodes, got)
+		}
+	}
+}
+
+func TestCompactSearchesReachEveryElement(t *testing.T) {
+	g, _ := compactOf(testDataset)
+	searches := map[string]func(string) ([]dataset.Recipe, int, error){
+		"bfs": g.FindPathBFS,
+		"dfs": g.FindPathDFS,
+		"bds": g.FindPathBDS,
+	}
+	for result := range testDataset.RecipeMap() {
+		for name, search := range searches {
+			path, _, err := search(result)
+			if err != nil || len(path) == 0 {
+				t.Errorf("%s(%s): jalur tidak ditemukan: %v", name, result, err)
+			}
+		}
+	}
+}
+
+func BenchmarkFindPathBFSCompact(b *testing.B) {
+	for _, bt := range benchTargets {
+		b.Run(benchName(bt.Tier, bt.Element), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FindPathBFSCompact(testDataset, bt.Element)
+			}
+		})
+	}
+}
+
+func BenchmarkFindPathDFSCompact(b *testing.B) {
+	for _, bt := range benchTargets {
+		b.Run(benchName(bt.Tier, bt.Element), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FindPathDFSCompact(testDataset, bt.Element)
+			}
+		})
+	}
+}
+
+func BenchmarkFindPathBDSCompact(b *testing.B) {
+	for _, bt := range benchTargets {
+		b.Run(benchName(bt.Tier, bt.Element), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FindPathBDSCompact(testDataset, bt.Element)
+			}
+		})
+	}
+}
+
+// Graf kompak dan versi map harus sepakat: jalur ditemukan untuk target
+// yang sama, dengan panjang yang sama, dan setiap jalur valid serta memuat
+// semua elemen wajib target (graph.MandatoryElements).
+func TestCompactSearchesMatchMapSearches(t *testing.T) {
+	tests := []struct {
+		target    string
+		wantFound bool
+	}{
+		{"Fire", true},         // elemen dasar: jalur kosong tanpa error
+		{"Time", false},        // elemen tanpa resep, tidak bisa dibuat
+		{"Unobtainium", false}, // elemen tidak dikenal
+		{"Mud", true},
+		{"Metal", true},
+		{"Human", true},
+		{"Internet", true},
+		{"Picnic", true},
+	}
+	searches := []struct {
+		name            string
+		mapped, compact func(*dataset.Dataset, string) ([]dataset.Recipe, int, error)
+	}{
+		{"bfs", FindPathBFS, FindPathBFSCompact},
+		{"dfs", FindPathDFS, FindPathDFSCompact},
+		{"bds", FindPathBDS, FindPathBDSCompact},
+	}
+
+	defer silenceStdout()()
+	for _, tt := range tests {
+		for _, s := range searches {
+			testDataset.ResetCache()
+			want, _, wantErr := s.mapped(testDataset, tt.target)
+			got, _, gotErr := s.compact(testDataset, tt.target)
+			wantFound := wantErr == nil && (len(want) > 0 || testDataset.IsBase(tt.target))
+			gotFound := gotErr == nil && (len(got) > 0 || testDataset.IsBase(tt.target))
+			if wantFound != tt.wantFound || gotFound != tt.wantFound {
+				t.Errorf("%s(%s): ditemukan map=%t (%v) kompak=%t (%v), ingin %t", s.name, tt.target, wantFound, wantErr, gotFound, gotErr, tt.wantFound)
+				continue
+			}
+			if !tt.wantFound {
+				continue
+			}
+			if len(want) != len(got) {
+				t.Errorf("%s(%s): panjang jalur map %d, kompak %d", s.name, tt.target, len(want), len(got))
+			}
+			if testDataset.IsBase(tt.target) {
+				continue
+			}
+			mandatory, err := graph.MandatoryElements(testDataset, tt.target)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.target, err)
+			}
+			for label, path := range map[string][]dataset.Recipe{"map": want, "kompak": got} {
+				if err := ValidatePath(testDataset, path, tt.target); err != nil {
+					t.Errorf("%s %s(%s): %v", label, s.name, tt.target, err)
+				}
+				for _, el := range mandatory {
+					if !testDataset.IsBase(el) && !pathContains(path, el) {
+						t.Errorf("%s %s(%s): elemen wajib %s tidak ada di jalur", label, s.name, tt.target, el)
+					}
+				}
+			}
+		}
+	}
+}
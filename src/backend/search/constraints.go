@@ -0,0 +1,294 @@
+// src/backend/search/constraints.go
+package search
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"tubes2stima/backend/dataset"
+)
+
+// MaxRequiredElements membatasi jumlah elemen require: graf produk berukuran
+// hingga 2^k salinan tiap elemen.
+const MaxRequiredElements = 3
+
+// requireTag memisahkan nama elemen dari daftar elemen wajib yang sudah
+// tercakup pada graf produk, misalnya "Clay ⟨via Metal⟩".
+const requireTag = " ⟨via "
+
+// SearchConstraints berisi batasan pencarian dari parameter avoid= dan require=.
+type SearchConstraints struct {
+	Avoid   []string
+	Require []string
+}
+
+// IsEmpty bernilai true jika tidak ada batasan sama sekali.
+func (c SearchConstraints) IsEmpty() bool {
+	return len(c.Avoid) == 0 && len(c.Require) == 0
+}
+
+// Without membuat view dataset tanpa resep yang menghasilkan atau memakai
+// salah satu elemen di avoid.
+func Without(d *dataset.Dataset, avoid []string) *dataset.Dataset {
+	if len(avoid) == 0 {
+		return d
+	}
+	avoided := make(map[string]bool, len(avoid))
+	for _, el := range avoid {
+		avoided[el] = true
+	}
+	return d.Filter(func(r dataset.Recipe) bool {
+		return !avoided[r.Result] && !avoided[r.Ingredient1] && !avoided[r.Ingredient2]
+	})
+}
+
+// requiredNodeName memberi nama simpul graf produk untuk element dengan
+// himpunan elemen wajib mask. Elemen wajib yang sama dengan element sendiri
+// tidak ditulis, sehingga mask minimal setiap elemen memakai nama aslinya.
+func requiredNodeName(element string, mask uint, required []string) string {
+	var covered []string
+	for i, req := range required {
+		if mask&(1<<uint(i)) != 0 && req != element {
+			covered = append(covered, req)
+		}
+	}
+	if len(covered) == 0 {
+		return element
+	}
+	return element + requireTag + strings.Join(covered, ", ") + "⟩"
+}
+
+// stripRequireTag mengembalikan nama elemen asli dari nama simpul graf produk.
+func stripRequireTag(name string) string {
+	if i := strings.Index(name, requireTag); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// Through membuat graf produk untuk batasan require. Setiap simpul adalah
+// pasangan (elemen, himpunan elemen wajib di pohonnya); resep X = A + B
+// menghasilkan (X, SA ∪ SB ∪ {X}) dari setiap pasangan (A, SA) dan (B, SB)
+// yang bisa dibuat. Pohon resep untuk simpul target bertanda semua elemen
+// wajib pasti melewati semuanya, dan setiap pohon seperti itu ada di graf
+// ini, jadi semua algoritma bisa dijalankan apa adanya.
+func Through(d *dataset.Dataset, required []string) *dataset.Dataset {
+	bit := make(map[string]uint, len(required))
+	for i, req := range required {
+		bit[req] = 1 << uint(i)
+	}
+
+	// masks[elemen] = mask yang sudah bisa dibuat, urut sesuai penemuan
+	// agar urutan resep graf produk deterministik
+	masks := make(map[string][]uint)
+	hasMask := make(map[string]map[uint]bool)
+	for _, base := range d.BaseElements() {
+		masks[base] = []uint{bit[base]}
+		hasMask[base] = map[uint]bool{bit[base]: true}
+	}
+
+	keys := make([]string, 0, len(d.RecipeMap()))
+	for result := range d.RecipeMap() {
+		keys = append(keys, result)
+	}
+	sort.Strings(keys)
+
+	// Fixpoint: ulangi sampai tidak ada pasangan (elemen, mask) baru
+	seenRecipe := make(map[dataset.Recipe]bool)
+	product := make(map[string][]dataset.Recipe)
+	for changed := true; changed; {
+		changed = false
+		for _, result := range keys {
+			for _, r := range d.RecipeMap()[result] {
+				for _, m1 := range masks[r.Ingredient1] {
+					for _, m2 := range masks[r.Ingredient2] {
+						mask := m1 | m2 | bit[result]
+						step := dataset.Recipe{
+							Result:      requiredNodeName(result, mask, required),
+							Ingredient1: requiredNodeName(r.Ingredient1, m1, required),
+							Ingredient2: requiredNodeName(r.Ingredient2, m2, required),
+						}
+						if seenRecipe[step] {
+							continue
+						}
+						seenRecipe[step] = true
+						product[step.Result] = append(product[step.Result], step)
+						if hasMask[result] == nil {
+							hasMask[result] = make(map[uint]bool)
+						}
+						if !hasMask[result][mask] {
+							hasMask[result][mask] = true
+							masks[result] = append(masks[result], mask)
+							changed = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return dataset.New(product, d.BaseElements())
+}
+
+// mergeRequiredPath mengubah jalur di graf produk kembali menjadi jalur
+// biasa. Satu elemen bisa muncul lebih dari sekali dengan tanda berbeda;
+// pohon dibangun ulang dari target dengan memilih resep bertanda paling
+// lengkap lebih dulu dan mundur ke resep lain jika terjadi siklus.
+func mergeRequiredPath(d *dataset.Dataset, path []dataset.Recipe, target string) []dataset.Recipe {
+	type producer struct {
+		recipe  dataset.Recipe
+		covered int
+	}
+	producers := make(map[string][]producer)
+	for _, step := range path {
+		covered := 0
+		if i := strings.Index(step.Result, requireTag); i >= 0 {
+			covered = strings.Count(step.Result[i:], ",") + 1
+		}
+		plain := dataset.Recipe{
+			Result:      stripRequireTag(step.Result),
+			Ingredient1: stripRequireTag(step.Ingredient1),
+			Ingredient2: stripRequireTag(step.Ingredient2),
+		}
+		producers[plain.Result] = append(producers[plain.Result], producer{plain, covered})
+	}
+	for el := range producers {
+		sort.SliceStable(producers[el], func(i, j int) bool {
+			return producers[el][i].covered > producers[el][j].covered
+		})
+	}
+
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make(map[string]int)
+	var merged []dataset.Recipe
+	var expand func(el string) bool
+	expand = func(el string) bool {
+		if d.IsBase(el) || state[el] == done {
+			return true
+		}
+		if state[el] == onStack {
+			return false
+		}
+		state[el] = onStack
+		for _, p := range producers[el] {
+			if expand(p.recipe.Ingredient1) && expand(p.recipe.Ingredient2) {
+				merged = append(merged, p.recipe)
+				state[el] = done
+				return true
+			}
+		}
+		state[el] = unvisited
+		return false
+	}
+	if !expand(target) {
+		return nil
+	}
+	// Langkah dari percobaan yang gagal dibuang di sini
+	return normalizePath(merged, target)
+}
+
+// pathContains memeriksa apakah element muncul di jalur (sebagai hasil atau bahan).
+func pathContains(path []dataset.Recipe, element string) bool {
+	for _, r := range path {
+		if r.Result == element || r.Ingredient1 == element || r.Ingredient2 == element {
+			return true
+		}
+	}
+	return false
+}
+
+// satisfiesRequire memeriksa apakah jalur untuk target memuat semua elemen required.
+func satisfiesRequire(path []dataset.Recipe, target string, require []string) bool {
+	for _, el := range require {
+		if el != target && !pathContains(path, el) {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchWithConstraints menjalankan run(view, target) pada view dataset yang
+// memenuhi batasan. Avoid diterapkan dengan membuang resep terlarang. Untuk
+// require, pencarian dicoba dulu pada view avoid; jika ada jalur yang tidak
+// melewati elemen wajib, pencarian diulang pada graf produk (lihat Through)
+// dan hasilnya dipetakan kembali ke nama elemen asli. nodesVisited
+// dijumlahkan dari semua percobaan.
+func SearchWithConstraints(d *dataset.Dataset, target string, c SearchConstraints, run func(d *dataset.Dataset, target string) ([][]dataset.Recipe, int, error)) ([][]dataset.Recipe, int, error) {
+	if d == nil {
+		return nil, 0, dataset.ErrNotInitialized
+	}
+	if len(c.Require) > MaxRequiredElements {
+		return nil, 0, fmt.Errorf("paling banyak %d elemen require", MaxRequiredElements)
+	}
+	for _, el := range c.Avoid {
+		if el == target {
+			return nil, 0, fmt.Errorf("target '%s' tidak boleh ada di avoid", target)
+		}
+		for _, req := range c.Require {
+			if el == req {
+				return nil, 0, fmt.Errorf("'%s' tidak bisa sekaligus di avoid dan require", el)
+			}
+		}
+	}
+
+	view := Without(d, c.Avoid)
+	if !d.IsBase(target) && len(view.RecipeMap()[target]) == 0 {
+		return nil, 0, fmt.Errorf("'%s' tidak bisa dibuat tanpa %s", target, strings.Join(c.Avoid, ", "))
+	}
+
+	paths, nodesVisited, err := run(view, target)
+	if len(c.Require) == 0 {
+		if err != nil && len(c.Avoid) > 0 {
+			err = fmt.Errorf("tidak ada jalur ke '%s' tanpa %s: %w", target, strings.Join(c.Avoid, ", "), err)
+		}
+		return paths, nodesVisited, err
+	}
+	if err == nil && len(paths) > 0 {
+		allSatisfied := true
+		for _, path := range paths {
+			if !satisfiesRequire(path, target, c.Require) {
+				allSatisfied = false
+				break
+			}
+		}
+		if allSatisfied {
+			return paths, nodesVisited, nil
+		}
+	}
+
+	unreachable := fmt.Errorf("'%s' tidak bisa dibuat melalui %s", target, strings.Join(c.Require, ", "))
+	through := Through(view, c.Require)
+	fullMask := uint(1)<<uint(len(c.Require)) - 1
+	productTarget := requiredNodeName(target, fullMask, c.Require)
+	if d.IsBase(target) || len(through.RecipeMap()[productTarget]) == 0 {
+		return nil, nodesVisited, unreachable
+	}
+	productPaths, nodes, err := run(through, productTarget)
+	nodesVisited += nodes
+	if err != nil {
+		return nil, nodesVisited, fmt.Errorf("%v: %w", unreachable, err)
+	}
+
+	var result [][]dataset.Recipe
+	seen := make(map[string]bool)
+	for _, productPath := range productPaths {
+		path := mergeRequiredPath(d, productPath, target)
+		if path == nil || !satisfiesRequire(path, target, c.Require) {
+			continue
+		}
+		id := PathIdentifier(path)
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, path)
+		}
+	}
+	if len(result) == 0 {
+		return nil, nodesVisited, unreachable
+	}
+	sort.SliceStable(result, func(i, j int) bool { return len(result[i]) < len(result[j]) })
+	return result, nodesVisited, nil
+}
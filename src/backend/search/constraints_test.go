@@ -0,0 +1,65 @@
+// src/backend/search/constraints_test.go
+package search
+
+import (
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+func TestSearchWithConstraintsAllAlgorithms(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	cases := []struct {
+		target      string
+		constraints SearchConstraints
+	}{
+		{"Human", SearchConstraints{Avoid: []string{"Swamp"}}},
+		{"Human", SearchConstraints{Require: []string{"Metal"}}},
+		{"Brick", SearchConstraints{Avoid: []string{"Stone"}, Require: []string{"Fire"}}},
+	}
+	for _, tc := range cases {
+		for _, algo := range []string{"bfs", "dfs", "bds", "kbest"} {
+			for _, mode := range []string{"shortest", "multiple"} {
+				run := func(d *dataset.Dataset, target string) ([][]dataset.Recipe, int, error) {
+					return Run(d, DefaultCostTable(), algo, mode, target, 3, nil)
+				}
+				paths, _, err := SearchWithConstraints(testDataset, tc.target, tc.constraints, run)
+				if err != nil {
+					t.Errorf("%s %s/%s %+v: %v", tc.target, algo, mode, tc.constraints, err)
+					continue
+				}
+				if len(paths) == 0 {
+					t.Errorf("%s %s/%s %+v: tidak ada jalur", tc.target, algo, mode, tc.constraints)
+				}
+				for _, path := range paths {
+					if err := ValidatePath(testDataset, path, tc.target); err != nil {
+						t.Errorf("%s %s/%s: jalur tidak valid: %v", tc.target, algo, mode, err)
+					}
+					for _, el := range tc.constraints.Avoid {
+						if pathContains(path, el) {
+							t.Errorf("%s %s/%s: jalur memakai '%s'", tc.target, algo, mode, el)
+						}
+					}
+					if !satisfiesRequire(path, tc.target, tc.constraints.Require) {
+						t.Errorf("%s %s/%s: jalur tidak melewati %v", tc.target, algo, mode, tc.constraints.Require)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSearchWithConstraintsUnreachable(t *testing.T) {
+	run := func(d *dataset.Dataset, target string) ([][]dataset.Recipe, int, error) {
+		return Run(d, DefaultCostTable(), "bfs", "shortest", target, 1, nil)
+	}
+	// Steam hanya bisa dibuat dari Water (langsung atau lewat turunannya)
+	if _, _, err := SearchWithConstraints(testDataset, "Steam", SearchConstraints{Avoid: []string{"Water"}}, run); err == nil {
+		t.Error("Steam tanpa Water seharusnya error")
+	}
+	if _, _, err := SearchWithConstraints(testDataset, "Steam", SearchConstraints{Require: []string{"Human"}}, run); err == nil {
+		t.Error("Steam melalui Human seharusnya error")
+	}
+}
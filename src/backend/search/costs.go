@@ -0,0 +1,224 @@
+// src/backend/search/costs.go
+package search
+
+import (
+	"container/heap"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/graph"
+)
+
+// CostTableFile adalah nama file tabel biaya (opsional) di direktori data.
+const CostTableFile = "recipe_costs.json"
+
+// RecipeCost adalah biaya khusus untuk satu resep (urutan bahan diabaikan).
+type RecipeCost struct {
+	dataset.Recipe
+	Cost float64 `json:"cost"`
+}
+
+// CostTable berisi biaya resep untuk mode cheapest. Biaya sebuah resep
+// diambil dari RecipeCosts jika ada, lalu ElementCosts[hasil], lalu tier
+// hasil jika TierCosts, lalu DefaultCost. Semua biaya harus >= 0.
+type CostTable struct {
+	Description  string             `json:"description,omitempty"`
+	DefaultCost  float64            `json:"defaultCost"`
+	TierCosts    bool               `json:"tierCosts,omitempty"` // Biaya default = tier hasil
+	ElementCosts map[string]float64 `json:"elementCosts,omitempty"`
+	RecipeCosts  []RecipeCost       `json:"recipeCosts,omitempty"`
+
+	recipeIndex map[string]float64 // getUniqueRecipeKey -> biaya
+	tiers       map[string]int     // Tier elemen, hanya diisi jika TierCosts
+}
+
+// DefaultCostTable: semua resep berbiaya 1, sehingga cheapest = jumlah langkah.
+func DefaultCostTable() *CostTable {
+	return &CostTable{DefaultCost: 1}
+}
+
+// LoadCostTable membaca tabel biaya dari filePath. File yang tidak ada tidak
+// dianggap error: tabel default dikembalikan. Jika tabel memakai tierCosts,
+// tier dihitung sekali di sini dari recipes dan baseElements.
+func LoadCostTable(filePath string, recipes []dataset.Recipe, baseElements []string) (*CostTable, error) {
+	bytes, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultCostTable(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca file %s: %w", filePath, err)
+	}
+	table := DefaultCostTable() // defaultCost tetap 1 jika tidak ditulis di file
+	if err := json.Unmarshal(bytes, table); err != nil {
+		return nil, fmt.Errorf("gagal unmarshal JSON biaya dari %s: %w", filePath, err)
+	}
+	if err := table.index(); err != nil {
+		return nil, fmt.Errorf("tabel biaya %s tidak valid: %w", filePath, err)
+	}
+	if table.TierCosts {
+		table.tiers, _ = dataset.ElementTiers(recipes, baseElements)
+	}
+	return table, nil
+}
+
+// index memvalidasi biaya dan membangun recipeIndex.
+func (t *CostTable) index() error {
+	validCost := func(c float64) bool { return c >= 0 && !math.IsInf(c, 0) && !math.IsNaN(c) }
+	if !validCost(t.DefaultCost) {
+		return fmt.Errorf("defaultCost %v harus >= 0", t.DefaultCost)
+	}
+	for element, c := range t.ElementCosts {
+		if !validCost(c) {
+			return fmt.Errorf("biaya elemen '%s' (%v) harus >= 0", element, c)
+		}
+	}
+	t.recipeIndex = make(map[string]float64, len(t.RecipeCosts))
+	for _, rc := range t.RecipeCosts {
+		if !validCost(rc.Cost) {
+			return fmt.Errorf("biaya resep %s (%v) harus >= 0", getUniqueRecipeKey(rc.Recipe), rc.Cost)
+		}
+		t.recipeIndex[getUniqueRecipeKey(rc.Recipe)] = rc.Cost
+	}
+	return nil
+}
+
+// Cost mengembalikan biaya satu resep. Nama simpul graf produk require
+// (lihat constraints.go) dikembalikan ke nama asli terlebih dahulu.
+func (t *CostTable) Cost(r dataset.Recipe) float64 {
+	plain := dataset.Recipe{
+		Result:      stripRequireTag(r.Result),
+		Ingredient1: stripRequireTag(r.Ingredient1),
+		Ingredient2: stripRequireTag(r.Ingredient2),
+	}
+	if c, ok := t.recipeIndex[getUniqueRecipeKey(plain)]; ok {
+		return c
+	}
+	if c, ok := t.ElementCosts[plain.Result]; ok {
+		return c
+	}
+	if tier, ok := t.tiers[plain.Result]; ok {
+		return float64(tier)
+	}
+	return t.DefaultCost
+}
+
+// PathCosts mengembalikan biaya tiap langkah jalur dan totalnya.
+func (t *CostTable) PathCosts(path []dataset.Recipe) ([]float64, float64) {
+	stepCosts := make([]float64, len(path))
+	total := 0.0
+	for i, step := range path {
+		stepCosts[i] = t.Cost(step)
+		total += stepCosts[i]
+	}
+	return stepCosts, total
+}
+
+// cheapestItem adalah kandidat biaya untuk satu elemen di antrian prioritas.
+type cheapestItem struct {
+	cost    float64
+	element uint32
+	recipe  uint32
+}
+
+type cheapestQueue []cheapestItem
+
+func (q cheapestQueue) Len() int { return len(q) }
+func (q cheapestQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	if q[i].element != q[j].element {
+		return q[i].element < q[j].element
+	}
+	return q[i].recipe < q[j].recipe
+}
+func (q cheapestQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *cheapestQueue) Push(x interface{}) { *q = append(*q, x.(cheapestItem)) }
+func (q *cheapestQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// FindCheapestPath menjalankan algoritma Knuth (generalisasi Dijkstra untuk
+// graf AND/OR): biaya elemen dasar 0, dan biaya elemen lain adalah minimum
+// dari biaya resep + biaya kedua bahannya. Elemen difinalisasi dengan urutan
+// biaya naik, dan sebuah resep baru dievaluasi setelah kedua bahannya final,
+// sehingga siklus resep aman. Yang diminimalkan adalah biaya pohon derivasi
+// (bahan yang dipakai dua kali dihitung dua kali); jalur yang dikembalikan
+// membuat setiap elemen sekali, jadi totalnya <= biaya pohon tersebut.
+// nodesVisited = jumlah elemen yang difinalisasi.
+func FindCheapestPath(d *dataset.Dataset, targetElement string, costs *CostTable) ([]dataset.Recipe, int, error) {
+	g, err := compactOf(d)
+	if err != nil {
+		return nil, 0, err
+	}
+	if d.IsBase(targetElement) {
+		return []dataset.Recipe{}, 0, nil
+	}
+	target, ok := g.ID(targetElement)
+	if !ok {
+		return nil, 0, fmt.Errorf("path to element '%s' not found", targetElement)
+	}
+
+	n := g.NumElements()
+	best := make([]float64, n)
+	parent := make([]uint32, n)
+	depth := make([]int32, n)
+	for i := range parent {
+		parent[i] = graph.NoElement
+	}
+	recipeCost := make([]float64, g.NumRecipes())
+	for i := range recipeCost {
+		recipeCost[i] = costs.Cost(g.ToRecipe(uint32(i)))
+	}
+
+	final := graph.NewBitset(n)
+	queue := &cheapestQueue{}
+	for _, base := range d.BaseElements() {
+		if id, ok := g.ID(base); ok {
+			heap.Push(queue, cheapestItem{cost: 0, element: id, recipe: graph.NoElement})
+		}
+	}
+
+	nodesVisited := 0
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(cheapestItem)
+		if final.Has(item.element) {
+			continue
+		}
+		final.Set(item.element)
+		best[item.element] = item.cost
+		parent[item.element] = item.recipe
+		if item.recipe != graph.NoElement {
+			r := g.Recipe(item.recipe)
+			h := depth[r.Ing1]
+			if depth[r.Ing2] > h {
+				h = depth[r.Ing2]
+			}
+			depth[item.element] = h + 1
+		}
+		nodesVisited++
+		if item.element == target {
+			return g.ToPath(g.buildRecipePath(parent, target, depth)), nodesVisited, nil
+		}
+
+		for _, ri := range g.RecipesUsing(item.element) {
+			r := g.Recipe(ri)
+			if final.Has(r.Result) || !final.Has(r.Ing1) || !final.Has(r.Ing2) {
+				continue
+			}
+			heap.Push(queue, cheapestItem{
+				cost:    recipeCost[ri] + best[r.Ing1] + best[r.Ing2],
+				element: r.Result,
+				recipe:  ri,
+			})
+		}
+	}
+	return nil, nodesVisited, fmt.Errorf("path to element '%s' not found", targetElement)
+}
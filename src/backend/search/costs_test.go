@@ -0,0 +1,138 @@
+// src/backend/search/costs_test.go
+package search
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+// fixpointTreeCosts menghitung biaya pohon termurah setiap elemen dengan
+// relaksasi berulang (Bellman-Ford) sebagai pembanding algoritma Knuth.
+func fixpointTreeCosts(costs *CostTable) map[string]float64 {
+	best := make(map[string]float64)
+	for _, base := range dataset.BaseElements {
+		best[base] = 0
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, r := range testDataset.Recipes() {
+			c1, ok1 := best[r.Ingredient1]
+			c2, ok2 := best[r.Ingredient2]
+			if !ok1 || !ok2 {
+				continue
+			}
+			c := costs.Cost(r) + c1 + c2
+			if current, ok := best[r.Result]; !ok || c < current-1e-9 {
+				best[r.Result] = c
+				changed = true
+			}
+		}
+	}
+	return best
+}
+
+// treeCost menghitung biaya pohon derivasi jalur (bahan yang dipakai dua
+// kali dihitung dua kali).
+func treeCost(path []dataset.Recipe, element string, costs *CostTable) float64 {
+	for _, step := range path {
+		if step.Result == element {
+			return costs.Cost(step) + treeCost(path, step.Ingredient1, costs) + treeCost(path, step.Ingredient2, costs)
+		}
+	}
+	return 0
+}
+
+func TestCheapestPathIsOptimal(t *testing.T) {
+	for name, costs := range map[string]*CostTable{"unit": DefaultCostTable(), "file": testCosts} {
+		want := fixpointTreeCosts(costs)
+		for _, element := range sortedElementNames() {
+			if testDataset.IsBase(element) {
+				continue
+			}
+			path, _, err := FindCheapestPath(testDataset, element, costs)
+			expected, reachable := want[element]
+			if !reachable {
+				if err == nil {
+					t.Errorf("%s/%s: tidak bisa dibuat tetapi jalur ditemukan", name, element)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s/%s: %v", name, element, err)
+				continue
+			}
+			if err := ValidatePath(testDataset, path, element); err != nil {
+				t.Errorf("%s/%s: %v", name, element, err)
+			}
+			if got := treeCost(path, element, costs); math.Abs(got-expected) > 1e-9 {
+				t.Errorf("%s/%s: biaya pohon = %v, ingin %v", name, element, got, expected)
+			}
+		}
+	}
+}
+
+func TestLoadCostTable(t *testing.T) {
+	dir := t.TempDir()
+
+	table, err := LoadCostTable(filepath.Join(dir, "tidak-ada.json"), testDataset.Recipes(), dataset.BaseElements)
+	if err != nil || table.DefaultCost != 1 {
+		t.Fatalf("file tidak ada: table=%+v err=%v", table, err)
+	}
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	table, err = LoadCostTable(write("ok.json", `{
+		"elementCosts": {"Mud": 4},
+		"recipeCosts": [{"result": "Steam", "ingredient1": "Water", "ingredient2": "Fire", "cost": 2.5}]
+	}`), testDataset.Recipes(), dataset.BaseElements)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checks := []struct {
+		recipe dataset.Recipe
+		want   float64
+	}{
+		{dataset.Recipe{Result: "Steam", Ingredient1: "Fire", Ingredient2: "Water"}, 2.5},
+		{dataset.Recipe{Result: "Mud", Ingredient1: "Earth", Ingredient2: "Water"}, 4},
+		{dataset.Recipe{Result: "Mud" + requireTag + "Fire⟩", Ingredient1: "Earth", Ingredient2: "Water"}, 4},
+		{dataset.Recipe{Result: "Dust", Ingredient1: "Air", Ingredient2: "Earth"}, 1},
+	}
+	for _, c := range checks {
+		if got := table.Cost(c.recipe); got != c.want {
+			t.Errorf("Cost(%v) = %v, ingin %v", c.recipe, got, c.want)
+		}
+	}
+
+	if _, err := LoadCostTable(write("negatif.json", `{"elementCosts": {"Mud": -1}}`), testDataset.Recipes(), dataset.BaseElements); err == nil {
+		t.Error("biaya negatif seharusnya error")
+	}
+
+	// tierCosts: biaya default = tier hasil, elementCosts tetap menimpa
+	table, err = LoadCostTable(write("tier.json", `{"tierCosts": true, "elementCosts": {"Mud": 4}}`), testDataset.Recipes(), dataset.BaseElements)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tierChecks := []struct {
+		recipe dataset.Recipe
+		want   float64
+	}{
+		{dataset.Recipe{Result: "Mud", Ingredient1: "Earth", Ingredient2: "Water"}, 4},
+		{dataset.Recipe{Result: "Dust", Ingredient1: "Air", Ingredient2: "Earth"}, 1},
+		{dataset.Recipe{Result: "Human" + requireTag + "Fire⟩", Ingredient1: "Clay", Ingredient2: "Life"}, 7}, // lihat benchTargets
+		{dataset.Recipe{Result: "Unobtainium", Ingredient1: "Air", Ingredient2: "Air"}, 1},                    // tanpa tier: defaultCost
+	}
+	for _, c := range tierChecks {
+		if got := table.Cost(c.recipe); got != c.want {
+			t.Errorf("tier: Cost(%v) = %v, ingin %v", c.recipe, got, c.want)
+		}
+	}
+}
@@ -1,38 +1,40 @@
-// src/backend/dfs.go
-package main
+// src/backend/search/dfs.go
+package search
 
 import (
 	//"container/list" // Digunakan oleh reconstructPathRevised (jika FindPathDFS masih pakai)
 	"errors"
 	"fmt"
-	"sort" // Diperlukan untuk generatePathIdentifier jika dipindah ke sini
-	"strings" // Diperlukan untuk generatePathIdentifier jika dipindah ke sini
+	"sort" // Diperlukan untuk PathIdentifier jika dipindah ke sini
+	"strings" // Diperlukan untuk PathIdentifier jika dipindah ke sini
 	"sync" // Import sync untuk Mutex dan WaitGroup
+
+	"tubes2stima/backend/dataset"
 )
 
-func FindPathDFS(targetElement string) ([]Recipe, int, error) {
+func FindPathDFS(d *dataset.Dataset, targetElement string) ([]dataset.Recipe, int, error) {
     fmt.Printf("Mencari jalur DFS (single) ke: %s\n", targetElement)
 
     // Persiapan
-    recipeMap := GetRecipeMap()
+    recipeMap := d.RecipeMap()
     if recipeMap == nil {
         return nil, 0, errors.New("map resep belum diinisialisasi")
     }
     
-    if isBaseElementDFS(targetElement) {
-        return []Recipe{}, 0, nil // Target adalah elemen dasar
+    if d.IsBase(targetElement) {
+        return []dataset.Recipe{}, 0, nil // Target adalah elemen dasar
     }
     
     nodesVisitedCount := 0
     
     // Cache untuk elemen yang bisa dibuat
     knownCreatableElements := make(map[string]bool)
-    for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
+    for _, base := range d.BaseElements() {
         knownCreatableElements[base] = true
     }
     
     // Cache untuk jalur ke setiap elemen
-    pathCache := make(map[string][]Recipe)
+    pathCache := make(map[string][]dataset.Recipe)
     
     // Fungsi untuk memeriksa apakah elemen bisa dibuat
     var isCreatable func(element string, visited map[string]bool, depth int) bool
@@ -45,7 +47,7 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
         }
         
         // Base case 1: Jika elemen dasar
-        if isBaseElementDFS(element) {
+        if d.IsBase(element) {
             return true
         }
         
@@ -92,12 +94,12 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
     }
     
     // Fungsi untuk membuat jalur berurutan dengan semua prasyarat (bottom-up DFS)
-    var buildOrderedPath func(target string, availableElements map[string]bool, visited map[string]bool) []Recipe
-    buildOrderedPath = func(target string, availableElements map[string]bool, visited map[string]bool) []Recipe {
+    var buildOrderedPath func(target string, availableElements map[string]bool, visited map[string]bool) []dataset.Recipe
+    buildOrderedPath = func(target string, availableElements map[string]bool, visited map[string]bool) []dataset.Recipe {
         nodesVisitedCount++
         // Jika elemen dasar atau sudah tersedia, tidak perlu membuat
-        if isBaseElementDFS(target) || availableElements[target] {
-            return []Recipe{}
+        if d.IsBase(target) || availableElements[target] {
+            return []dataset.Recipe{}
         }
         
         // Cek apakah ada di cache
@@ -111,11 +113,11 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
             // Cek apakah jalur dari cache valid dengan elemen yang tersedia saat ini
             valid := true
             for _, recipe := range path {
-                if !isBaseElementDFS(recipe.Ingredient1) && !clonedAvailable[recipe.Ingredient1] {
+                if !d.IsBase(recipe.Ingredient1) && !clonedAvailable[recipe.Ingredient1] {
                     valid = false
                     break
                 }
-                if !isBaseElementDFS(recipe.Ingredient2) && !clonedAvailable[recipe.Ingredient2] {
+                if !d.IsBase(recipe.Ingredient2) && !clonedAvailable[recipe.Ingredient2] {
                     valid = false
                     break
                 }
@@ -124,7 +126,7 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
             
             if valid {
                 // Jalur cache valid, gunakan
-                pathCopy := make([]Recipe, len(path))
+                pathCopy := make([]dataset.Recipe, len(path))
                 copy(pathCopy, path)
                 return pathCopy
             }
@@ -144,17 +146,18 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
         newVisited[target] = true
         
         // Cari resep yang bisa membuat target
-        recipes := recipeMap[target]
+        // Salin dulu agar pengurutan tidak mengubah recipeMap dataset
+        recipes := append([]dataset.Recipe(nil), recipeMap[target]...)
         if len(recipes) == 0 {
             return nil
         }
         
         // Urutkan resep (prioritaskan yang bisa langsung dibuat)
         sort.Slice(recipes, func(i, j int) bool {
-            iCanMake := (isBaseElementDFS(recipes[i].Ingredient1) || availableElements[recipes[i].Ingredient1]) &&
-                        (isBaseElementDFS(recipes[i].Ingredient2) || availableElements[recipes[i].Ingredient2])
-            jCanMake := (isBaseElementDFS(recipes[j].Ingredient1) || availableElements[recipes[j].Ingredient1]) &&
-                        (isBaseElementDFS(recipes[j].Ingredient2) || availableElements[recipes[j].Ingredient2])
+            iCanMake := (d.IsBase(recipes[i].Ingredient1) || availableElements[recipes[i].Ingredient1]) &&
+                        (d.IsBase(recipes[i].Ingredient2) || availableElements[recipes[i].Ingredient2])
+            jCanMake := (d.IsBase(recipes[j].Ingredient1) || availableElements[recipes[j].Ingredient1]) &&
+                        (d.IsBase(recipes[j].Ingredient2) || availableElements[recipes[j].Ingredient2])
             
             if iCanMake && !jCanMake {
                 return true
@@ -167,16 +170,16 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
             iBaseCount := 0
             jBaseCount := 0
             
-            if isBaseElementDFS(recipes[i].Ingredient1) {
+            if d.IsBase(recipes[i].Ingredient1) {
                 iBaseCount++
             }
-            if isBaseElementDFS(recipes[i].Ingredient2) {
+            if d.IsBase(recipes[i].Ingredient2) {
                 iBaseCount++
             }
-            if isBaseElementDFS(recipes[j].Ingredient1) {
+            if d.IsBase(recipes[j].Ingredient1) {
                 jBaseCount++
             }
-            if isBaseElementDFS(recipes[j].Ingredient2) {
+            if d.IsBase(recipes[j].Ingredient2) {
                 jBaseCount++
             }
             
@@ -188,7 +191,7 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
         })
         
         // Cari jalur terbaik
-        var bestPath []Recipe
+        var bestPath []dataset.Recipe
         
         for _, recipe := range recipes {
             // Buat salinan daftar elemen yang tersedia
@@ -201,8 +204,8 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
             // Kita akan memastikan bahan pertama dibuat, lalu bahan kedua
             
             // 1. Cek dan buat bahan pertama jika perlu
-            var path1 []Recipe
-            if !isBaseElementDFS(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1] {
+            var path1 []dataset.Recipe
+            if !d.IsBase(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1] {
                 path1 = buildOrderedPath(recipe.Ingredient1, elementsAvailable, newVisited)
                 if path1 == nil {
                     continue // Tidak bisa membuat bahan pertama, coba resep lain
@@ -215,8 +218,8 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
             }
             
             // 2. Cek dan buat bahan kedua jika perlu
-            var path2 []Recipe
-            if !isBaseElementDFS(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2] {
+            var path2 []dataset.Recipe
+            if !d.IsBase(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2] {
                 path2 = buildOrderedPath(recipe.Ingredient2, elementsAvailable, newVisited)
                 if path2 == nil {
                     continue // Tidak bisa membuat bahan kedua, coba resep lain
@@ -231,13 +234,13 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
             // 3. Buat target dengan resep saat ini
             
             // Cek sekali lagi apakah kedua bahan tersedia (karena loop mungkin terjadi)
-            if (!isBaseElementDFS(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1]) ||
-               (!isBaseElementDFS(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2]) {
+            if (!d.IsBase(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1]) ||
+               (!d.IsBase(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2]) {
                 continue // Ada masalah dengan ketersediaan bahan, coba resep lain
             }
             
             // Rangkai jalur lengkap: buat bahan 1, buat bahan 2, buat target
-            completePath := make([]Recipe, 0)
+            completePath := make([]dataset.Recipe, 0)
             
             if path1 != nil {
                 completePath = append(completePath, path1...)
@@ -257,7 +260,7 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
         
         // Simpan hasil ke cache jika ditemukan jalur
         if bestPath != nil {
-            pathCopy := make([]Recipe, len(bestPath))
+            pathCopy := make([]dataset.Recipe, len(bestPath))
             copy(pathCopy, bestPath)
             pathCache[target] = pathCopy
         }
@@ -266,10 +269,10 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
     }
     
     // Helper untuk menghapus resep duplikat dalam path
-    //var removeDuplicateRecipes func(path []Recipe) []Recipe
-    var removeDuplicateRecipes = func(path []Recipe) []Recipe {
+    //var removeDuplicateRecipes func(path []dataset.Recipe) []dataset.Recipe
+    var removeDuplicateRecipes = func(path []dataset.Recipe) []dataset.Recipe {
         seen := make(map[string]bool)
-        unique := make([]Recipe, 0, len(path))
+        unique := make([]dataset.Recipe, 0, len(path))
         
         for _, recipe := range path {
             key := fmt.Sprintf("%s:%s+%s", recipe.Result, recipe.Ingredient1, recipe.Ingredient2)
@@ -284,7 +287,7 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
     
     // Tentukan elemen dasar yang tersedia
     availableElements := make(map[string]bool)
-    for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
+    for _, base := range d.BaseElements() {
         availableElements[base] = true
     }
     
@@ -296,29 +299,13 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
         return nil, nodesVisitedCount, fmt.Errorf("tidak ada jalur valid untuk membuat %s", targetElement)
     }
     
-    // Hilangkan duplikat
-    optimalPath = removeDuplicateRecipes(optimalPath)
-    
-    // Verifikasi jalur optimal
-    available := make(map[string]bool)
-    for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
-        available[base] = true
-    }
+    // Hilangkan duplikat, termasuk elemen yang dibuat ulang oleh jalur dari pathCache
+    optimalPath = normalizePath(removeDuplicateRecipes(optimalPath), targetElement)
     
-    for i, recipe := range optimalPath {
-        // Debug: cek prasyarat tersedia
-        if !isBaseElementDFS(recipe.Ingredient1) && !available[recipe.Ingredient1] {
-            fmt.Printf("PERINGATAN: Jalur optimal - bahan %s tidak tersedia pada langkah %d\n", 
-                       recipe.Ingredient1, i+1)
-        }
-        
-        if !isBaseElementDFS(recipe.Ingredient2) && !available[recipe.Ingredient2] {
-            fmt.Printf("PERINGATAN: Jalur optimal - bahan %s tidak tersedia pada langkah %d\n", 
-                       recipe.Ingredient2, i+1)
-        }
-        
-        // Tandai hasil sebagai tersedia
-        available[recipe.Result] = true
+    // Verifikasi jalur optimal: jalur yang tidak valid tidak dikembalikan
+    if err := ValidatePath(d, optimalPath, targetElement); err != nil {
+        fmt.Printf("PERINGATAN: %v\n", err)
+        return nil, nodesVisitedCount, err
     }
     
     // PERUBAHAN: Balik urutan jalur sebelum menampilkan & mengembalikan
@@ -336,32 +323,38 @@ func FindPathDFS(targetElement string) ([]Recipe, int, error) {
 }
 
 
-func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int, error) {
+func FindMultiplePathsDFS(d *dataset.Dataset, targetElement string, maxRecipes int) ([][]dataset.Recipe, int, error) {
+    return findMultiplePathsDFS(d, targetElement, maxRecipes, multiSearchOptions{})
+}
+
+// findMultiplePathsDFS: pada mode deterministik jalur alternatif dicari
+// berurutan sehingga pathCache dan jumlah node selalu terisi dengan urutan sama.
+func findMultiplePathsDFS(d *dataset.Dataset, targetElement string, maxRecipes int, opts multiSearchOptions) ([][]dataset.Recipe, int, error) {
     fmt.Printf("Mencari %d jalur DFS BERBEDA ke: %s dengan multithreading (Super Robust)\n", maxRecipes, targetElement)
 
     // Akses data yang diperlukan
-    recipeMap := GetRecipeMap()
+    recipeMap := d.RecipeMap()
     if recipeMap == nil {
         return nil, 0, errors.New("map resep belum diinisialisasi")
     }
     if maxRecipes <= 0 {
         return nil, 0, errors.New("jumlah resep minimal harus 1")
     }
-    if isBaseElementDFS(targetElement) {
-        return [][]Recipe{}, 0, nil
+    if d.IsBase(targetElement) {
+        return [][]dataset.Recipe{}, 0, nil
     }
 
     nodesVisitedCount := 0
     
     // Cache untuk elemen yang bisa dibuat
     knownCreatableElements := make(map[string]bool)
-    for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
+    for _, base := range d.BaseElements() {
         knownCreatableElements[base] = true
     }
     var knownCreatableMutex sync.RWMutex
 
     // Cache untuk jalur ke setiap elemen
-    pathCache := make(map[string][]Recipe)
+    pathCache := make(map[string][]dataset.Recipe)
     var pathCacheMutex sync.RWMutex
     
     // Fungsi untuk memeriksa apakah elemen bisa dibuat
@@ -375,7 +368,7 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
         }
         
         // Base case 1: Jika elemen dasar
-        if isBaseElementDFS(element) {
+        if d.IsBase(element) {
             return true
         }
         
@@ -431,12 +424,12 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
     }
     
     // Fungsi untuk membuat jalur berurutan dengan semua prasyarat (bottom-up DFS)
-    var buildOrderedPath func(target string, availableElements map[string]bool, visited map[string]bool) []Recipe
-    buildOrderedPath = func(target string, availableElements map[string]bool, visited map[string]bool) []Recipe {
+    var buildOrderedPath func(target string, availableElements map[string]bool, visited map[string]bool) []dataset.Recipe
+    buildOrderedPath = func(target string, availableElements map[string]bool, visited map[string]bool) []dataset.Recipe {
         nodesVisitedCount++
         // Jika elemen dasar atau sudah tersedia, tidak perlu membuat
-        if isBaseElementDFS(target) || availableElements[target] {
-            return []Recipe{}
+        if d.IsBase(target) || availableElements[target] {
+            return []dataset.Recipe{}
         }
         
         // Cek apakah ada di cache
@@ -453,11 +446,11 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
             // Cek apakah jalur dari cache valid dengan elemen yang tersedia saat ini
             valid := true
             for _, recipe := range path {
-                if !isBaseElementDFS(recipe.Ingredient1) && !clonedAvailable[recipe.Ingredient1] {
+                if !d.IsBase(recipe.Ingredient1) && !clonedAvailable[recipe.Ingredient1] {
                     valid = false
                     break
                 }
-                if !isBaseElementDFS(recipe.Ingredient2) && !clonedAvailable[recipe.Ingredient2] {
+                if !d.IsBase(recipe.Ingredient2) && !clonedAvailable[recipe.Ingredient2] {
                     valid = false
                     break
                 }
@@ -466,7 +459,7 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
             
             if valid {
                 // Jalur cache valid, gunakan
-                pathCopy := make([]Recipe, len(path))
+                pathCopy := make([]dataset.Recipe, len(path))
                 copy(pathCopy, path)
                 return pathCopy
             }
@@ -488,17 +481,18 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
         newVisited[target] = true
         
         // Cari resep yang bisa membuat target
-        recipes := recipeMap[target]
+        // Salin dulu agar pengurutan tidak mengubah recipeMap dataset
+        recipes := append([]dataset.Recipe(nil), recipeMap[target]...)
         if len(recipes) == 0 {
             return nil
         }
         
         // Urutkan resep (prioritaskan yang bisa langsung dibuat)
         sort.Slice(recipes, func(i, j int) bool {
-            iCanMake := (isBaseElementDFS(recipes[i].Ingredient1) || availableElements[recipes[i].Ingredient1]) &&
-                        (isBaseElementDFS(recipes[i].Ingredient2) || availableElements[recipes[i].Ingredient2])
-            jCanMake := (isBaseElementDFS(recipes[j].Ingredient1) || availableElements[recipes[j].Ingredient1]) &&
-                        (isBaseElementDFS(recipes[j].Ingredient2) || availableElements[recipes[j].Ingredient2])
+            iCanMake := (d.IsBase(recipes[i].Ingredient1) || availableElements[recipes[i].Ingredient1]) &&
+                        (d.IsBase(recipes[i].Ingredient2) || availableElements[recipes[i].Ingredient2])
+            jCanMake := (d.IsBase(recipes[j].Ingredient1) || availableElements[recipes[j].Ingredient1]) &&
+                        (d.IsBase(recipes[j].Ingredient2) || availableElements[recipes[j].Ingredient2])
             
             if iCanMake && !jCanMake {
                 return true
@@ -511,16 +505,16 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
             iBaseCount := 0
             jBaseCount := 0
             
-            if isBaseElementDFS(recipes[i].Ingredient1) {
+            if d.IsBase(recipes[i].Ingredient1) {
                 iBaseCount++
             }
-            if isBaseElementDFS(recipes[i].Ingredient2) {
+            if d.IsBase(recipes[i].Ingredient2) {
                 iBaseCount++
             }
-            if isBaseElementDFS(recipes[j].Ingredient1) {
+            if d.IsBase(recipes[j].Ingredient1) {
                 jBaseCount++
             }
-            if isBaseElementDFS(recipes[j].Ingredient2) {
+            if d.IsBase(recipes[j].Ingredient2) {
                 jBaseCount++
             }
             
@@ -532,7 +526,7 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
         })
         
         // Cari jalur terbaik
-        var bestPath []Recipe
+        var bestPath []dataset.Recipe
         
         for _, recipe := range recipes {
             // Buat salinan daftar elemen yang tersedia
@@ -545,8 +539,8 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
             // Kita akan memastikan bahan pertama dibuat, lalu bahan kedua
             
             // 1. Cek dan buat bahan pertama jika perlu
-            var path1 []Recipe
-            if !isBaseElementDFS(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1] {
+            var path1 []dataset.Recipe
+            if !d.IsBase(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1] {
                 path1 = buildOrderedPath(recipe.Ingredient1, elementsAvailable, newVisited)
                 if path1 == nil {
                     continue // Tidak bisa membuat bahan pertama, coba resep lain
@@ -559,8 +553,8 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
             }
             
             // 2. Cek dan buat bahan kedua jika perlu
-            var path2 []Recipe
-            if !isBaseElementDFS(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2] {
+            var path2 []dataset.Recipe
+            if !d.IsBase(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2] {
                 path2 = buildOrderedPath(recipe.Ingredient2, elementsAvailable, newVisited)
                 if path2 == nil {
                     continue // Tidak bisa membuat bahan kedua, coba resep lain
@@ -575,13 +569,13 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
             // 3. Buat target dengan resep saat ini
             
             // Cek sekali lagi apakah kedua bahan tersedia (karena loop mungkin terjadi)
-            if (!isBaseElementDFS(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1]) ||
-               (!isBaseElementDFS(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2]) {
+            if (!d.IsBase(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1]) ||
+               (!d.IsBase(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2]) {
                 continue // Ada masalah dengan ketersediaan bahan, coba resep lain
             }
             
             // Rangkai jalur lengkap: buat bahan 1, buat bahan 2, buat target
-            completePath := make([]Recipe, 0)
+            completePath := make([]dataset.Recipe, 0)
             
             if path1 != nil {
                 completePath = append(completePath, path1...)
@@ -601,7 +595,7 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
         
         // Simpan hasil ke cache jika ditemukan jalur
         if bestPath != nil {
-            pathCopy := make([]Recipe, len(bestPath))
+            pathCopy := make([]dataset.Recipe, len(bestPath))
             copy(pathCopy, bestPath)
             
             pathCacheMutex.Lock()
@@ -613,10 +607,10 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
     }
     
     // Helper untuk menghapus resep duplikat dalam path
-    //var removeDuplicateRecipes func(path []Recipe) []Recipe
-    var removeDuplicateRecipes = func(path []Recipe) []Recipe {
+    //var removeDuplicateRecipes func(path []dataset.Recipe) []dataset.Recipe
+    var removeDuplicateRecipes = func(path []dataset.Recipe) []dataset.Recipe {
         seen := make(map[string]bool)
-        unique := make([]Recipe, 0, len(path))
+        unique := make([]dataset.Recipe, 0, len(path))
         
         for _, recipe := range path {
             key := fmt.Sprintf("%s:%s+%s", recipe.Result, recipe.Ingredient1, recipe.Ingredient2)
@@ -630,9 +624,9 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
     }
     
     // Fungsi untuk mencari jalur alternatif
-    //var findAlternativePaths func(target string, existingPath []Recipe, maxPaths int) [][]Recipe
-    var findAlternativePaths = func(target string, existingPath []Recipe, maxPaths int) [][]Recipe {
-        results := [][]Recipe{existingPath} // Mulai dengan jalur yang ada
+    //var findAlternativePaths func(target string, existingPath []dataset.Recipe, maxPaths int) [][]dataset.Recipe
+    var findAlternativePaths = func(target string, existingPath []dataset.Recipe, maxPaths int) [][]dataset.Recipe {
+        results := [][]dataset.Recipe{existingPath} // Mulai dengan jalur yang ada
         uniquePathMap := make(map[string]bool)
         
         // Tandai jalur yang ada sebagai sudah dilihat
@@ -642,12 +636,14 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
         // Batasi jumlah goroutines berjalan simultan
         var wg sync.WaitGroup
         semaphore := make(chan struct{}, 8)
-        var resultsMutex sync.Mutex
         
-        // Cari jalur alternatif dengan mencoba semua resep untuk target
+        // Cari jalur alternatif dengan mencoba semua resep untuk target.
+        // Setiap goroutine menulis ke slotnya sendiri, lalu hasil digabung
+        // sesuai urutan resep agar output tidak bergantung penjadwalan.
         recipesForTarget := recipeMap[target]
+        candidates := make([][]dataset.Recipe, len(recipesForTarget))
         
-        for _, recipe := range recipesForTarget {
+        for idx, recipe := range recipesForTarget {
             // Skip jika resep sama dengan yang digunakan di jalur yang ada
             // Asumsi: resep untuk target ada di akhir jalur
             if len(existingPath) > 0 {
@@ -659,8 +655,7 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
                 }
             }
             
-            wg.Add(1)
-            go func(r Recipe) {
+            tryRecipe := func(idx int, r dataset.Recipe) {
                 semaphore <- struct{}{} // Ambil token
                 defer func() {
                     <-semaphore // Kembalikan token
@@ -669,7 +664,7 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
                 
                 // Inisialisasi dengan elemen dasar tersedia
                 availableElements := make(map[string]bool)
-                for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
+                for _, base := range d.BaseElements() {
                     availableElements[base] = true
                 }
                 
@@ -680,10 +675,10 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
                 }
                 
                 // Buat jalur lengkap dari awal untuk resep alternatif ini
-                var completePath []Recipe
+                var completePath []dataset.Recipe
                 
                 // Cari jalur untuk bahan pertama jika perlu
-                if !isBaseElementDFS(r.Ingredient1) {
+                if !d.IsBase(r.Ingredient1) {
                     ing1Path := buildOrderedPath(r.Ingredient1, availableElements, make(map[string]bool))
                     if ing1Path == nil {
                         return // Tidak bisa membuat bahan pertama
@@ -698,7 +693,7 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
                 }
                 
                 // Cari jalur untuk bahan kedua jika perlu
-                if !isBaseElementDFS(r.Ingredient2) && !availableElements[r.Ingredient2] {
+                if !d.IsBase(r.Ingredient2) && !availableElements[r.Ingredient2] {
                     ing2Path := buildOrderedPath(r.Ingredient2, availableElements, make(map[string]bool))
                     if ing2Path == nil {
                         return // Tidak bisa membuat bahan kedua
@@ -715,52 +710,39 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
                 // Tambahkan resep target
                 completePath = append(completePath, r)
                 
-                // Hilangkan duplikat
-                finalPath := removeDuplicateRecipes(completePath)
+                // Hilangkan duplikat dan langkah yang hasilnya tidak dipakai
+                finalPath := normalizePath(removeDuplicateRecipes(completePath), target)
                 
                 // Verifikasi jalur sudah benar (semua bahan tersedia saat digunakan)
-                available := make(map[string]bool)
-                for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
-                    available[base] = true
-                }
-                
-                valid := true
-                for _, recipe := range finalPath {
-                    // Cek bahan pertama tersedia
-                    if !isBaseElementDFS(recipe.Ingredient1) && !available[recipe.Ingredient1] {
-                        valid = false
-                        break
-                    }
-                    
-                    // Cek bahan kedua tersedia
-                    if !isBaseElementDFS(recipe.Ingredient2) && !available[recipe.Ingredient2] {
-                        valid = false
-                        break
-                    }
-                    
-                    // Tandai hasil resep sebagai tersedia
-                    available[recipe.Result] = true
-                }
-                
-                if !valid {
+                if ValidatePath(d, finalPath, target) != nil {
                     return // Jalur tidak valid, abaikan
                 }
                 
-                // Cek keunikan jalur
-                pathID := generatePathIdentifierDFS(finalPath)
-                
-                resultsMutex.Lock()
-                defer resultsMutex.Unlock()
-                
-                if !uniquePathMap[pathID] && len(results) < maxPaths {
-                    uniquePathMap[pathID] = true
-                    results = append(results, finalPath)
-                }
-            }(recipe)
+                candidates[idx] = finalPath
+            }
+            
+            wg.Add(1)
+            if opts.deterministic {
+                tryRecipe(idx, recipe)
+            } else {
+                go tryRecipe(idx, recipe)
+            }
         }
         
         wg.Wait()
         
+        // Cek keunikan jalur
+        for _, finalPath := range candidates {
+            if finalPath == nil || len(results) >= maxPaths {
+                continue
+            }
+            pathID := generatePathIdentifierDFS(finalPath)
+            if !uniquePathMap[pathID] {
+                uniquePathMap[pathID] = true
+                results = append(results, finalPath)
+            }
+        }
+        
         return results
     }
     
@@ -768,7 +750,7 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
     
     // Tentukan elemen dasar yang tersedia
     availableElements := make(map[string]bool)
-    for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
+    for _, base := range d.BaseElements() {
         availableElements[base] = true
     }
     
@@ -780,29 +762,13 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
         return nil, nodesVisitedCount, fmt.Errorf("tidak ada jalur valid untuk membuat %s", targetElement)
     }
     
-    // Hilangkan duplikat
-    optimalPath = removeDuplicateRecipes(optimalPath)
+    // Hilangkan duplikat, termasuk elemen yang dibuat ulang oleh jalur dari pathCache
+    optimalPath = normalizePath(removeDuplicateRecipes(optimalPath), targetElement)
     
-    // Verifikasi jalur optimal
-    available := make(map[string]bool)
-    for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
-        available[base] = true
-    }
-    
-    for i, recipe := range optimalPath {
-        // Debug: cek prasyarat tersedia
-        if !isBaseElementDFS(recipe.Ingredient1) && !available[recipe.Ingredient1] {
-            fmt.Printf("PERINGATAN: Jalur optimal - bahan %s tidak tersedia pada langkah %d\n", 
-                       recipe.Ingredient1, i+1)
-        }
-        
-        if !isBaseElementDFS(recipe.Ingredient2) && !available[recipe.Ingredient2] {
-            fmt.Printf("PERINGATAN: Jalur optimal - bahan %s tidak tersedia pada langkah %d\n", 
-                       recipe.Ingredient2, i+1)
-        }
-        
-        // Tandai hasil sebagai tersedia
-        available[recipe.Result] = true
+    // Verifikasi jalur optimal: jalur yang tidak valid tidak dikembalikan
+    if err := ValidatePath(d, optimalPath, targetElement); err != nil {
+        fmt.Printf("PERINGATAN: %v\n", err)
+        return nil, nodesVisitedCount, err
     }
     
     // Debug: tampilkan jalur optimal
@@ -814,7 +780,7 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
     
     // Jika hanya butuh 1 jalur, kembalikan sekarang
     if maxRecipes <= 1 {
-        return [][]Recipe{optimalPath}, nodesVisitedCount, nil
+        return [][]dataset.Recipe{optimalPath}, nodesVisitedCount, nil
     }
     
     // Cari jalur alternatif
@@ -822,7 +788,7 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
     allPaths := findAlternativePaths(targetElement, optimalPath, maxRecipes)
     
     // Urutkan hasil berdasarkan panjang (pendek ke panjang)
-    sort.Slice(allPaths, func(i, j int) bool {
+    sort.SliceStable(allPaths, func(i, j int) bool {
         return len(allPaths[i]) < len(allPaths[j])
     })
     
@@ -839,9 +805,9 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
 }
 
 // Helper untuk menghapus resep duplikat dalam path
-// func removeDuplicateRecipes(path []Recipe) []Recipe {
+// func removeDuplicateRecipes(path []dataset.Recipe) []dataset.Recipe {
 //     seen := make(map[string]bool)
-//     unique := make([]Recipe, 0, len(path))
+//     unique := make([]dataset.Recipe, 0, len(path))
     
 //     for _, recipe := range path {
 //         key := fmt.Sprintf("%s:%s+%s", recipe.Result, recipe.Ingredient1, recipe.Ingredient2)
@@ -855,19 +821,8 @@ func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int
 // }
 
 
-// Fungsi isBaseElementDFS (pastikan ada dan bisa diakses)
-func isBaseElementDFS(name string) bool {
-	baseElements := []string{"Air", "Earth", "Fire", "Water"}
-	for _, base := range baseElements {
-		if name == base {
-			return true
-		}
-	}
-	return false
-}
-
-func generatePathIdentifierDFS(path []Recipe) string {
-    recipesCopy := make([]Recipe, len(path))
+func generatePathIdentifierDFS(path []dataset.Recipe) string {
+    recipesCopy := make([]dataset.Recipe, len(path))
     copy(recipesCopy, path)
     sort.Slice(recipesCopy, func(i, j int) bool {
         if recipesCopy[i].Result != recipesCopy[j].Result {
@@ -0,0 +1,125 @@
+// src/backend/search/diversity.go
+package search
+
+import (
+	"tubes2stima/backend/dataset"
+)
+
+// DiversityPoolFactor: pada mode diversity, algoritma diminta mencari
+// max*DiversityPoolFactor jalur, lalu max jalur dipilih dari kumpulan itu.
+const DiversityPoolFactor = 3
+
+// recipeKeySet mengubah jalur menjadi himpunan key resep kanonik
+// (getUniqueRecipeKey), sehingga urutan bahan dan urutan langkah diabaikan.
+func recipeKeySet(path []dataset.Recipe) map[string]bool {
+	keys := make(map[string]bool, len(path))
+	for _, r := range path {
+		keys[getUniqueRecipeKey(r)] = true
+	}
+	return keys
+}
+
+// jaccard menghitung |a ∩ b| / |a ∪ b|. Dua himpunan kosong dianggap identik.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	intersection := 0
+	for key := range a {
+		if b[key] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
+
+// PathSimilarity mengembalikan kemiripan Jaccard dua jalur (0 = tidak ada
+// resep yang sama, 1 = himpunan resep identik).
+func PathSimilarity(a, b []dataset.Recipe) float64 {
+	return jaccard(recipeKeySet(a), recipeKeySet(b))
+}
+
+// PathDistanceMatrix mengembalikan matriks jarak (1 - kemiripan Jaccard)
+// antar semua pasangan jalur.
+func PathDistanceMatrix(paths [][]dataset.Recipe) [][]float64 {
+	sets := make([]map[string]bool, len(paths))
+	for i, path := range paths {
+		sets[i] = recipeKeySet(path)
+	}
+	matrix := make([][]float64, len(paths))
+	for i := range paths {
+		matrix[i] = make([]float64, len(paths))
+	}
+	for i := range paths {
+		for j := i + 1; j < len(paths); j++ {
+			d := 1 - jaccard(sets[i], sets[j])
+			matrix[i][j] = d
+			matrix[j][i] = d
+		}
+	}
+	return matrix
+}
+
+// SelectDiversePaths memilih paling banyak k jalur dari pool secara greedy.
+// Jalur pertama pool (hasil terbaik algoritma) selalu diambil, lalu setiap
+// langkah memilih kandidat dengan skor
+//
+//	diversity * jarak minimum ke jalur terpilih + (1 - diversity) * skor panjang
+//
+// di mana skor panjang = 1 untuk jalur terpendek di pool dan 0 untuk yang
+// terpanjang. diversity = 1 berarti murni max-min dissimilarity. Tie-break
+// mengikuti urutan pool agar hasil tetap deterministik.
+func SelectDiversePaths(pool [][]dataset.Recipe, k int, diversity float64) [][]dataset.Recipe {
+	if k <= 0 || len(pool) == 0 {
+		return nil
+	}
+	if len(pool) <= k {
+		return pool
+	}
+
+	distances := PathDistanceMatrix(pool)
+	shortest, longest := len(pool[0]), len(pool[0])
+	for _, path := range pool {
+		shortest = min(shortest, len(path))
+		longest = max(longest, len(path))
+	}
+	lengthScore := func(i int) float64 {
+		if longest == shortest {
+			return 1
+		}
+		return float64(longest-len(pool[i])) / float64(longest-shortest)
+	}
+
+	selected := []int{0}
+	chosen := make([]bool, len(pool))
+	chosen[0] = true
+	// minDist[i] = jarak minimum kandidat i ke semua jalur terpilih
+	minDist := make([]float64, len(pool))
+	copy(minDist, distances[0])
+
+	for len(selected) < k {
+		best := -1
+		bestScore := 0.0
+		for i := range pool {
+			if chosen[i] {
+				continue
+			}
+			score := diversity*minDist[i] + (1-diversity)*lengthScore(i)
+			if best == -1 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		selected = append(selected, best)
+		chosen[best] = true
+		for i := range pool {
+			minDist[i] = min(minDist[i], distances[best][i])
+		}
+	}
+
+	result := make([][]dataset.Recipe, 0, len(selected))
+	for _, i := range selected {
+		result = append(result, pool[i])
+	}
+	return result
+}
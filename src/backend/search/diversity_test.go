@@ -0,0 +1,54 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+func TestPathSimilarity(t *testing.T) {
+	a := []dataset.Recipe{{Result: "Lava", Ingredient1: "Earth", Ingredient2: "Fire"}, {Result: "Stone", Ingredient1: "Air", Ingredient2: "Lava"}}
+	swapped := []dataset.Recipe{{Result: "Stone", Ingredient1: "Lava", Ingredient2: "Air"}, {Result: "Lava", Ingredient1: "Fire", Ingredient2: "Earth"}}
+	b := []dataset.Recipe{{Result: "Pressure", Ingredient1: "Air", Ingredient2: "Air"}, {Result: "Stone", Ingredient1: "Earth", Ingredient2: "Pressure"}}
+	c := []dataset.Recipe{{Result: "Lava", Ingredient1: "Earth", Ingredient2: "Fire"}, {Result: "Stone", Ingredient1: "Earth", Ingredient2: "Pressure"}, {Result: "Pressure", Ingredient1: "Air", Ingredient2: "Air"}}
+
+	if got := PathSimilarity(a, swapped); got != 1 {
+		t.Errorf("urutan bahan/langkah tidak boleh berpengaruh: %v", got)
+	}
+	if got := PathSimilarity(a, b); got != 0 {
+		t.Errorf("jalur tanpa resep bersama: %v, ingin 0", got)
+	}
+	if got := PathSimilarity(a, c); got != 0.25 {
+		t.Errorf("Jaccard = %v, ingin 0.25", got)
+	}
+	if got := PathSimilarity(nil, nil); got != 1 {
+		t.Errorf("dua jalur kosong = %v, ingin 1", got)
+	}
+}
+
+func TestSelectDiversePaths(t *testing.T) {
+	base := []dataset.Recipe{{Result: "Lava", Ingredient1: "Earth", Ingredient2: "Fire"}, {Result: "Stone", Ingredient1: "Air", Ingredient2: "Lava"}}
+	nearDuplicate := []dataset.Recipe{{Result: "Lava", Ingredient1: "Earth", Ingredient2: "Fire"}, {Result: "Stone", Ingredient1: "Air", Ingredient2: "Lava"}, {Result: "Mud", Ingredient1: "Earth", Ingredient2: "Water"}}
+	different := []dataset.Recipe{{Result: "Pressure", Ingredient1: "Air", Ingredient2: "Air"}, {Result: "Stone", Ingredient1: "Earth", Ingredient2: "Pressure"}, {Result: "Steam", Ingredient1: "Fire", Ingredient2: "Water"}}
+	pool := [][]dataset.Recipe{base, nearDuplicate, different}
+
+	if got := SelectDiversePaths(pool, 2, 1); !reflect.DeepEqual(got, [][]dataset.Recipe{base, different}) {
+		t.Errorf("diversity 1 harus memilih jalur paling berbeda: %v", got)
+	}
+	if got := SelectDiversePaths(pool, 2, 0); !reflect.DeepEqual(got, [][]dataset.Recipe{base, nearDuplicate}) {
+		t.Errorf("diversity 0 harus mengikuti urutan pool: %v", got)
+	}
+
+	matrix := PathDistanceMatrix(pool)
+	for i := range matrix {
+		if matrix[i][i] != 0 {
+			t.Errorf("diagonal [%d] = %v", i, matrix[i][i])
+		}
+		for j := range matrix {
+			if matrix[i][j] != matrix[j][i] {
+				t.Errorf("matriks tidak simetris di (%d,%d)", i, j)
+			}
+		}
+	}
+}
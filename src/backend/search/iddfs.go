@@ -0,0 +1,316 @@
+// src/backend/search/iddfs.go
+package search
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/graph"
+)
+
+// IDDFSIteration mencatat satu iterasi IDDFS: batas kedalaman dan jumlah
+// elemen yang diekspansi pada iterasi tersebut.
+type IDDFSIteration struct {
+	DepthLimit   int `json:"depthLimit"`
+	NodesVisited int `json:"nodesVisited"`
+}
+
+// iddfsNeverSolvable menandai elemen yang terbukti tidak bisa dibuat pada
+// kedalaman berapa pun (gagal tanpa terpotong batas kedalaman maupun siklus).
+const iddfsNeverSolvable = math.MaxInt32
+
+// iddfsSearch menyimpan state IDDFS pada graf kompak. Memori yang dipakai
+// O(n + kedalaman), bukan O(kedalaman): selain stack rekursi, ada array per
+// elemen berukuran tetap n yang dialokasikan sekali dan dipakai ulang antar
+// iterasi. Tanpa cache ini, IDDFS pada graf AND/OR mengulang subpohon yang
+// sama secara eksponensial; mode O(kedalaman) yang sebenarnya ada di
+// iddfsNoCache (FindPathIDDFSNoCache). Isinya:
+//   - choice/height: resep terpilih dan tinggi subpohonnya (cache sukses).
+//     Tinggi anak selalu < tinggi induk, jadi choice tidak pernah membentuk
+//     siklus dan subpohon tersimpan valid di konteks mana pun.
+//   - failedAt: batas terbesar yang terbukti gagal (cache gagal). Hanya diisi
+//     jika kegagalan tidak melibatkan pemotongan siklus, karena hasil seperti
+//     itu bergantung pada isi stack saat itu.
+//   - onStack: elemen di jalur rekursi saat ini (deteksi siklus).
+type iddfsSearch struct {
+	g        compactGraph
+	choice   []uint32
+	height   []int32
+	failedAt []int32
+	onStack  graph.Bitset
+	nodes    int
+}
+
+// solve mencoba membuat el dengan pohon setinggi paling banyak limit.
+// cycleCut: kegagalan melibatkan elemen yang sedang di stack.
+// depthCut: kegagalan melibatkan batas kedalaman (bisa berhasil jika limit lebih besar).
+func (s *iddfsSearch) solve(el uint32, limit int32) (ok, cycleCut, depthCut bool) {
+	g := s.g
+	if g.IsBase(el) {
+		return true, false, false
+	}
+	if s.height[el] > 0 && s.height[el] <= limit {
+		return true, false, false
+	}
+	if s.failedAt[el] >= limit {
+		return false, false, s.failedAt[el] != iddfsNeverSolvable
+	}
+	if limit == 0 {
+		return false, false, true
+	}
+	if s.onStack.Has(el) {
+		return false, true, false
+	}
+
+	s.nodes++
+	s.onStack.Set(el)
+	defer s.onStack.Clear(el)
+	for _, ri := range g.RecipesFor(el) {
+		r := g.Recipe(ri)
+		ok1, cycle1, depth1 := s.solve(r.Ing1, limit-1)
+		cycleCut = cycleCut || cycle1
+		depthCut = depthCut || depth1
+		if !ok1 {
+			continue
+		}
+		ok2, cycle2, depth2 := s.solve(r.Ing2, limit-1)
+		cycleCut = cycleCut || cycle2
+		depthCut = depthCut || depth2
+		if !ok2 {
+			continue
+		}
+		h := s.height[r.Ing1]
+		if s.height[r.Ing2] > h {
+			h = s.height[r.Ing2]
+		}
+		s.choice[el] = ri
+		s.height[el] = h + 1
+		return true, false, false
+	}
+
+	if !cycleCut {
+		if !depthCut {
+			s.failedAt[el] = iddfsNeverSolvable
+		} else if limit > s.failedAt[el] {
+			s.failedAt[el] = limit
+		}
+	}
+	return false, cycleCut, depthCut
+}
+
+// FindPathIDDFS membungkus FindPathIDDFSIterations tanpa batas kedalaman
+// dengan format (jalur, nodesVisited, error) seperti algoritma lain.
+func FindPathIDDFS(d *dataset.Dataset, targetElement string) ([]dataset.Recipe, int, error) {
+	path, iterations, err := FindPathIDDFSIterations(d, targetElement, 0)
+	nodesVisited := 0
+	for _, it := range iterations {
+		nodesVisited += it.NodesVisited
+	}
+	return path, nodesVisited, err
+}
+
+// FindPathIDDFSIterations menjalankan iterative deepening DFS pada graf
+// AND/OR resep: batas kedalaman dinaikkan 1, 2, ... sampai pohon resep untuk
+// target ditemukan. Pohon pertama yang ditemukan memiliki tinggi minimum
+// (sama dengan tier target). maxDepth <= 0 berarti tanpa batas; pencarian
+// juga berhenti jika satu iterasi gagal tanpa pernah terpotong batas
+// kedalaman, karena batas yang lebih besar tidak akan mengubah hasilnya.
+// Cache sukses/gagal per elemen dipakai ulang antar iterasi, sehingga memori
+// O(n + kedalaman) untuk n elemen (lihat iddfsSearch). Untuk memori
+// O(kedalaman), gunakan FindPathIDDFSNoCache.
+func FindPathIDDFSIterations(d *dataset.Dataset, targetElement string, maxDepth int) ([]dataset.Recipe, []IDDFSIteration, error) {
+	g, err := compactOf(d)
+	if err != nil {
+		return nil, nil, err
+	}
+	if d.IsBase(targetElement) {
+		return []dataset.Recipe{}, nil, nil
+	}
+	target, ok := g.ID(targetElement)
+	if !ok {
+		return nil, nil, fmt.Errorf("path to element '%s' not found", targetElement)
+	}
+
+	n := g.NumElements()
+	if maxDepth <= 0 || maxDepth > n {
+		maxDepth = n // pohon minimum tidak mungkin lebih tinggi dari jumlah elemen
+	}
+	s := &iddfsSearch{
+		g:        g,
+		choice:   make([]uint32, n),
+		height:   make([]int32, n),
+		failedAt: make([]int32, n),
+		onStack:  graph.NewBitset(n),
+	}
+	for i := range s.choice {
+		s.choice[i] = graph.NoElement
+	}
+
+	var iterations []IDDFSIteration
+	for limit := 1; limit <= maxDepth; limit++ {
+		s.nodes = 0
+		found, _, depthCut := s.solve(target, int32(limit))
+		iterations = append(iterations, IDDFSIteration{DepthLimit: limit, NodesVisited: s.nodes})
+		if found {
+			return g.ToPath(g.orderTree(s.choice, target)), iterations, nil
+		}
+		if !depthCut {
+			return nil, iterations, fmt.Errorf("path to element '%s' not found", targetElement)
+		}
+	}
+	return nil, iterations, fmt.Errorf("tidak ada jalur ke '%s' dengan kedalaman <= %d", targetElement, maxDepth)
+}
+
+// iddfsNoCacheNodeLimit membatasi jumlah ekspansi FindPathIDDFSNoCache.
+// Tanpa cache, iterasi yang gagal menelusuri ulang seluruh subpohon AND/OR,
+// sehingga waktu eksponensial terhadap kedalaman.
+const iddfsNoCacheNodeLimit = 5_000_000
+
+// errIDDFSNodeLimit dikembalikan jika FindPathIDDFSNoCache melewati
+// iddfsNoCacheNodeLimit.
+var errIDDFSNodeLimit = fmt.Errorf("IDDFS tanpa cache melewati batas %d node, gunakan IDDFS biasa", iddfsNoCacheNodeLimit)
+
+// iddfsStep adalah satu langkah pohon yang sedang dibangun iddfsNoCache
+// beserta tinggi subpohonnya.
+type iddfsStep struct {
+	recipe uint32
+	height int32
+}
+
+// iddfsNoCache adalah IDDFS murni tanpa array per elemen: state pencarian
+// hanya stack rekursi (O(kedalaman)), ditambah steps yang berisi pohon
+// hasil sementara (sebanding dengan ukuran jalur keluaran). Siklus tidak
+// perlu dideteksi karena batas turun satu setiap level rekursi.
+type iddfsNoCache struct {
+	g     compactGraph
+	steps []iddfsStep
+	nodes int
+}
+
+// solve mencoba membuat el dengan pohon setinggi paling banyak limit dan
+// mengembalikan tinggi pohonnya. Langkah pohon ditambahkan ke s.steps; jika
+// gagal, s.steps dikembalikan ke panjang semula. depthCut seperti pada
+// iddfsSearch.solve.
+func (s *iddfsNoCache) solve(el uint32, limit int32) (height int32, ok, depthCut bool, err error) {
+	g := s.g
+	if g.IsBase(el) {
+		return 0, true, false, nil
+	}
+	// Elemen yang sudah dibuat di pohon sementara dipakai ulang jika cukup rendah
+	for _, step := range s.steps {
+		if g.Recipe(step.recipe).Result == el && step.height <= limit {
+			return step.height, true, false, nil
+		}
+	}
+	if limit == 0 {
+		return 0, false, true, nil
+	}
+	if s.nodes++; s.nodes > iddfsNoCacheNodeLimit {
+		return 0, false, false, errIDDFSNodeLimit
+	}
+
+	mark := len(s.steps)
+	for _, ri := range g.RecipesFor(el) {
+		r := g.Recipe(ri)
+		h1, ok1, depth1, err := s.solve(r.Ing1, limit-1)
+		if err != nil {
+			return 0, false, false, err
+		}
+		depthCut = depthCut || depth1
+		if ok1 {
+			h2, ok2, depth2, err := s.solve(r.Ing2, limit-1)
+			if err != nil {
+				return 0, false, false, err
+			}
+			depthCut = depthCut || depth2
+			if ok2 {
+				height = max(h1, h2) + 1
+				s.steps = append(s.steps, iddfsStep{recipe: ri, height: height})
+				return height, true, false, nil
+			}
+		}
+		s.steps = s.steps[:mark]
+	}
+	return 0, false, depthCut, nil
+}
+
+// tree memilih dari s.steps pohon untuk target: satu elemen bisa dibuat
+// lebih dari sekali dengan tinggi berbeda, jadi dipakai langkah terendah.
+// Langkah diurutkan menurut tinggi, sehingga bahan selalu dibuat lebih dulu.
+func (s *iddfsNoCache) tree(target uint32) []uint32 {
+	lowest := make(map[uint32]iddfsStep)
+	for _, step := range s.steps {
+		result := s.g.Recipe(step.recipe).Result
+		if best, ok := lowest[result]; !ok || step.height < best.height {
+			lowest[result] = step
+		}
+	}
+	var kept []iddfsStep
+	needed := map[uint32]bool{target: true}
+	queue := []uint32{target}
+	for len(queue) > 0 {
+		el := queue[0]
+		queue = queue[1:]
+		step, ok := lowest[el]
+		if !ok {
+			continue // elemen dasar
+		}
+		kept = append(kept, step)
+		r := s.g.Recipe(step.recipe)
+		for _, ing := range []uint32{r.Ing1, r.Ing2} {
+			if !needed[ing] {
+				needed[ing] = true
+				queue = append(queue, ing)
+			}
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].height < kept[j].height })
+	recipes := make([]uint32, len(kept))
+	for i, step := range kept {
+		recipes[i] = step.recipe
+	}
+	return recipes
+}
+
+// FindPathIDDFSNoCache sama dengan FindPathIDDFSIterations, tetapi tanpa
+// cache sukses/gagal per elemen: memori pencarian O(kedalaman) (di luar
+// graf dan jalur keluaran), dengan harga waktu eksponensial terhadap
+// kedalaman. Pencarian dihentikan dengan error setelah
+// iddfsNoCacheNodeLimit ekspansi. Pohon yang ditemukan tetap setinggi tier
+// target.
+func FindPathIDDFSNoCache(d *dataset.Dataset, targetElement string, maxDepth int) ([]dataset.Recipe, []IDDFSIteration, error) {
+	g, err := compactOf(d)
+	if err != nil {
+		return nil, nil, err
+	}
+	if d.IsBase(targetElement) {
+		return []dataset.Recipe{}, nil, nil
+	}
+	target, ok := g.ID(targetElement)
+	if !ok {
+		return nil, nil, fmt.Errorf("path to element '%s' not found", targetElement)
+	}
+	if n := g.NumElements(); maxDepth <= 0 || maxDepth > n {
+		maxDepth = n
+	}
+
+	s := &iddfsNoCache{g: g}
+	var iterations []IDDFSIteration
+	for limit := 1; limit <= maxDepth; limit++ {
+		before := s.nodes
+		_, found, depthCut, err := s.solve(target, int32(limit))
+		iterations = append(iterations, IDDFSIteration{DepthLimit: limit, NodesVisited: s.nodes - before})
+		if err != nil {
+			return nil, iterations, err
+		}
+		if found {
+			return g.ToPath(s.tree(target)), iterations, nil
+		}
+		if !depthCut {
+			return nil, iterations, fmt.Errorf("path to element '%s' not found", targetElement)
+		}
+	}
+	return nil, iterations, fmt.Errorf("tidak ada jalur ke '%s' dengan kedalaman <= %d", targetElement, maxDepth)
+}
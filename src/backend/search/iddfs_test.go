@@ -0,0 +1,92 @@
+// src/backend/search/iddfs_test.go
+package search
+
+import (
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+// pathHeight menghitung tinggi pohon resep jalur untuk element.
+func pathHeight(path []dataset.Recipe, element string) int {
+	for _, step := range path {
+		if step.Result == element {
+			return 1 + max(pathHeight(path, step.Ingredient1), pathHeight(path, step.Ingredient2))
+		}
+	}
+	return 0
+}
+
+func TestIDDFSFindsMinimumHeightTree(t *testing.T) {
+	tiers, _ := dataset.ElementTiers(testDataset.Recipes(), dataset.BaseElements)
+	for _, element := range sortedElementNames() {
+		if testDataset.IsBase(element) {
+			continue
+		}
+		path, iterations, err := FindPathIDDFSIterations(testDataset, element, 0)
+		tier, reachable := tiers[element]
+		if !reachable {
+			if err == nil {
+				t.Errorf("%s: tidak bisa dibuat tetapi jalur ditemukan", element)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", element, err)
+			continue
+		}
+		if err := ValidatePath(testDataset, path, element); err != nil {
+			t.Errorf("%s: %v", element, err)
+		}
+		if h := pathHeight(path, element); h != tier {
+			t.Errorf("%s: tinggi pohon %d, tier %d", element, h, tier)
+		}
+		if len(iterations) != tier || iterations[len(iterations)-1].DepthLimit != tier {
+			t.Errorf("%s: iterasi %+v, ingin berhenti di kedalaman %d", element, iterations, tier)
+		}
+	}
+}
+
+func TestIDDFSMaxDepth(t *testing.T) {
+	// Human ada di tier 7 (lihat benchTargets)
+	_, iterations, err := FindPathIDDFSIterations(testDataset, "Human", 3)
+	if err == nil {
+		t.Fatal("Human dengan maxDepth 3 seharusnya error")
+	}
+	if len(iterations) != 3 {
+		t.Errorf("jumlah iterasi %d, ingin 3", len(iterations))
+	}
+}
+
+// Tanpa cache, IDDFS harus menemukan pohon setinggi tier yang sama. Hanya
+// tier rendah yang diuji karena waktunya eksponensial terhadap kedalaman.
+func TestIDDFSNoCacheFindsMinimumHeightTree(t *testing.T) {
+	tiers, _ := dataset.ElementTiers(testDataset.Recipes(), dataset.BaseElements)
+	for _, element := range sortedElementNames() {
+		tier, reachable := tiers[element]
+		if !reachable || tier == 0 || tier > 8 {
+			continue
+		}
+		path, iterations, err := FindPathIDDFSNoCache(testDataset, element, 0)
+		if err != nil {
+			t.Errorf("%s: %v", element, err)
+			continue
+		}
+		if err := ValidatePath(testDataset, path, element); err != nil {
+			t.Errorf("%s: %v", element, err)
+		}
+		if h := pathHeight(path, element); h != tier {
+			t.Errorf("%s: tinggi pohon %d, tier %d", element, h, tier)
+		}
+		if len(iterations) != tier {
+			t.Errorf("%s: iterasi %+v, ingin berhenti di kedalaman %d", element, iterations, tier)
+		}
+	}
+
+	if _, _, err := FindPathIDDFSNoCache(testDataset, "Time", 0); err == nil {
+		t.Error("Time tidak bisa dibuat tetapi jalur ditemukan")
+	}
+	if _, iterations, err := FindPathIDDFSNoCache(testDataset, "Human", 3); err == nil || len(iterations) != 3 {
+		t.Errorf("Human dengan maxDepth 3: err %v, %d iterasi", err, len(iterations))
+	}
+}
@@ -0,0 +1,397 @@
+// src/backend/search/kbest.go
+package search
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/graph"
+)
+
+// kBestNodeBudget membatasi jumlah state yang diekspansi oleh KBestPaths.
+// Ruang pohon resep tumbuh eksponensial untuk elemen tier tinggi, jadi
+// pencarian berhenti (dengan hasil parsial) jika anggaran habis.
+const kBestNodeBudget = 100000
+
+// kbestState adalah pohon resep parsial: fungsi pilihan elemen -> resep untuk
+// elemen yang sudah ditutup, plus daftar elemen terbuka yang masih harus
+// dibuat. Pilihan disimpan sebagai rantai ke state induk supaya state baru
+// cukup menambah satu pasangan (elemen, resep).
+type kbestState struct {
+	prev    *kbestState
+	element uint32
+	recipe  uint32
+	steps   int      // jumlah elemen yang sudah punya resep (g)
+	open    []uint32 // elemen terbuka, terurut naik
+	extra   int      // batas bawah elemen baru di luar open (lihat extraSteps)
+	scored  bool     // extra sudah dihitung untuk state ini
+	seq     int      // urutan masuk antrian, untuk tie-break deterministik
+}
+
+// priority = steps + |open| + extra. Setiap elemen terbuka butuh minimal satu
+// langkah dan elemen tidak pernah dibuat dua kali, sehingga heuristik ini
+// admissible dan pohon selesai keluar dari antrian terurut jumlah langkah.
+func (s *kbestState) priority() int {
+	return s.steps + len(s.open) + s.extra
+}
+
+type kbestQueue []*kbestState
+
+func (q kbestQueue) Len() int { return len(q) }
+
+func (q kbestQueue) Less(i, j int) bool {
+	pi, pj := q[i].priority(), q[j].priority()
+	if pi != pj {
+		return pi < pj
+	}
+	// Pada prioritas sama, dahulukan state yang lebih dekat selesai
+	if len(q[i].open) != len(q[j].open) {
+		return len(q[i].open) < len(q[j].open)
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q kbestQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *kbestQueue) Push(x any) { *q = append(*q, x.(*kbestState)) }
+
+func (q *kbestQueue) Pop() any {
+	old := *q
+	s := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return s
+}
+
+// FindKBestPaths menjalankan KBestPaths pada graf kompak milik dataset d.
+func FindKBestPaths(d *dataset.Dataset, targetElement string, k int) ([][]dataset.Recipe, int, error) {
+	g, err := compactOf(d)
+	if err != nil {
+		return nil, 0, err
+	}
+	return g.KBestPaths(targetElement, k)
+}
+
+// KBestPaths mengenumerasi pohon resep (satu resep per elemen yang dibutuhkan,
+// tanpa siklus) secara best-first berdasarkan jumlah langkah. Pohon yang
+// selesai keluar dari antrian dengan urutan jumlah langkah tidak menurun,
+// sehingga k pohon pertama adalah k pohon terkecil. Keunikan dijamin dengan
+// PathIdentifier. nodesVisited = jumlah state yang diekspansi.
+// Jika anggaran kBestNodeBudget habis, pohon yang sudah ditemukan tetap
+// dikembalikan (bisa kurang dari k); error hanya jika belum ada satu pun.
+func (g compactGraph) KBestPaths(targetElement string, k int) ([][]dataset.Recipe, int, error) {
+	if k <= 0 {
+		return nil, 0, errors.New("jumlah resep minimal harus 1")
+	}
+	target, ok := g.ID(targetElement)
+	if !ok {
+		return nil, 0, fmt.Errorf("element '%s' not found in recipe database", targetElement)
+	}
+	if g.IsBase(target) {
+		return [][]dataset.Recipe{}, 0, nil
+	}
+
+	trees, nodesVisited := g.kBestTrees(target, k, kBestNodeBudget)
+	if len(trees) == 0 {
+		if nodesVisited >= kBestNodeBudget {
+			return nil, nodesVisited, fmt.Errorf("k-best untuk '%s' melebihi anggaran %d state tanpa menemukan pohon", targetElement, kBestNodeBudget)
+		}
+		return nil, nodesVisited, fmt.Errorf("path to element '%s' not found", targetElement)
+	}
+
+	paths := make([][]dataset.Recipe, 0, len(trees))
+	for _, tree := range trees {
+		paths = append(paths, g.ToPath(tree))
+	}
+	return paths, nodesVisited, nil
+}
+
+// kBestTrees mengembalikan paling banyak k pohon (sebagai daftar indeks resep
+// terurut topologis) dan jumlah state yang diekspansi.
+func (g compactGraph) kBestTrees(target uint32, k int, budget int) ([][]uint32, int) {
+	n := g.NumElements()
+	choices := g.distinctRecipeChoices()
+
+	// assigned: scratch elemen -> resep untuk state yang sedang diekspansi
+	assigned := make([]uint32, n)
+	for i := range assigned {
+		assigned[i] = graph.NoElement
+	}
+	visitStamp := make([]int, n)
+	stamp := 0
+	scratch := newKBestScratch(g)
+
+	// reaches memeriksa apakah goal bisa dicapai dari start lewat resep yang
+	// sudah dipilih (bahan -> bahan dari resepnya), yaitu apakah memilih resep
+	// berbahan start untuk goal akan membentuk siklus.
+	reaches := func(start, goal uint32) bool {
+		stamp++
+		stack := []uint32{start}
+		for len(stack) > 0 {
+			el := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if el == goal {
+				return true
+			}
+			if visitStamp[el] == stamp || assigned[el] == graph.NoElement {
+				continue
+			}
+			visitStamp[el] = stamp
+			r := g.Recipe(assigned[el])
+			stack = append(stack, r.Ing1, r.Ing2)
+		}
+		return false
+	}
+
+	queue := &kbestQueue{}
+	seq := 0
+	heap.Push(queue, &kbestState{element: graph.NoElement, open: []uint32{target}, seq: seq})
+
+	var trees [][]uint32
+	seen := make(map[string]bool)
+	nodesVisited := 0
+
+	for queue.Len() > 0 && len(trees) < k && nodesVisited < budget {
+		s := heap.Pop(queue).(*kbestState)
+
+		for c := s; c != nil && c.element != graph.NoElement; c = c.prev {
+			assigned[c.element] = c.recipe
+		}
+
+		// Heuristik extra dihitung malas saat state pertama kali keluar; jika
+		// prioritasnya naik, state dikembalikan ke antrian.
+		if !s.scored {
+			s.scored = true
+			s.extra = g.extraSteps(s.open, s, scratch)
+			if s.extra != 0 {
+				if s.extra > 0 {
+					heap.Push(queue, s)
+				}
+				for c := s; c != nil && c.element != graph.NoElement; c = c.prev {
+					assigned[c.element] = graph.NoElement
+				}
+				continue
+			}
+		}
+		nodesVisited++
+
+		if len(s.open) == 0 {
+			tree := g.orderTree(assigned, target)
+			id := PathIdentifier(g.ToPath(tree))
+			if !seen[id] {
+				seen[id] = true
+				trees = append(trees, tree)
+			}
+		} else {
+			el := g.pickOpenElement(s.open, choices)
+			for _, ri := range choices[el] {
+				r := g.Recipe(ri)
+				if reaches(r.Ing1, el) || reaches(r.Ing2, el) {
+					continue
+				}
+				open := make([]uint32, 0, len(s.open)+1)
+				for _, o := range s.open {
+					if o != el {
+						open = append(open, o)
+					}
+				}
+				for _, ing := range []uint32{r.Ing1, r.Ing2} {
+					if g.IsBase(ing) || assigned[ing] != graph.NoElement {
+						continue
+					}
+					open = insertSorted(open, ing)
+				}
+				seq++
+				heap.Push(queue, &kbestState{prev: s, element: el, recipe: ri, steps: s.steps + 1, open: open, seq: seq})
+			}
+		}
+
+		for c := s; c != nil && c.element != graph.NoElement; c = c.prev {
+			assigned[c.element] = graph.NoElement
+		}
+	}
+
+	return trees, nodesVisited
+}
+
+// kbestScratch menyimpan buffer extraSteps. Isi dist/pending hanya valid jika
+// stamp-nya sama dengan stamp pemanggilan saat ini, sehingga tidak perlu
+// di-reset penuh untuk setiap state.
+type kbestScratch struct {
+	stamp        int32
+	distStamp    []int32
+	dist         []int32
+	pendingStamp []int32
+	pending      []uint8
+	resolved     []int32
+	level        []uint32
+	next         []uint32
+}
+
+func newKBestScratch(g compactGraph) *kbestScratch {
+	n := g.NumElements()
+	return &kbestScratch{
+		distStamp:    make([]int32, n),
+		dist:         make([]int32, n),
+		pendingStamp: make([]int32, g.NumRecipes()),
+		pending:      make([]uint8, g.NumRecipes()),
+		resolved:     make([]int32, n),
+	}
+}
+
+// extraSteps menghitung batas bawah jumlah elemen baru (di luar elemen dasar,
+// elemen yang sudah dipilih, dan elemen terbuka) yang masih harus dibuat.
+// Elemen-elemen tersebut dianggap tersedia di level 0, lalu BFS per level
+// pada graf AND/OR (resep siap jika kedua bahan sudah ter-settle) memberi
+// panjang rantai elemen baru minimal untuk setiap elemen. Level pertama saat
+// salah satu resep elemen terbuka siap adalah panjang rantai untuk elemen itu;
+// rantai tersebut berisi elemen baru yang berbeda semua, jadi maksimum atas
+// elemen terbuka adalah batas bawah. Mengembalikan -1 jika ada elemen terbuka
+// yang tidak mungkin dibuat lagi.
+func (g compactGraph) extraSteps(open []uint32, chain *kbestState, sc *kbestScratch) int {
+	sc.stamp++
+	stamp := sc.stamp
+	settle := func(el uint32, d int32) bool {
+		if sc.distStamp[el] == stamp {
+			return false
+		}
+		sc.distStamp[el] = stamp
+		sc.dist[el] = d
+		return true
+	}
+
+	sc.level = sc.level[:0]
+	for _, id := range g.BaseIDs() {
+		if settle(id, 0) {
+			sc.level = append(sc.level, id)
+		}
+	}
+	for c := chain; c != nil && c.element != graph.NoElement; c = c.prev {
+		if settle(c.element, 0) {
+			sc.level = append(sc.level, c.element)
+		}
+	}
+	for _, el := range open {
+		settle(el, 0)
+		sc.level = append(sc.level, el)
+		sc.resolved[el] = -1
+	}
+
+	remaining := len(open)
+	extra := 0
+	for d := int32(0); len(sc.level) > 0 && remaining > 0; d++ {
+		sc.next = sc.next[:0]
+		for _, el := range sc.level {
+			for _, ri := range g.RecipesUsing(el) {
+				if sc.pendingStamp[ri] != stamp {
+					sc.pendingStamp[ri] = stamp
+					r := g.Recipe(ri)
+					if r.Ing1 == r.Ing2 {
+						sc.pending[ri] = 1
+					} else {
+						sc.pending[ri] = 2
+					}
+				}
+				sc.pending[ri]--
+				if sc.pending[ri] != 0 {
+					continue
+				}
+				result := g.Recipe(ri).Result
+				if settle(result, d+1) {
+					sc.next = append(sc.next, result)
+					continue
+				}
+				// Elemen terbuka: resep pertama yang siap menentukan rantainya
+				if sc.dist[result] == 0 && sc.resolved[result] == -1 && isOpen(open, result) {
+					sc.resolved[result] = d
+					remaining--
+					extra = int(d)
+				}
+			}
+		}
+		sc.level, sc.next = sc.next, sc.level
+	}
+	if remaining > 0 {
+		return -1
+	}
+	return extra
+}
+
+// isOpen memeriksa keanggotaan el pada list open yang terurut.
+func isOpen(open []uint32, el uint32) bool {
+	i := sort.Search(len(open), func(i int) bool { return open[i] >= el })
+	return i < len(open) && open[i] == el
+}
+
+// distinctRecipeChoices mengelompokkan resep per hasil dan membuang resep
+// dengan pasangan bahan yang sama (hanya beda urutan), karena keduanya
+// menghasilkan identifier jalur yang sama.
+func (g compactGraph) distinctRecipeChoices() [][]uint32 {
+	n := g.NumElements()
+	choices := make([][]uint32, n)
+	for el := 0; el < n; el++ {
+		seen := make(map[recipeKey]bool)
+		for _, ri := range g.RecipesFor(uint32(el)) {
+			key := g.keyOf(ri)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			choices[el] = append(choices[el], ri)
+		}
+	}
+	return choices
+}
+
+// pickOpenElement memilih elemen terbuka dengan pilihan resep paling sedikit
+// (percabangan terkecil), tie-break ID terkecil.
+func (g compactGraph) pickOpenElement(open []uint32, choices [][]uint32) uint32 {
+	best := open[0]
+	for _, el := range open[1:] {
+		if len(choices[el]) < len(choices[best]) {
+			best = el
+		}
+	}
+	return best
+}
+
+// orderTree mengurutkan pohon lengkap (assigned) secara topologis memakai
+// buildRecipePath, dengan kedalaman = tinggi elemen di dalam pohon.
+func (g compactGraph) orderTree(assigned []uint32, target uint32) []uint32 {
+	depth := make([]int32, g.NumElements())
+	var height func(el uint32) int32
+	height = func(el uint32) int32 {
+		if g.IsBase(el) || assigned[el] == graph.NoElement {
+			return 0
+		}
+		if depth[el] > 0 {
+			return depth[el]
+		}
+		r := g.Recipe(assigned[el])
+		h1, h2 := height(r.Ing1), height(r.Ing2)
+		if h2 > h1 {
+			h1 = h2
+		}
+		depth[el] = h1 + 1
+		return depth[el]
+	}
+	height(target)
+	return g.buildRecipePath(assigned, target, depth)
+}
+
+// insertSorted menyisipkan el ke list terurut jika belum ada.
+func insertSorted(list []uint32, el uint32) []uint32 {
+	i := 0
+	for i < len(list) && list[i] < el {
+		i++
+	}
+	if i < len(list) && list[i] == el {
+		return list
+	}
+	list = append(list, 0)
+	copy(list[i+1:], list[i:])
+	list[i] = el
+	return list
+}
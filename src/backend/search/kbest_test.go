@@ -0,0 +1,133 @@
+package search
+
+import (
+	"sort"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/graph"
+)
+
+// bruteForceTreeSizes mengenumerasi semua pohon resep untuk target (tanpa
+// heuristik) dan mengembalikan ukurannya per identifier. Hanya dipakai untuk
+// elemen tier rendah yang ruang pohonnya kecil.
+func bruteForceTreeSizes(g compactGraph, target uint32) map[string]int {
+	choices := g.distinctRecipeChoices()
+	assigned := make([]uint32, g.NumElements())
+	for i := range assigned {
+		assigned[i] = graph.NoElement
+	}
+	var reaches func(from, goal uint32) bool
+	reaches = func(from, goal uint32) bool {
+		if from == goal {
+			return true
+		}
+		if assigned[from] == graph.NoElement {
+			return false
+		}
+		r := g.Recipe(assigned[from])
+		return reaches(r.Ing1, goal) || reaches(r.Ing2, goal)
+	}
+
+	sizes := make(map[string]int)
+	var expand func(open []uint32, steps int)
+	expand = func(open []uint32, steps int) {
+		if len(open) == 0 {
+			tree := g.orderTree(assigned, target)
+			sizes[PathIdentifier(g.ToPath(tree))] = steps
+			return
+		}
+		el := open[0]
+		for _, ri := range choices[el] {
+			r := g.Recipe(ri)
+			if reaches(r.Ing1, el) || reaches(r.Ing2, el) {
+				continue
+			}
+			next := append([]uint32(nil), open[1:]...)
+			assigned[el] = ri
+			for _, ing := range []uint32{r.Ing1, r.Ing2} {
+				if !g.IsBase(ing) && assigned[ing] == graph.NoElement {
+					next = insertSorted(next, ing)
+				}
+			}
+			expand(next, steps+1)
+			assigned[el] = graph.NoElement
+		}
+	}
+	expand([]uint32{target}, 0)
+	return sizes
+}
+
+func TestKBestMatchesBruteForce(t *testing.T) {
+	g, _ := compactOf(testDataset)
+	tiers, _ := dataset.ElementTiers(testDataset.Recipes(), dataset.BaseElements)
+	checked := 0
+	for _, name := range sortedElementNames() {
+		id, ok := g.ID(name)
+		if !ok || g.IsBase(id) || tiers[name] > 5 {
+			continue
+		}
+		sizes := bruteForceTreeSizes(g, id)
+		want := make([]int, 0, len(sizes))
+		for _, size := range sizes {
+			want = append(want, size)
+		}
+		sort.Ints(want)
+		k := len(want) + 1 // minta lebih dari yang ada: harus berhenti di len(want)
+
+		paths, _, err := g.KBestPaths(name, k)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(paths) != len(want) {
+			t.Errorf("%s: %d pohon, brute force %d", name, len(paths), len(want))
+			continue
+		}
+		for i, path := range paths {
+			if len(path) != want[i] {
+				t.Errorf("%s: pohon #%d panjang %d, ingin %d", name, i+1, len(path), want[i])
+			}
+			if _, ok := sizes[PathIdentifier(path)]; !ok {
+				t.Errorf("%s: pohon #%d tidak ada di hasil brute force", name, i+1)
+			}
+		}
+		checked++
+	}
+	if checked == 0 {
+		t.Fatal("tidak ada elemen yang diperiksa")
+	}
+}
+
+func TestKBestPathsAreValidDistinctAndOrdered(t *testing.T) {
+	g, _ := compactOf(testDataset)
+	targets := []string{}
+	for _, bt := range benchTargets {
+		targets = append(targets, bt.Element)
+	}
+	for _, name := range targets {
+		paths, _, err := g.KBestPaths(name, 5)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		seen := make(map[string]bool)
+		for i, path := range paths {
+			if err := ValidatePath(testDataset, path, name); err != nil {
+				t.Errorf("%s #%d: %v", name, i+1, err)
+			}
+			id := PathIdentifier(path)
+			if seen[id] {
+				t.Errorf("%s #%d: duplikat", name, i+1)
+			}
+			seen[id] = true
+			if i > 0 && len(path) < len(paths[i-1]) {
+				t.Errorf("%s: urutan panjang tidak naik di #%d", name, i+1)
+			}
+		}
+		// Pohon terbaik tidak boleh lebih panjang dari hasil BFS maupun DFS
+		bfsPath, _, _ := g.FindPathBFS(name)
+		dfsPath, _, _ := g.FindPathDFS(name)
+		if len(paths) > 0 && (len(paths[0]) > len(bfsPath) || len(paths[0]) > len(dfsPath)) {
+			t.Errorf("%s: k-best %d langkah, BFS %d, DFS %d", name, len(paths[0]), len(bfsPath), len(dfsPath))
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// src/backend/search/mandatory_test.go
+package search
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/graph"
+)
+
+// treeElements mengambil semua elemen dari identifier pohon
+// (format PathIdentifier: "A+B=>R|...").
+func treeElements(id string) map[string]bool {
+	elements := make(map[string]bool)
+	for _, step := range strings.Split(id, "|") {
+		ingredients, result, _ := strings.Cut(step, "=>")
+		a, b, _ := strings.Cut(ingredients, "+")
+		elements[a], elements[b], elements[result] = true, true, true
+	}
+	return elements
+}
+
+func TestMandatoryMatchesBruteForce(t *testing.T) {
+	g, _ := compactOf(testDataset)
+	tiers, _ := dataset.ElementTiers(testDataset.Recipes(), dataset.BaseElements)
+	for _, name := range sortedElementNames() {
+		id, ok := g.ID(name)
+		if !ok || g.IsBase(id) || tiers[name] > 5 {
+			continue
+		}
+		// Irisan elemen dari semua pohon resep = elemen wajib
+		var common map[string]bool
+		for treeID := range bruteForceTreeSizes(g, id) {
+			elements := treeElements(treeID)
+			if common == nil {
+				common = elements
+				continue
+			}
+			for el := range common {
+				if !elements[el] {
+					delete(common, el)
+				}
+			}
+		}
+		want := []string{}
+		for _, el := range sortedElementNames() {
+			if common[el] && el != name {
+				want = append(want, el)
+			}
+		}
+
+		got, err := graph.MandatoryElements(testDataset, name)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if got == nil {
+			got = []string{}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: elemen wajib %v, brute force %v", name, got, want)
+		}
+	}
+}
+
+func TestMandatoryElementsInEveryPath(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	for _, bt := range benchTargets {
+		mandatory, err := graph.MandatoryElements(testDataset, bt.Element)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths, _, _ := FindKBestPaths(testDataset, bt.Element, 5)
+		dfsPaths, _, _ := FindMultiplePathsDFS(testDataset, bt.Element, 5)
+		for _, path := range append(paths, dfsPaths...) {
+			for _, el := range mandatory {
+				if !pathContains(path, el) {
+					t.Errorf("%s: elemen wajib '%s' tidak ada di jalur %v", bt.Element, el, path)
+				}
+			}
+		}
+	}
+}
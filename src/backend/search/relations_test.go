@@ -0,0 +1,52 @@
+// src/backend/search/relations_test.go
+package search
+
+import (
+	"testing"
+
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/graph"
+)
+
+func relatedSet(related []graph.RelatedElement) map[string]int {
+	set := make(map[string]int, len(related))
+	for _, el := range related {
+		set[el.Name] = el.Depth
+	}
+	return set
+}
+
+func TestAncestorsAreRequirable(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	run := func(d *dataset.Dataset, target string) ([][]dataset.Recipe, int, error) {
+		return Run(d, DefaultCostTable(), "bfs", "shortest", target, 1, nil)
+	}
+	for _, target := range []string{"Mud", "Metal", "Human"} {
+		ancestors, err := graph.Ancestors(testDataset, target, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		set := relatedSet(ancestors)
+		for _, el := range ancestors {
+			// Setiap ancestor benar-benar bisa muncul di pohon resep target
+			if _, _, err := SearchWithConstraints(testDataset, target, SearchConstraints{Require: []string{el.Name}}, run); err != nil {
+				t.Errorf("%s: ancestor '%s' tidak bisa dipaksa masuk: %v", target, el.Name, err)
+			}
+			descendants, _ := graph.Descendants(testDataset, el.Name, 0)
+			if _, ok := relatedSet(descendants)[target]; !ok {
+				t.Errorf("%s ada di ancestors %s, tetapi %s tidak ada di descendants %s", el.Name, target, target, el.Name)
+			}
+		}
+		// Sebaliknya, elemen di jalur hasil pencarian pasti ancestor
+		path, _, _ := FindPathBFS(testDataset, target)
+		for _, step := range path {
+			for _, el := range []string{step.Ingredient1, step.Ingredient2} {
+				if _, ok := set[el]; !ok {
+					t.Errorf("%s: '%s' ada di jalur BFS tetapi bukan ancestor", target, el)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// src/backend/search/run.go
+
+// Package search berisi algoritma pencarian jalur resep (BFS, DFS, BDS,
+// IDDFS, k-best, cheapest) beserta batasan, diversitas, dan validasi jalur.
+// Semua algoritma menerima *dataset.Dataset sebagai argumen.
+package search
+
+import (
+	"fmt"
+
+	"tubes2stima/backend/dataset"
+)
+
+// Run menjalankan algoritma sesuai parameter request pada dataset d; costs
+// dipakai pada mode cheapest. Pada mode shortest dan cheapest, jalur tunggal
+// dikembalikan sebagai elemen pertama.
+func Run(d *dataset.Dataset, costs *CostTable, algo, mode, targetElement string, maxRecipes int, seed *int64) ([][]dataset.Recipe, int, error) {
+	if mode == "cheapest" {
+		path, nodesVisited, err := FindCheapestPath(d, targetElement, costs)
+		if err != nil || len(path) == 0 {
+			return nil, nodesVisited, err
+		}
+		return [][]dataset.Recipe{path}, nodesVisited, nil
+	}
+	if seed != nil {
+		// Mode deterministik: hasil dapat diulang persis dengan seed yang sama
+		return FindMultiplePathsSeeded(d, algo, targetElement, maxRecipes, *seed)
+	}
+	if algo == "kbest" {
+		// k pohon resep berbeda dengan langkah paling sedikit (shortest = k 1)
+		return FindKBestPaths(d, targetElement, maxRecipes)
+	}
+
+	if mode == "multiple" {
+		switch algo {
+		case "bfs":
+			return FindMultiplePathsBFS(d, targetElement, maxRecipes)
+		case "dfs":
+			return FindMultiplePathsDFS(d, targetElement, maxRecipes)
+		case "bds":
+			return FindMultiplePathsBDS(d, targetElement, maxRecipes)
+		}
+		return nil, 0, fmt.Errorf("algoritma '%s' tidak dikenal", algo)
+	}
+
+	var path []dataset.Recipe
+	var nodesVisited int
+	var err error
+	// bfs, dfs, dan bds memakai port graf kompak (compact_search.go): jalur
+	// sama panjang dengan versi berbasis map (lihat compact_search_test.go)
+	// tetapi tanpa key string
+	switch algo {
+	case "bfs":
+		path, nodesVisited, err = FindPathBFSCompact(d, targetElement)
+	case "bfs-parallel":
+		path, nodesVisited, err = FindPathBFSParallel(d, targetElement, 0)
+	case "iddfs":
+		path, nodesVisited, err = FindPathIDDFS(d, targetElement)
+	case "dfs":
+		path, nodesVisited, err = FindPathDFSCompact(d, targetElement)
+	case "bds":
+		path, nodesVisited, err = FindPathBDSCompact(d, targetElement)
+	default:
+		return nil, 0, fmt.Errorf("algoritma '%s' tidak dikenal", algo)
+	}
+	if err != nil || len(path) == 0 {
+		return nil, nodesVisited, err
+	}
+	return [][]dataset.Recipe{path}, nodesVisited, nil
+}
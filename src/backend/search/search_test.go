@@ -0,0 +1,79 @@
+// src/backend/search/search_test.go
+package search
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+// benchTargets adalah elemen perwakilan beberapa tier dataset
+// recipes_final_filtered.json (tier dihitung dengan dataset.ElementTiers).
+var benchTargets = []struct {
+	Tier    int
+	Element string
+}{
+	{1, "Mud"},
+	{3, "Metal"},
+	{7, "Human"},
+	{10, "Internet"},
+	{15, "Picnic"},
+}
+
+// testDataset dan testCosts dimuat sekali dari data bawaan la2.
+var (
+	testDataset *dataset.Dataset
+	testCosts   *CostTable
+)
+
+func TestMain(m *testing.M) {
+	dataDir := filepath.Join("..", "data")
+	var err error
+	testDataset, _, err = dataset.LoadFile(filepath.Join(dataDir, dataset.FilteredRecipeFile), dataset.BaseElements)
+	if err == nil {
+		testCosts, err = LoadCostTable(filepath.Join(dataDir, CostTableFile), testDataset.Recipes(), dataset.BaseElements)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gagal memuat data untuk test: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// sortedElementNames mengembalikan semua elemen dataset (hasil, bahan, dan
+// elemen dasar), terurut.
+func sortedElementNames() []string {
+	names := make(map[string]bool)
+	for _, r := range testDataset.Recipes() {
+		names[r.Result] = true
+		names[r.Ingredient1] = true
+		names[r.Ingredient2] = true
+	}
+	for _, base := range dataset.BaseElements {
+		names[base] = true
+	}
+	return slices.Sorted(maps.Keys(names))
+}
+
+func benchName(tier int, element string) string {
+	return fmt.Sprintf("tier%d-%s", tier, element)
+}
+
+// silenceStdout membuang output log algoritma selama benchmark/test.
+func silenceStdout() func() {
+	original := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return func() {}
+	}
+	os.Stdout = devNull
+	return func() {
+		os.Stdout = original
+		devNull.Close()
+	}
+}
@@ -0,0 +1,97 @@
+// src/backend/search/seed.go
+package search
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"tubes2stima/backend/dataset"
+)
+
+// deterministicBFSWorkers menggantikan runtime.NumCPU()*2 pada mode deterministik.
+const deterministicBFSWorkers = 8
+
+// multiSearchOptions mengatur jalannya pencarian multiple. Nilai nol berarti
+// perilaku lama (multithreaded, urutan hasil bergantung penjadwalan goroutine).
+type multiSearchOptions struct {
+	deterministic bool
+	seed          int64
+}
+
+// orderCombinations mengurutkan kombinasi resep target berdasarkan key-nya lalu
+// mengacaknya dengan seed. Pada mode non-deterministik urutan dibiarkan.
+func (o multiSearchOptions) orderCombinations(combos []dataset.Recipe) {
+	if !o.deterministic {
+		return
+	}
+	sort.Slice(combos, func(i, j int) bool {
+		return getUniqueRecipeKey(combos[i]) < getUniqueRecipeKey(combos[j])
+	})
+	rnd := rand.New(rand.NewSource(o.seed))
+	rnd.Shuffle(len(combos), func(i, j int) {
+		combos[i], combos[j] = combos[j], combos[i]
+	})
+}
+
+// strategyOffset menggeser pembagian strategi antar worker sesuai seed.
+func (o multiSearchOptions) strategyOffset() int {
+	if !o.deterministic {
+		return 0
+	}
+	offset := int(o.seed % 5)
+	if offset < 0 {
+		offset += 5
+	}
+	return offset
+}
+
+// sortPathsCanonical mengurutkan jalur berdasarkan panjang, lalu identifier
+// jalur, sehingga urutan output stabil untuk himpunan jalur yang sama.
+func sortPathsCanonical(paths [][]dataset.Recipe) {
+	type keyedPath struct {
+		path []dataset.Recipe
+		id   string
+	}
+	keyed := make([]keyedPath, len(paths))
+	for i, path := range paths {
+		keyed[i] = keyedPath{path: path, id: PathIdentifier(path)}
+	}
+	sort.SliceStable(keyed, func(i, j int) bool {
+		if len(keyed[i].path) != len(keyed[j].path) {
+			return len(keyed[i].path) < len(keyed[j].path)
+		}
+		return keyed[i].id < keyed[j].id
+	})
+	for i := range keyed {
+		paths[i] = keyed[i].path
+	}
+}
+
+// FindMultiplePathsSeeded menjalankan pencarian multiple algorithm ("bfs",
+// "dfs", "bds", atau "kbest") pada dataset d dalam mode deterministik. seed
+// menentukan urutan acak kombinasi resep target dan pembagian strategi antar
+// worker; jalur hasil diurutkan kanonik (panjang, lalu PathIdentifier).
+// Dataset, target, maxRecipes, dan seed yang sama selalu menghasilkan
+// himpunan dan urutan jalur yang sama.
+func FindMultiplePathsSeeded(d *dataset.Dataset, algorithm, targetElement string, maxRecipes int, seed int64) ([][]dataset.Recipe, int, error) {
+	opts := multiSearchOptions{deterministic: true, seed: seed}
+	var paths [][]dataset.Recipe
+	var nodesVisited int
+	var err error
+	switch algorithm {
+	case "bfs":
+		return findMultiplePathsBFS(d, targetElement, maxRecipes, opts)
+	case "dfs":
+		paths, nodesVisited, err = findMultiplePathsDFS(d, targetElement, maxRecipes, opts)
+	case "bds":
+		paths, nodesVisited, err = findMultiplePathsBDS(d, targetElement, maxRecipes, opts)
+	case "kbest":
+		// k-best sudah deterministik dan terurut jumlah langkah
+		return FindKBestPaths(d, targetElement, maxRecipes)
+	default:
+		return nil, 0, fmt.Errorf("algoritma '%s' tidak dikenal", algorithm)
+	}
+	sortPathsCanonical(paths)
+	return paths, nodesVisited, err
+}
@@ -0,0 +1,52 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"tubes2stima/backend/dataset"
+)
+
+func TestSeededMultipleSearchIsReproducible(t *testing.T) {
+	cases := []struct {
+		algo    string
+		element string
+	}{
+		{"bfs", "Steam"},
+		{"dfs", "Life"},
+		{"dfs", "Internet"},
+		{"bds", "Human"},
+	}
+	for _, tc := range cases {
+		var first [][]dataset.Recipe
+		var firstNodes int
+		for run := 0; run < 3; run++ {
+			testDataset.ResetCache()
+			restore := silenceStdout()
+			paths, nodes, err := FindMultiplePathsSeeded(testDataset, tc.algo, tc.element, 4, 7)
+			restore()
+			if err != nil {
+				t.Fatalf("%s %s: %v", tc.algo, tc.element, err)
+			}
+			if run == 0 {
+				first, firstNodes = paths, nodes
+				continue
+			}
+			if !reflect.DeepEqual(paths, first) || nodes != firstNodes {
+				t.Errorf("%s %s run %d: hasil berbeda dari run pertama", tc.algo, tc.element, run)
+			}
+		}
+	}
+}
+
+func TestSortPathsCanonical(t *testing.T) {
+	long := []dataset.Recipe{{Result: "Lava", Ingredient1: "Earth", Ingredient2: "Fire"}, {Result: "Steam", Ingredient1: "Lava", Ingredient2: "Water"}}
+	shortB := []dataset.Recipe{{Result: "Steam", Ingredient1: "Water", Ingredient2: "Fire"}}
+	shortA := []dataset.Recipe{{Result: "Mud", Ingredient1: "Earth", Ingredient2: "Water"}}
+	paths := [][]dataset.Recipe{long, shortB, shortA}
+	sortPathsCanonical(paths)
+	want := [][]dataset.Recipe{shortA, shortB, long}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("urutan = %v, ingin %v", paths, want)
+	}
+}
@@ -0,0 +1,139 @@
+// src/backend/search/validate.go
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"tubes2stima/backend/dataset"
+)
+
+// PathValidationError berisi semua pelanggaran yang ditemukan ValidatePath.
+type PathValidationError struct {
+	Target string
+	Issues []string
+}
+
+func (e *PathValidationError) Error() string {
+	return fmt.Sprintf("jalur ke '%s' tidak valid: %s", e.Target, strings.Join(e.Issues, "; "))
+}
+
+// ValidatePath memvalidasi jalur terhadap resep milik dataset d.
+func ValidatePath(d *dataset.Dataset, path []dataset.Recipe, target string) error {
+	return validatePathWith(d.RecipeMap(), d.IsBase, path, target)
+}
+
+// validatePathWith memeriksa (dengan isBase sebagai penentu elemen dasar) bahwa:
+//   - setiap langkah adalah resep yang benar-benar ada di recipes,
+//   - urutan langkah topologis: setiap bahan adalah elemen dasar atau sudah
+//     dihasilkan oleh langkah sebelumnya,
+//   - tidak ada elemen yang dihasilkan lebih dari sekali,
+//   - target dihasilkan (atau path kosong jika target elemen dasar),
+//   - tidak ada langkah berlebih: setiap hasil selain target dipakai oleh
+//     langkah setelahnya.
+func validatePathWith(recipes map[string][]dataset.Recipe, isBase func(string) bool, path []dataset.Recipe, target string) error {
+	var issues []string
+	addIssue := func(format string, args ...interface{}) {
+		issues = append(issues, fmt.Sprintf(format, args...))
+	}
+
+	if isBase(target) {
+		if len(path) > 0 {
+			addIssue("target '%s' adalah elemen dasar tetapi jalur berisi %d langkah", target, len(path))
+		}
+		return newPathValidationError(target, issues)
+	}
+	if len(path) == 0 {
+		addIssue("jalur kosong untuk target non-dasar")
+		return newPathValidationError(target, issues)
+	}
+
+	producedAt := make(map[string]int) // elemen -> indeks langkah yang menghasilkannya
+	usedLater := make(map[string]bool) // elemen hasil yang dipakai langkah setelahnya
+
+	for i, step := range path {
+		stepNo := i + 1
+		if !recipeExists(recipes, step) {
+			addIssue("langkah %d (%s + %s => %s) bukan resep yang dikenal", stepNo, step.Ingredient1, step.Ingredient2, step.Result)
+		}
+		for _, ingredient := range []string{step.Ingredient1, step.Ingredient2} {
+			if isBase(ingredient) {
+				continue
+			}
+			if _, ok := producedAt[ingredient]; !ok {
+				addIssue("langkah %d memakai '%s' sebelum dihasilkan", stepNo, ingredient)
+				continue
+			}
+			usedLater[ingredient] = true
+		}
+		if isBase(step.Result) {
+			addIssue("langkah %d menghasilkan elemen dasar '%s'", stepNo, step.Result)
+		}
+		if previous, ok := producedAt[step.Result]; ok {
+			addIssue("'%s' dihasilkan dua kali (langkah %d dan %d)", step.Result, previous+1, stepNo)
+			continue
+		}
+		producedAt[step.Result] = i
+	}
+
+	if _, ok := producedAt[target]; !ok {
+		addIssue("target '%s' tidak pernah dihasilkan", target)
+	}
+	for i, step := range path {
+		if step.Result != target && !usedLater[step.Result] && producedAt[step.Result] == i {
+			addIssue("langkah %d (%s) berlebih: hasilnya tidak dipakai", i+1, step.Result)
+		}
+	}
+	return newPathValidationError(target, issues)
+}
+
+func newPathValidationError(target string, issues []string) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	return &PathValidationError{Target: target, Issues: issues}
+}
+
+// recipeExists memeriksa resep terhadap recipes tanpa memperhatikan urutan bahan.
+func recipeExists(recipes map[string][]dataset.Recipe, step dataset.Recipe) bool {
+	key := getUniqueRecipeKey(step)
+	for _, r := range recipes[step.Result] {
+		if getUniqueRecipeKey(r) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePath merapikan jalur yang sudah berurutan topologis menjadi satu
+// pohon resep: hanya langkah pertama yang menghasilkan suatu elemen yang
+// dipertahankan, lalu langkah yang hasilnya tidak dibutuhkan target dibuang
+// (disapu dari langkah terakhir ke awal).
+func normalizePath(path []dataset.Recipe, target string) []dataset.Recipe {
+	firstProducer := make(map[string]int, len(path))
+	for i, step := range path {
+		if _, ok := firstProducer[step.Result]; !ok {
+			firstProducer[step.Result] = i
+		}
+	}
+
+	needed := map[string]bool{target: true}
+	keep := make([]bool, len(path))
+	for i := len(path) - 1; i >= 0; i-- {
+		step := path[i]
+		if firstProducer[step.Result] != i || !needed[step.Result] {
+			continue
+		}
+		keep[i] = true
+		needed[step.Ingredient1] = true
+		needed[step.Ingredient2] = true
+	}
+
+	result := make([]dataset.Recipe, 0, len(path))
+	for i, step := range path {
+		if keep[i] {
+			result = append(result, step)
+		}
+	}
+	return result
+}
@@ -0,0 +1,219 @@
+// src/backend/search/validate_test.go
+package search
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"tubes2stima/backend/dataset"
+)
+
+func TestValidatePathRejectsBrokenPaths(t *testing.T) {
+	cases := []struct {
+		name   string
+		target string
+		path   []dataset.Recipe
+	}{
+		{"resep tidak dikenal", "Mud", []dataset.Recipe{{Result: "Mud", Ingredient1: "Fire", Ingredient2: "Fire"}}},
+		{"urutan terbalik", "Brick", []dataset.Recipe{{Result: "Brick", Ingredient1: "Mud", Ingredient2: "Fire"}, {Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}}},
+		{"target tidak dihasilkan", "Brick", []dataset.Recipe{{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}}},
+		{"langkah berlebih", "Mud", []dataset.Recipe{{Result: "Lava", Ingredient1: "Earth", Ingredient2: "Fire"}, {Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}}},
+		{"dihasilkan dua kali", "Mud", []dataset.Recipe{{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}, {Result: "Mud", Ingredient1: "Earth", Ingredient2: "Water"}}},
+		{"jalur kosong", "Mud", nil},
+		{"elemen dasar dengan langkah", "Fire", []dataset.Recipe{{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}}},
+	}
+	for _, tc := range cases {
+		if err := ValidatePath(testDataset, tc.path, tc.target); err == nil {
+			t.Errorf("%s: seharusnya tidak valid", tc.name)
+		}
+	}
+
+	if err := ValidatePath(testDataset, []dataset.Recipe{{Result: "Mud", Ingredient1: "Earth", Ingredient2: "Water"}, {Result: "Brick", Ingredient1: "Fire", Ingredient2: "Mud"}}, "Brick"); err != nil {
+		t.Errorf("jalur valid ditolak: %v", err)
+	}
+	if err := ValidatePath(testDataset, []dataset.Recipe{}, "Water"); err != nil {
+		t.Errorf("elemen dasar dengan jalur kosong ditolak: %v", err)
+	}
+}
+
+func TestCustomBaseElements(t *testing.T) {
+	restore := silenceStdout()
+	defer restore()
+
+	// Elemen dasar edisi lain: Fire bukan elemen dasar dan harus dibuat
+	d := dataset.FromRecipes([]dataset.Recipe{
+		{Result: "Fire", Ingredient1: "Sun", Ingredient2: "Wood"},
+		{Result: "Ash", Ingredient1: "Fire", Ingredient2: "Wood"},
+		{Result: "Soap", Ingredient1: "Ash", Ingredient2: "Sea"},
+	}, []string{"Wood", "Sun", "Sea"})
+	if !d.IsBase("Sun") || d.IsBase("Fire") || len(d.BaseElements()) != 3 {
+		t.Fatalf("elemen dasar dataset = %v", d.BaseElements())
+	}
+	runs := []struct{ algo, mode string }{
+		{"bfs", "shortest"}, {"bfs-parallel", "shortest"}, {"dfs", "shortest"}, {"bds", "shortest"}, {"iddfs", "shortest"},
+		{"bfs", "multiple"}, {"dfs", "multiple"}, {"bds", "multiple"}, {"kbest", "multiple"}, {"bfs", "cheapest"},
+	}
+	for _, run := range runs {
+		paths, _, err := Run(d, DefaultCostTable(), run.algo, run.mode, "Soap", 2, nil)
+		if err != nil || len(paths) == 0 {
+			t.Errorf("%s/%s: tidak ada jalur (%v)", run.algo, run.mode, err)
+			continue
+		}
+		for _, path := range paths {
+			if len(path) != 3 {
+				t.Errorf("%s/%s: jalur %v, ingin 3 langkah", run.algo, run.mode, path)
+			}
+			if err := ValidatePath(d, path, "Soap"); err != nil {
+				t.Errorf("%s/%s: %v", run.algo, run.mode, err)
+			}
+		}
+	}
+
+	// Batasan require memakai elemen dasar dataset pada graf produk
+	paths, _, err := SearchWithConstraints(d, "Soap", SearchConstraints{Require: []string{"Fire"}}, func(d *dataset.Dataset, target string) ([][]dataset.Recipe, int, error) {
+		return Run(d, nil, "bfs", "shortest", target, 1, nil)
+	})
+	if err != nil || len(paths) != 1 || ValidatePath(d, paths[0], "Soap") != nil {
+		t.Errorf("require pada elemen dasar kustom: %v (%v)", paths, err)
+	}
+}
+
+func TestNormalizePathKeepsOnlyTree(t *testing.T) {
+	path := []dataset.Recipe{
+		{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"},
+		{Result: "Lava", Ingredient1: "Earth", Ingredient2: "Fire"},
+		{Result: "Mud", Ingredient1: "Earth", Ingredient2: "Water"},
+		{Result: "Brick", Ingredient1: "Mud", Ingredient2: "Fire"},
+	}
+	got := normalizePath(path, "Brick")
+	want := []dataset.Recipe{{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}, {Result: "Brick", Ingredient1: "Mud", Ingredient2: "Fire"}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("normalizePath = %v, seharusnya %v", got, want)
+	}
+}
+
+type singleSearch struct {
+	name string
+	find func(*dataset.Dataset, string) ([]dataset.Recipe, int, error)
+}
+
+type multipleSearch struct {
+	name string
+	find func(*dataset.Dataset, string, int) ([][]dataset.Recipe, int, error)
+}
+
+// craftableElements mengembalikan semua elemen yang punya resep, terurut.
+func craftableElements() []string {
+	var elements []string
+	for _, name := range sortedElementNames() {
+		if len(testDataset.RecipeMap()[name]) > 0 {
+			elements = append(elements, name)
+		}
+	}
+	return elements
+}
+
+// Properti: setiap algoritma cepat menghasilkan jalur valid untuk SEMUA elemen.
+func TestPropertyFastSearchesProduceValidPaths(t *testing.T) {
+	searches := []singleSearch{
+		{"bfs-compact", FindPathBFSCompact},
+		{"dfs-compact", FindPathDFSCompact},
+		{"bds-compact", FindPathBDSCompact},
+		{"dfs", FindPathDFS},
+	}
+	defer silenceStdout()()
+	for _, search := range searches {
+		for _, element := range craftableElements() {
+			path, _, err := search.find(testDataset, element)
+			if err != nil {
+				t.Errorf("%s(%s): %v", search.name, element, err)
+				continue
+			}
+			if err := ValidatePath(testDataset, path, element); err != nil {
+				t.Errorf("%s: %v", search.name, err)
+			}
+		}
+	}
+}
+
+// quickConfig membatasi jumlah kasus untuk algoritma yang lambat (BFS/BDS
+// berbasis string butuh ratusan ms untuk elemen tier tinggi).
+func quickConfig(maxCount int) *quick.Config {
+	if testing.Short() {
+		maxCount = (maxCount + 2) / 3
+	}
+	return &quick.Config{MaxCount: maxCount, Rand: rand.New(rand.NewSource(26))}
+}
+
+// Properti: jalur dari BFS/BDS berbasis string valid untuk elemen acak.
+// (BFS string identik dengan BFS kompak yang sudah diuji untuk semua elemen.)
+func TestPropertySlowSearchesProduceValidPaths(t *testing.T) {
+	elements := craftableElements()
+	searches := []singleSearch{{"bfs", FindPathBFS}, {"bds", FindPathBDS}}
+	defer silenceStdout()()
+	for _, search := range searches {
+		property := func(index uint16) bool {
+			element := elements[int(index)%len(elements)]
+			path, _, err := search.find(testDataset, element)
+			if err != nil {
+				t.Logf("%s(%s): %v", search.name, element, err)
+				return false
+			}
+			if err := ValidatePath(testDataset, path, element); err != nil {
+				t.Logf("%s: %v", search.name, err)
+				return false
+			}
+			return true
+		}
+		if err := quick.Check(property, quickConfig(25)); err != nil {
+			t.Errorf("%s: %v", search.name, err)
+		}
+	}
+}
+
+// Properti: mode multiple menghasilkan jalur yang semuanya valid dan berbeda.
+func TestPropertyMultiplePathsAreValidAndDistinct(t *testing.T) {
+	elements := craftableElements()
+	searches := []struct {
+		multipleSearch
+		count int
+	}{
+		{multipleSearch{"bfs", FindMultiplePathsBFS}, 4},
+		{multipleSearch{"dfs", FindMultiplePathsDFS}, 60},
+		{multipleSearch{"bds", FindMultiplePathsBDS}, 15},
+	}
+	defer silenceStdout()()
+	for _, search := range searches {
+		property := func(index uint16, maxRecipes uint8) bool {
+			element := elements[int(index)%len(elements)]
+			limit := int(maxRecipes)%4 + 1
+			paths, _, err := search.find(testDataset, element, limit)
+			if err != nil {
+				t.Logf("%s(%s, %d): %v", search.name, element, limit, err)
+				return false
+			}
+			if len(paths) == 0 || len(paths) > limit {
+				t.Logf("%s(%s, %d): %d jalur", search.name, element, limit, len(paths))
+				return false
+			}
+			seen := make(map[string]bool)
+			for _, path := range paths {
+				if err := ValidatePath(testDataset, path, element); err != nil {
+					t.Logf("%s: %v", search.name, err)
+					return false
+				}
+				id := PathIdentifier(path)
+				if seen[id] {
+					t.Logf("%s(%s): jalur duplikat %s", search.name, element, id)
+					return false
+				}
+				seen[id] = true
+			}
+			return true
+		}
+		if err := quick.Check(property, quickConfig(search.count)); err != nil {
+			t.Errorf("%s: %v", search.name, err)
+		}
+	}
+}
@@ -0,0 +1,607 @@
+// src/backend/tui.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"tubes2stima/backend/api"
+	"tubes2stima/backend/dataset"
+	"tubes2stima/backend/search"
+)
+
+// tuiKeyKind adalah jenis tombol yang dibaca dari terminal mode raw.
+type tuiKeyKind int
+
+const (
+	tuiKeyRune tuiKeyKind = iota
+	tuiKeyUp
+	tuiKeyDown
+	tuiKeyLeft
+	tuiKeyRight
+	tuiKeyPageUp
+	tuiKeyPageDown
+	tuiKeyEnter
+	tuiKeyBackspace
+	tuiKeyTab
+	tuiKeyEsc
+	tuiKeyQuit // Ctrl-C atau Ctrl-D
+)
+
+// tuiKey adalah satu tombol; r hanya diisi untuk tuiKeyRune.
+type tuiKey struct {
+	kind tuiKeyKind
+	r    rune
+}
+
+// parseTUIKeys mengurai byte dari terminal mode raw menjadi tombol. Escape
+// sequence yang tidak dikenal dilewati; ESC tunggal dibaca sebagai tuiKeyEsc.
+func parseTUIKeys(buf []byte) []tuiKey {
+	var keys []tuiKey
+	for len(buf) > 0 {
+		switch c := buf[0]; {
+		case c == 0x1b && len(buf) == 1:
+			keys = append(keys, tuiKey{kind: tuiKeyEsc})
+			buf = buf[1:]
+		case c == 0x1b && (buf[1] == '[' || buf[1] == 'O'):
+			// CSI/SS3: parameter lalu satu byte akhir (0x40..0x7e)
+			end := 2
+			for end < len(buf) && (buf[end] < 0x40 || buf[end] > 0x7e) {
+				end++
+			}
+			if end == len(buf) {
+				return keys
+			}
+			switch seq := string(buf[2 : end+1]); seq {
+			case "A":
+				keys = append(keys, tuiKey{kind: tuiKeyUp})
+			case "B":
+				keys = append(keys, tuiKey{kind: tuiKeyDown})
+			case "C":
+				keys = append(keys, tuiKey{kind: tuiKeyRight})
+			case "D":
+				keys = append(keys, tuiKey{kind: tuiKeyLeft})
+			case "5~":
+				keys = append(keys, tuiKey{kind: tuiKeyPageUp})
+			case "6~":
+				keys = append(keys, tuiKey{kind: tuiKeyPageDown})
+			}
+			buf = buf[end+1:]
+		case c == 0x1b:
+			keys = append(keys, tuiKey{kind: tuiKeyEsc})
+			buf = buf[1:]
+		case c == '\r' || c == '\n':
+			keys = append(keys, tuiKey{kind: tuiKeyEnter})
+			buf = buf[1:]
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, tuiKey{kind: tuiKeyBackspace})
+			buf = buf[1:]
+		case c == '\t':
+			keys = append(keys, tuiKey{kind: tuiKeyTab})
+			buf = buf[1:]
+		case c == 0x03 || c == 0x04:
+			keys = append(keys, tuiKey{kind: tuiKeyQuit})
+			buf = buf[1:]
+		default:
+			r, size := utf8.DecodeRune(buf)
+			if unicode.IsPrint(r) {
+				keys = append(keys, tuiKey{kind: tuiKeyRune, r: r})
+			}
+			buf = buf[size:]
+		}
+	}
+	return keys
+}
+
+// tuiScreen adalah layar yang sedang aktif.
+type tuiScreen int
+
+const (
+	tuiScreenSearch  tuiScreen = iota // Pencarian nama elemen (type-ahead)
+	tuiScreenElement                  // Resep dan pemakaian satu elemen
+	tuiScreenResult                   // Hasil BFS/DFS/BDS dan pohon resep
+)
+
+// tuiAlgorithms adalah algoritma yang dijalankan berdampingan di layar hasil.
+var tuiAlgorithms = []struct {
+	name string
+	find func(d *dataset.Dataset, target string) ([]dataset.Recipe, int, error)
+}{
+	{"BFS", search.FindPathBFS},
+	{"DFS", search.FindPathDFS},
+	{"BDS", search.FindPathBDS},
+}
+
+// tuiAlgorithmRun adalah hasil satu algoritma untuk target di layar hasil.
+type tuiAlgorithmRun struct {
+	name         string
+	path         []dataset.Recipe
+	nodesVisited int
+	duration     time.Duration
+	err          error
+}
+
+// tuiElementItem adalah satu baris di layar elemen: resep yang membuat
+// elemen, atau resep yang memakai elemen sebagai bahan (usage).
+type tuiElementItem struct {
+	recipe dataset.Recipe
+	usage  bool
+}
+
+// tuiTreeLine adalah satu baris pohon resep yang terlihat. id adalah jalur
+// nama dari akar ("Human/Clay/Mud") agar elemen yang sama di cabang berbeda
+// bisa dibuka-tutup sendiri-sendiri.
+type tuiTreeLine struct {
+	id       string
+	name     string
+	depth    int
+	step     *dataset.Recipe // nil untuk elemen dasar
+	expanded bool
+}
+
+// tuiModel adalah state penjelajah terminal. Update mengubah state per
+// tombol dan View merender state menjadi baris teks, sehingga keduanya bisa
+// diuji tanpa terminal.
+type tuiModel struct {
+	game          *api.GameData
+	names         []string
+	width, height int
+	screen        tuiScreen
+	quit          bool
+
+	query        string
+	matches      []string
+	searchCursor int
+
+	element       string
+	history       []string // Elemen yang dibuka sebelumnya (untuk Esc)
+	items         []tuiElementItem
+	elementCursor int
+
+	runs        []tuiAlgorithmRun
+	selectedRun int
+	expanded    map[string]bool
+	lines       []tuiTreeLine
+	treeCursor  int
+}
+
+func newTUIModel(g *api.GameData) *tuiModel {
+	m := &tuiModel{game: g, names: g.ElementNames(), width: 80, height: 24}
+	m.updateMatches()
+	return m
+}
+
+// updateMatches mengisi matches dengan elemen yang cocok dengan query:
+// awalan (tanpa membedakan huruf besar/kecil) lebih dulu, lalu substring.
+func (m *tuiModel) updateMatches() {
+	query := strings.ToLower(m.query)
+	var prefix, contains []string
+	for _, name := range m.names {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, query) {
+			prefix = append(prefix, name)
+		} else if strings.Contains(lower, query) {
+			contains = append(contains, name)
+		}
+	}
+	m.matches = append(prefix, contains...)
+	m.searchCursor = 0
+}
+
+// openElement menampilkan resep dan pemakaian name. push menyimpan elemen
+// saat ini di history.
+func (m *tuiModel) openElement(name string, push bool) {
+	if push && m.element != "" && m.screen == tuiScreenElement {
+		m.history = append(m.history, m.element)
+	}
+	d := m.game.Dataset()
+	m.element = name
+	m.items = m.items[:0]
+	recipes := append([]dataset.Recipe(nil), d.RecipeMap()[name]...)
+	usages := append([]dataset.Recipe(nil), d.Graph()[name]...)
+	sortRecipes(recipes)
+	sortRecipes(usages)
+	for _, r := range recipes {
+		m.items = append(m.items, tuiElementItem{recipe: r})
+	}
+	for i, r := range usages {
+		// Resep A + A muncul dua kali di adjacency list bahan A
+		if i > 0 && r == usages[i-1] {
+			continue
+		}
+		m.items = append(m.items, tuiElementItem{recipe: r, usage: true})
+	}
+	m.elementCursor = 0
+	m.screen = tuiScreenElement
+}
+
+func sortRecipes(recipes []dataset.Recipe) {
+	sort.Slice(recipes, func(i, j int) bool {
+		a, b := recipes[i], recipes[j]
+		if a.Result != b.Result {
+			return a.Result < b.Result
+		}
+		if a.Ingredient1 != b.Ingredient1 {
+			return a.Ingredient1 < b.Ingredient1
+		}
+		return a.Ingredient2 < b.Ingredient2
+	})
+}
+
+// followItem mengembalikan elemen yang dibuka dari baris item: hasil untuk
+// pemakaian, atau bahan pertama yang bukan elemen dasar untuk resep.
+func (m *tuiModel) followItem(item tuiElementItem) string {
+	if item.usage {
+		return item.recipe.Result
+	}
+	for _, ingredient := range []string{item.recipe.Ingredient1, item.recipe.Ingredient2} {
+		if !m.game.Dataset().IsBase(ingredient) && ingredient != m.element {
+			return ingredient
+		}
+	}
+	return ""
+}
+
+// runSearches menjalankan semua tuiAlgorithms untuk elemen saat ini. Cache
+// BFS dikosongkan sebelum setiap algoritma agar waktu bisa dibandingkan.
+func (m *tuiModel) runSearches() {
+	d := m.game.Dataset()
+	m.runs = m.runs[:0]
+	for _, algo := range tuiAlgorithms {
+		d.ResetCache()
+		restore := silenceStdout()
+		start := time.Now()
+		path, nodesVisited, err := algo.find(d, m.element)
+		duration := time.Since(start)
+		restore()
+		m.runs = append(m.runs, tuiAlgorithmRun{name: algo.name, path: path, nodesVisited: nodesVisited, duration: duration, err: err})
+	}
+	m.selectRun(0)
+	m.screen = tuiScreenResult
+}
+
+// selectRun memilih pohon hasil yang ditampilkan; akar langsung dibuka.
+func (m *tuiModel) selectRun(i int) {
+	m.selectedRun = i
+	m.expanded = map[string]bool{m.element: true}
+	m.treeCursor = 0
+	m.rebuildTree()
+}
+
+// rebuildTree menyusun ulang baris pohon yang terlihat dari state expanded.
+func (m *tuiModel) rebuildTree() {
+	m.lines = m.lines[:0]
+	stepFor, _ := api.RecipeTreeSteps(m.runs[m.selectedRun].path, m.element)
+	var visit func(name, id string, depth int)
+	visit = func(name, id string, depth int) {
+		line := tuiTreeLine{id: id, name: name, depth: depth, expanded: m.expanded[id]}
+		if step, ok := stepFor[name]; ok && !m.game.Dataset().IsBase(name) && depth <= len(stepFor) {
+			line.step = &step
+		}
+		m.lines = append(m.lines, line)
+		if line.step != nil && line.expanded {
+			visit(line.step.Ingredient1, id+"/"+line.step.Ingredient1, depth+1)
+			visit(line.step.Ingredient2, id+"/"+line.step.Ingredient2, depth+1)
+		}
+	}
+	visit(m.element, m.element, 0)
+	m.treeCursor = min(m.treeCursor, len(m.lines)-1)
+}
+
+// setExpandedAll membuka atau menutup semua node pohon hasil.
+func (m *tuiModel) setExpandedAll(open bool) {
+	m.expanded = map[string]bool{m.element: true}
+	if open {
+		stepFor, _ := api.RecipeTreeSteps(m.runs[m.selectedRun].path, m.element)
+		var visit func(name, id string, depth int)
+		visit = func(name, id string, depth int) {
+			step, ok := stepFor[name]
+			if !ok || m.game.Dataset().IsBase(name) || depth > len(stepFor) {
+				return
+			}
+			m.expanded[id] = true
+			visit(step.Ingredient1, id+"/"+step.Ingredient1, depth+1)
+			visit(step.Ingredient2, id+"/"+step.Ingredient2, depth+1)
+		}
+		visit(m.element, m.element, 0)
+	}
+	m.treeCursor = 0
+	m.rebuildTree()
+}
+
+// pageSize adalah jumlah baris yang dilompati PgUp/PgDn.
+func (m *tuiModel) pageSize() int {
+	return max(1, m.height-8)
+}
+
+// moveCursor menggeser cursor sesuai tombol navigasi dalam rentang [0, n).
+func (m *tuiModel) moveCursor(cursor *int, n int, key tuiKey) bool {
+	switch key.kind {
+	case tuiKeyUp:
+		*cursor--
+	case tuiKeyDown:
+		*cursor++
+	case tuiKeyPageUp:
+		*cursor -= m.pageSize()
+	case tuiKeyPageDown:
+		*cursor += m.pageSize()
+	default:
+		return false
+	}
+	*cursor = max(0, min(*cursor, n-1))
+	return true
+}
+
+// Update menerapkan satu tombol ke state.
+func (m *tuiModel) Update(key tuiKey) {
+	if key.kind == tuiKeyQuit {
+		m.quit = true
+		return
+	}
+	switch m.screen {
+	case tuiScreenSearch:
+		m.updateSearch(key)
+	case tuiScreenElement:
+		m.updateElement(key)
+	case tuiScreenResult:
+		m.updateResult(key)
+	}
+}
+
+func (m *tuiModel) updateSearch(key tuiKey) {
+	if m.moveCursor(&m.searchCursor, len(m.matches), key) {
+		return
+	}
+	switch key.kind {
+	case tuiKeyRune:
+		m.query += string(key.r)
+		m.updateMatches()
+	case tuiKeyBackspace:
+		if m.query != "" {
+			_, size := utf8.DecodeLastRuneInString(m.query)
+			m.query = m.query[:len(m.query)-size]
+			m.updateMatches()
+		}
+	case tuiKeyEsc:
+		if m.query == "" {
+			m.quit = true
+			return
+		}
+		m.query = ""
+		m.updateMatches()
+	case tuiKeyEnter, tuiKeyTab:
+		if len(m.matches) == 0 {
+			return
+		}
+		m.history = m.history[:0]
+		m.openElement(m.matches[m.searchCursor], false)
+		if key.kind == tuiKeyTab {
+			m.runSearches()
+		}
+	}
+}
+
+func (m *tuiModel) updateElement(key tuiKey) {
+	if m.moveCursor(&m.elementCursor, len(m.items), key) {
+		return
+	}
+	switch {
+	case key.kind == tuiKeyEnter || key.kind == tuiKeyRight:
+		if len(m.items) == 0 {
+			return
+		}
+		if next := m.followItem(m.items[m.elementCursor]); next != "" {
+			m.openElement(next, true)
+		}
+	case key.kind == tuiKeyTab || key.r == 'r':
+		m.runSearches()
+	case key.kind == tuiKeyEsc || key.kind == tuiKeyBackspace || key.kind == tuiKeyLeft:
+		if len(m.history) == 0 {
+			m.screen = tuiScreenSearch
+			return
+		}
+		previous := m.history[len(m.history)-1]
+		m.history = m.history[:len(m.history)-1]
+		m.openElement(previous, false)
+	case key.r == '/':
+		m.screen = tuiScreenSearch
+	case key.r == 'q':
+		m.quit = true
+	}
+}
+
+func (m *tuiModel) updateResult(key tuiKey) {
+	if m.moveCursor(&m.treeCursor, len(m.lines), key) {
+		return
+	}
+	line := m.lines[m.treeCursor]
+	switch {
+	case key.kind == tuiKeyEnter || key.r == ' ':
+		if line.step != nil {
+			m.expanded[line.id] = !line.expanded
+			m.rebuildTree()
+		}
+	case key.kind == tuiKeyRight:
+		if line.step != nil && !line.expanded {
+			m.expanded[line.id] = true
+			m.rebuildTree()
+		}
+	case key.kind == tuiKeyLeft:
+		if line.step != nil && line.expanded {
+			m.expanded[line.id] = false
+			m.rebuildTree()
+			return
+		}
+		// Node sudah tertutup: pindah ke induknya
+		parent := line.id[:max(0, strings.LastIndex(line.id, "/"))]
+		for i, l := range m.lines {
+			if l.id == parent {
+				m.treeCursor = i
+			}
+		}
+	case key.kind == tuiKeyTab:
+		m.selectRun((m.selectedRun + 1) % len(m.runs))
+	case key.r >= '1' && int(key.r-'1') < len(m.runs):
+		m.selectRun(int(key.r - '1'))
+	case key.r == 'e':
+		m.setExpandedAll(true)
+	case key.r == 'c':
+		m.setExpandedAll(false)
+	case key.r == 'o':
+		m.openElement(line.name, false)
+		m.history = m.history[:0]
+	case key.kind == tuiKeyEsc || key.kind == tuiKeyBackspace:
+		m.screen = tuiScreenElement
+	case key.r == 'q':
+		m.quit = true
+	}
+}
+
+// tuiList merender rows dengan penanda "> " pada baris selected dan
+// menggulir agar baris tersebut selalu terlihat dalam height baris.
+func tuiList(rows []string, selected, height int) []string {
+	height = max(1, height)
+	start := max(0, min(selected-height+1+height/2, len(rows)-height))
+	end := min(len(rows), start+height)
+	out := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		marker := "  "
+		if i == selected {
+			marker = "> "
+		}
+		out = append(out, marker+rows[i])
+	}
+	return out
+}
+
+// View merender state sebagai baris teks selebar m.width.
+func (m *tuiModel) View() []string {
+	lines := []string{fmt.Sprintf("%s - penjelajah resep (%d elemen)", m.game.Edition.Title, len(m.names)), ""}
+	var help string
+	switch m.screen {
+	case tuiScreenSearch:
+		lines = append(lines, "Cari: "+m.query+"_", fmt.Sprintf("%d elemen cocok", len(m.matches)))
+		lines = append(lines, tuiList(m.matches, m.searchCursor, m.height-len(lines)-2)...)
+		help = "ketik untuk mencari  ↑/↓ pilih  Enter buka  Tab cari jalur  Esc hapus/keluar"
+
+	case tuiScreenElement:
+		header := "Elemen: " + m.element
+		if m.game.Dataset().IsBase(m.element) {
+			header += " (elemen dasar)"
+		}
+		recipes := 0
+		var rows []string
+		for _, item := range m.items {
+			if item.usage {
+				rows = append(rows, fmt.Sprintf("dipakai: %s + %s = %s", item.recipe.Ingredient1, item.recipe.Ingredient2, item.recipe.Result))
+			} else {
+				recipes++
+				rows = append(rows, fmt.Sprintf("resep  : %s + %s", item.recipe.Ingredient1, item.recipe.Ingredient2))
+			}
+		}
+		lines = append(lines, header, fmt.Sprintf("%d resep, dipakai di %d resep", recipes, len(m.items)-recipes))
+		lines = append(lines, tuiList(rows, m.elementCursor, m.height-len(lines)-2)...)
+		help = "↑/↓ pilih  Enter buka elemen  r/Tab jalankan BFS/DFS/BDS  Esc kembali  / cari  q keluar"
+
+	case tuiScreenResult:
+		lines = append(lines, "Target: "+m.element, fmt.Sprintf("    %-4s %8s %12s %8s  %s", "Algo", "Node", "Waktu", "Langkah", "Status"))
+		for i, run := range m.runs {
+			marker := " "
+			if i == m.selectedRun {
+				marker = "*"
+			}
+			status := "ditemukan"
+			if run.err != nil {
+				status = run.err.Error()
+			} else if len(run.path) == 0 && !m.game.Dataset().IsBase(m.element) {
+				status = "tidak ditemukan"
+			}
+			lines = append(lines, fmt.Sprintf("%s %d %-4s %8d %12s %8d  %s", marker, i+1, run.name, run.nodesVisited, run.duration.Round(time.Microsecond), len(run.path), status))
+		}
+		lines = append(lines, "", "Pohon "+m.runs[m.selectedRun].name+":")
+		rows := make([]string, len(m.lines))
+		for i, line := range m.lines {
+			icon, detail := "•", ""
+			if line.step != nil {
+				icon = "▸"
+				if line.expanded {
+					icon = "▾"
+				}
+				detail = " = " + line.step.Ingredient1 + " + " + line.step.Ingredient2
+			}
+			rows[i] = strings.Repeat("  ", line.depth) + icon + " " + line.name + detail
+		}
+		lines = append(lines, tuiList(rows, m.treeCursor, m.height-len(lines)-2)...)
+		help = "↑/↓ pilih  Enter/←/→ buka-tutup  e/c semua  Tab/1-3 algoritma  o buka elemen  Esc kembali  q keluar"
+	}
+
+	for len(lines) < m.height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines[:max(0, m.height-1)], help)
+	for i, line := range lines {
+		lines[i] = truncateRunes(line, m.width)
+	}
+	return lines
+}
+
+// truncateRunes memotong s menjadi paling banyak width rune.
+func truncateRunes(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:max(0, width)])
+}
+
+// runTUI menjalankan penjelajah interaktif untuk game g di terminal in/out
+// sampai pengguna keluar.
+func runTUI(g *api.GameData, in, out *os.File) error {
+	restoreTerminal, err := enableRawMode(int(in.Fd()))
+	if err != nil {
+		return fmt.Errorf("gagal mengaktifkan mode raw terminal: %w", err)
+	}
+	defer restoreTerminal()
+	// Log algoritma akan merusak tampilan layar
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	// Layar alternatif dan kursor disembunyikan selama TUI berjalan
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	m := newTUIModel(g)
+	buf := make([]byte, 256)
+	for !m.quit {
+		if width, height, err := terminalSize(int(out.Fd())); err == nil && width > 0 && height > 0 {
+			m.width, m.height = width, height
+		}
+		var screen strings.Builder
+		screen.WriteString("\x1b[H")
+		for i, line := range m.View() {
+			if i > 0 {
+				screen.WriteString("\r\n")
+			}
+			screen.WriteString(line + "\x1b[K")
+		}
+		screen.WriteString("\x1b[J")
+		if _, err := io.WriteString(out, screen.String()); err != nil {
+			return err
+		}
+
+		n, err := in.Read(buf)
+		if err != nil {
+			return err
+		}
+		for _, key := range parseTUIKeys(buf[:n]) {
+			m.Update(key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+//go:build linux
+
+// src/backend/tui_term_linux.go
+package main
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// enableRawMode mematikan echo, mode kanonik, dan sinyal keyboard terminal
+// fd (setara cfmakeraw). Fungsi yang dikembalikan memulihkan mode semula.
+func enableRawMode(fd int) (func(), error) {
+	var original syscall.Termios
+	if err := termiosIoctl(fd, syscall.TCGETS, &original); err != nil {
+		return nil, err
+	}
+	raw := original
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Oflag &^= syscall.OPOST
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := termiosIoctl(fd, syscall.TCSETS, &raw); err != nil {
+		return nil, err
+	}
+	return func() { termiosIoctl(fd, syscall.TCSETS, &original) }, nil
+}
+
+func termiosIoctl(fd int, request uintptr, termios *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(unsafe.Pointer(termios))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// terminalSize mengembalikan jumlah kolom dan baris terminal fd.
+func terminalSize(fd int) (int, int, error) {
+	var size struct{ rows, cols, xpixel, ypixel uint16 }
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&size))); errno != 0 {
+		return 0, 0, errno
+	}
+	return int(size.cols), int(size.rows), nil
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+// src/backend/tui_term_other.go
+package main
+
+import "errors"
+
+// errRawModeUnsupported dikembalikan di luar Linux: mode raw terminal hanya
+// diimplementasikan dengan ioctl termios Linux (lihat tui_term_linux.go).
+var errRawModeUnsupported = errors.New("mode raw terminal hanya didukung di Linux")
+
+func enableRawMode(fd int) (func(), error) {
+	return nil, errRawModeUnsupported
+}
+
+func terminalSize(fd int) (int, int, error) {
+	return 0, 0, errRawModeUnsupported
+}
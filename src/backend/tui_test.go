@@ -0,0 +1,79 @@
+// src/backend/tui_test.go
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"tubes2stima/backend/api"
+	"tubes2stima/backend/dataset"
+)
+
+func TestParseTUIKeys(t *testing.T) {
+	got := parseTUIKeys([]byte("Hé\x1b[A\x1b[B\x1b[5~\x1b[1;5C\r\x7f\t\x03\x1b"))
+	want := []tuiKey{
+		{kind: tuiKeyRune, r: 'H'}, {kind: tuiKeyRune, r: 'é'},
+		{kind: tuiKeyUp}, {kind: tuiKeyDown}, {kind: tuiKeyPageUp},
+		{kind: tuiKeyEnter}, {kind: tuiKeyBackspace}, {kind: tuiKeyTab}, {kind: tuiKeyQuit},
+		{kind: tuiKeyEsc},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTUIKeys = %v, ingin %v", got, want)
+	}
+}
+
+func TestTUIModel(t *testing.T) {
+	g, err := api.GetGame(dataset.DefaultGame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newTUIModel(g)
+	press := func(input string) {
+		for _, key := range parseTUIKeys([]byte(input)) {
+			m.Update(key)
+		}
+	}
+	view := func() string { return strings.Join(m.View(), "\n") }
+
+	press("mu")
+	if len(m.matches) == 0 || m.matches[0] != "Mud" {
+		t.Fatalf("matches untuk %q = %v, ingin Mud lebih dulu", m.query, m.matches)
+	}
+	press("\r")
+	if m.screen != tuiScreenElement || !strings.Contains(view(), "resep  : Water + Earth") {
+		t.Fatalf("layar elemen Mud:\n%s", view())
+	}
+
+	press("\t")
+	if m.screen != tuiScreenResult || len(m.runs) != len(tuiAlgorithms) {
+		t.Fatalf("layar hasil: screen = %d, %d algoritma", m.screen, len(m.runs))
+	}
+	for _, run := range m.runs {
+		if run.err != nil || len(run.path) != 1 {
+			t.Errorf("%s: path = %v, err = %v", run.name, run.path, run.err)
+		}
+	}
+	for _, want := range []string{"1 BFS", "2 DFS", "3 BDS", "> ▾ Mud = Water + Earth", "• Water"} {
+		if !strings.Contains(view(), want) {
+			t.Errorf("layar hasil tidak berisi %q:\n%s", want, view())
+		}
+	}
+	press("\x1b[D")
+	if len(m.lines) != 1 {
+		t.Errorf("setelah ditutup %d baris pohon, ingin 1", len(m.lines))
+	}
+
+	press("\x1b\x1b")
+	if m.screen != tuiScreenSearch {
+		t.Errorf("Esc dari layar elemen: screen = %d", m.screen)
+	}
+	press("\x1b")
+	if m.query != "" || m.quit {
+		t.Errorf("Esc pertama harus menghapus query: query = %q, quit = %v", m.query, m.quit)
+	}
+	press("\x1b")
+	if !m.quit {
+		t.Error("Esc dengan query kosong harus keluar")
+	}
+}
@@ -21,6 +21,7 @@ console.log("Frontend: Using API_BASE_URL:", API_BASE_URL);
  * @param {string} mode Mode ('shortest' atau 'multiple')
  * @param {number} [maxRecipes] Jumlah maksimal resep (hanya untuk mode 'multiple')
  * @returns {Promise<object>} Promise yang resolve dengan data JSON dari API
+ *   (skema SearchResponse di /api/openapi.json)
  */
 async function findRecipes(target, algo, mode, maxRecipes) {
   const params = new URLSearchParams({ target, algo, mode });